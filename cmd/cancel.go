@@ -15,17 +15,23 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
-	"github.com/pulumi/pulumi/pkg/backend/httpstate"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 )
 
+// updateCanceler is implemented by backends that are able to cancel a stack's currently running update.
+type updateCanceler interface {
+	CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error
+}
+
 func newCancelCmd() *cobra.Command {
 	var yes bool
 	var cmd = &cobra.Command{
@@ -39,7 +45,11 @@ func newCancelCmd() *cobra.Command {
 			"inconsistent state if a resource operation was pending when the update was canceled.\n" +
 			"\n" +
 			"After this command completes successfully, the stack will be ready for further\n" +
-			"updates.",
+			"updates.\n" +
+			"\n" +
+			"For stacks that use the local backend, this command removes the lock held by the\n" +
+			"current update, which is useful if that update's process exited without releasing it.\n" +
+			"A lock held by an update that is still running on this machine is not removed.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			// Use the stack provided or, if missing, default to the current one.
 			stack := ""
@@ -56,10 +66,10 @@ func newCancelCmd() *cobra.Command {
 				return err
 			}
 
-			// Ensure that we are targeting the Pulumi cloud.
-			backend, ok := s.Backend().(httpstate.Backend)
+			// Ensure that the stack's backend supports cancellation.
+			be, ok := s.Backend().(updateCanceler)
 			if !ok {
-				return errors.New("the `cancel` command is not supported for local stacks")
+				return errors.New("the `cancel` command is not supported by this stack's backend")
 			}
 
 			// Ensure the user really wants to do this.
@@ -70,7 +80,7 @@ func newCancelCmd() *cobra.Command {
 			}
 
 			// Cancel the update.
-			if err := backend.CancelCurrentUpdate(commandContext(), s.Ref()); err != nil {
+			if err := be.CancelCurrentUpdate(commandContext(), s.Ref()); err != nil {
 				return err
 			}
 
@@ -30,6 +30,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
@@ -77,10 +78,20 @@ func newConfigCmd() *cobra.Command {
 }
 
 func newConfigGetCmd(stack *string) *cobra.Command {
+	var path bool
+
 	getCmd := &cobra.Command{
 		Use:   "get <key>",
 		Short: "Get a single configuration value",
-		Args:  cmdutil.SpecificArgs([]string{"key"}),
+		Long: "Get a single configuration value.\n" +
+			"\n" +
+			"The `--path` flag can be used to get a value inside a map or list:\n" +
+			"\n" +
+			"  - `pulumi config get --path outer.inner` will get the value of the `inner` key, " +
+			"if the value of `outer` is a map `inner: value`.\n" +
+			"  - `pulumi config get --path names[0]` will get the value of the first item, " +
+			"if the value of `names` is a list.",
+		Args: cmdutil.SpecificArgs([]string{"key"}),
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -91,23 +102,36 @@ func newConfigGetCmd(stack *string) *cobra.Command {
 				return err
 			}
 
-			key, err := parseConfigKey(args[0])
+			key, keyPath, err := parseConfigKeyPath(args[0], path)
 			if err != nil {
 				return errors.Wrap(err, "invalid configuration key")
 			}
 
-			return getConfig(s, key)
+			return getConfig(s, key, keyPath)
 		}),
 	}
+	getCmd.PersistentFlags().BoolVar(
+		&path, "path", false,
+		"The key contains a path to a property in a map or list to get")
 
 	return getCmd
 }
 
 func newConfigRmCmd(stack *string) *cobra.Command {
+	var path bool
+
 	rmCmd := &cobra.Command{
 		Use:   "rm <key>",
 		Short: "Remove configuration value",
-		Args:  cmdutil.SpecificArgs([]string{"key"}),
+		Long: "Remove configuration value.\n" +
+			"\n" +
+			"The `--path` flag can be used to remove a value inside a map or list:\n" +
+			"\n" +
+			"  - `pulumi config rm --path outer.inner` will remove the `inner` key, " +
+			"if the value of `outer` is a map `inner: value`.\n" +
+			"  - `pulumi config rm --path names[0]` will remove the first item, " +
+			"if the value of `names` is a list.",
+		Args: cmdutil.SpecificArgs([]string{"key"}),
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -119,7 +143,7 @@ func newConfigRmCmd(stack *string) *cobra.Command {
 			}
 			stackName := s.Ref().Name()
 
-			key, err := parseConfigKey(args[0])
+			key, keyPath, err := parseConfigKeyPath(args[0], path)
 			if err != nil {
 				return errors.Wrap(err, "invalid configuration key")
 			}
@@ -130,12 +154,17 @@ func newConfigRmCmd(stack *string) *cobra.Command {
 			}
 
 			if ps.Config != nil {
-				delete(ps.Config, key)
+				if err = ps.Config.RemovePath(key, keyPath); err != nil {
+					return err
+				}
 			}
 
 			return workspace.SaveProjectStack(stackName, ps)
 		}),
 	}
+	rmCmd.PersistentFlags().BoolVar(
+		&path, "path", false,
+		"The key contains a path to a property in a map or list to remove")
 
 	return rmCmd
 }
@@ -215,13 +244,22 @@ func newConfigRefreshCmd(stack *string) *cobra.Command {
 func newConfigSetCmd(stack *string) *cobra.Command {
 	var plaintext bool
 	var secret bool
+	var path bool
 
 	setCmd := &cobra.Command{
 		Use:   "set <key> [value]",
 		Short: "Set configuration value",
 		Long: "Configuration values can be accessed when a stack is being deployed and used to configure behavior. \n" +
 			"If a value is not present on the command line, pulumi will prompt for the value. Multi-line values\n" +
-			"may be set by piping a file to standard in.",
+			"may be set by piping a file to standard in.\n" +
+			"\n" +
+			"The `--path` flag can be used to set a value inside a map or list, creating it if needed:\n" +
+			"\n" +
+			"  - `pulumi config set --path outer.inner value` will set the value of `outer` to a map " +
+			"`inner: value`.\n" +
+			"  - `pulumi config set --path 'db.replicas[0].host' value` will set the value of `db` to a map " +
+			"containing a list `replicas` whose first item is a map `host: value`.\n" +
+			"  - `pulumi config set --path '[\"key.with.dots\"]' value` will set a key containing dots.",
 		Args: cmdutil.RangeArgs(1, 2),
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
@@ -235,7 +273,7 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 			}
 			stackName := s.Ref().Name()
 
-			key, err := parseConfigKey(args[0])
+			key, keyPath, err := parseConfigKeyPath(args[0], path)
 			if err != nil {
 				return errors.Wrap(err, "invalid configuration key")
 			}
@@ -291,7 +329,9 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 				return err
 			}
 
-			ps.Config[key] = v
+			if err = ps.Config.SetPath(key, keyPath, v); err != nil {
+				return err
+			}
 
 			return workspace.SaveProjectStack(stackName, ps)
 		}),
@@ -303,6 +343,9 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 	setCmd.PersistentFlags().BoolVar(
 		&secret, "secret", false,
 		"Encrypt the value instead of storing it in plaintext")
+	setCmd.PersistentFlags().BoolVar(
+		&path, "path", false,
+		"The key contains a path to a property in a map or list to set")
 
 	return setCmd
 }
@@ -322,6 +365,30 @@ func parseConfigKey(key string) (config.Key, error) {
 	return config.ParseKey(key)
 }
 
+// parseConfigKeyPath parses a configuration key. If path is true, the key is a property path such as `outer.inner` or
+// `names[0]` whose first element names the configuration key; the remaining elements are returned as the path to a
+// value within that key's object.
+func parseConfigKeyPath(key string, path bool) (config.Key, []interface{}, error) {
+	if !path {
+		k, err := parseConfigKey(key)
+		return k, nil, err
+	}
+
+	propertyPath, err := resource.ParsePropertyPath(key)
+	if err != nil {
+		return config.Key{}, nil, err
+	}
+	name, ok := propertyPath[0].(string)
+	if !ok {
+		return config.Key{}, nil, errors.Errorf("configuration path '%s' must begin with a key name", key)
+	}
+	k, err := parseConfigKey(name)
+	if err != nil {
+		return config.Key{}, nil, err
+	}
+	return k, propertyPath[1:], nil
+}
+
 func prettyKey(k config.Key) string {
 	proj, err := workspace.DetectProject()
 	if err != nil {
@@ -390,17 +457,19 @@ func listConfig(stack backend.Stack, showSecrets bool) error {
 	return nil
 }
 
-func getConfig(stack backend.Stack, key config.Key) error {
+func getConfig(stack backend.Stack, key config.Key, path []interface{}) error {
 	ps, err := workspace.DetectProjectStack(stack.Ref().Name())
 	if err != nil {
 		return err
 	}
 
-	cfg := ps.Config
-
-	if v, ok := cfg[key]; ok {
+	v, ok, err := ps.Config.GetPath(key, path)
+	if err != nil {
+		return err
+	}
+	if ok {
 		var d config.Decrypter
-		if v.Secure() {
+		if v.ContainsSecrets() {
 			var err error
 			if d, err = backend.GetStackCrypter(stack); err != nil {
 				return errors.Wrap(err, "could not create a decrypter")
@@ -23,6 +23,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 )
 
@@ -35,6 +36,8 @@ func newDestroyCmd() *cobra.Command {
 	// Flags for engine.UpdateOptions.
 	var analyzers []string
 	var diffDisplay bool
+	var jsonDisplay bool
+	var retry deploy.RetryPolicy
 	var parallel int
 	var refresh bool
 	var showConfig bool
@@ -43,6 +46,8 @@ func newDestroyCmd() *cobra.Command {
 	var skipPreview bool
 	var suppressOutputs bool
 	var yes bool
+	var targets []string
+	var targetDependents bool
 
 	var cmd = &cobra.Command{
 		Use:        "destroy",
@@ -58,11 +63,8 @@ func newDestroyCmd() *cobra.Command {
 			"is generally irreversible and should be used with great care.",
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
-			interactive := cmdutil.Interactive()
-			if !interactive {
-				yes = true // auto-approve changes, since we cannot prompt.
-			}
-
+			// A JSON event stream is meant for other programs and so never prompts.
+			interactive := cmdutil.Interactive() && !jsonDisplay
 			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
 			if err != nil {
 				return err
@@ -76,6 +78,7 @@ func newDestroyCmd() *cobra.Command {
 				SuppressOutputs:      suppressOutputs,
 				IsInteractive:        interactive,
 				DiffDisplay:          diffDisplay,
+				JSONDisplay:          jsonDisplay,
 				Debug:                debug,
 			}
 
@@ -93,11 +96,19 @@ func newDestroyCmd() *cobra.Command {
 				return errors.Wrap(err, "gathering environment metadata")
 			}
 
+			targetURNs, err := parseResourceURNs(targets)
+			if err != nil {
+				return err
+			}
+
 			opts.Engine = engine.UpdateOptions{
-				Analyzers: analyzers,
-				Parallel:  parallel,
-				Debug:     debug,
-				Refresh:   refresh,
+				Analyzers:        analyzers,
+				Parallel:         parallel,
+				Retry:            retry,
+				Debug:            debug,
+				Refresh:          refresh,
+				Targets:          targetURNs,
+				TargetDependents: targetDependents,
 			}
 
 			_, err = s.Destroy(commandContext(), backend.UpdateOperation{
@@ -131,9 +142,21 @@ func newDestroyCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().BoolVar(
+		&jsonDisplay, "json", false,
+		"Emit the operation's events as a stream of JSON objects, one per line")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (<=1 for no parallelism)")
+	cmd.PersistentFlags().IntVar(
+		&retry.MaxAttempts, "max-attempts", defaultMaxAttempts,
+		"Attempt each resource operation at most N times if it fails with a transient error (<=1 for no retries)")
+	cmd.PersistentFlags().DurationVar(
+		&retry.Delay, "retry-delay", defaultRetryDelay,
+		"Wait this long before the first retry of a resource operation that failed with a transient error")
+	cmd.PersistentFlags().DurationVar(
+		&retry.MaxDelay, "retry-max-delay", defaultRetryMaxDelay,
+		"Wait at most this long between retries of a resource operation; the delay grows after each retry")
 	cmd.PersistentFlags().BoolVarP(
 		&refresh, "refresh", "r", false,
 		"Refresh the state of the stack's resources before this update")
@@ -152,6 +175,13 @@ func newDestroyCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&suppressOutputs, "suppress-outputs", false,
 		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().StringArrayVarP(
+		&targets, "target", "t", []string{},
+		"Specify a single resource URN to destroy. Other resources will not be destroyed. "+
+			"Multiple resources can be specified using --target urn1 --target urn2")
+	cmd.PersistentFlags().BoolVar(
+		&targetDependents, "target-dependents", false,
+		"Also destroy any resources that depend upon the resources specified with --target")
 	cmd.PersistentFlags().BoolVarP(
 		&yes, "yes", "y", false,
 		"Automatically approve and perform the destroy after previewing it")
@@ -0,0 +1,144 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+// driftExitCode is the status code with which `pulumi drift` exits if any resource has drifted.
+const driftExitCode = 2
+
+func newDriftCmd() *cobra.Command {
+	var debug bool
+	var stack string
+
+	// Flags for engine.UpdateOptions.
+	var jsonDisplay bool
+	var parallel int
+	var showSames bool
+	var suppressOutputs bool
+
+	var cmd = &cobra.Command{
+		Use:   "drift",
+		Short: "Detect resources whose actual state has drifted from the stack's state",
+		Long: "Detect resources whose actual state has drifted from the stack's state.\n" +
+			"\n" +
+			"This command reads the current state of each resource in the stack from its cloud provider and\n" +
+			"reports the properties that differ from the state recorded in the stack. Unlike `pulumi refresh`,\n" +
+			"it never modifies the stack's state.\n" +
+			"\n" +
+			fmt.Sprintf("If any resource has drifted, the command exits with status code %d. Any other failure\n",
+				driftExitCode) +
+			"exits with a different non-zero status code.\n" +
+			"\n" +
+			"The program to run is loaded from the project in the current directory. Use the `-C` or\n" +
+			"`--cwd` flag to use a different directory.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := backend.UpdateOptions{
+				PreviewOnly: true,
+				Engine: engine.UpdateOptions{
+					Parallel: parallel,
+					Debug:    debug,
+				},
+				Display: display.Options{
+					Color:             cmdutil.GetGlobalColorization(),
+					ShowSameResources: showSames,
+					SuppressOutputs:   suppressOutputs,
+					IsInteractive:     cmdutil.Interactive() && !jsonDisplay,
+					DiffDisplay:       true,
+					JSONDisplay:       jsonDisplay,
+					Debug:             debug,
+				},
+			}
+
+			s, err := requireStack(stack, false, opts.Display, false /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+
+			proj, root, err := readProject()
+			if err != nil {
+				return err
+			}
+
+			m, err := getUpdateMetadata("", root)
+			if err != nil {
+				return errors.Wrap(err, "gathering environment metadata")
+			}
+
+			changes, err := s.Refresh(commandContext(), backend.UpdateOperation{
+				Proj:   proj,
+				Root:   root,
+				M:      m,
+				Opts:   opts,
+				Scopes: cancellationScopes,
+			})
+			switch {
+			case err == context.Canceled:
+				return errors.New("drift detection cancelled")
+			case err != nil:
+				return PrintEngineError(err)
+			case changes.HasChanges():
+				drifted := 0
+				for op, count := range changes {
+					if op != deploy.OpSame {
+						drifted += count
+					}
+				}
+				return &cmdutil.ExitCodeError{
+					Code: driftExitCode,
+					Err:  errors.Errorf("%d resource(s) in stack '%s' have drifted", drifted, s.Ref()),
+				}
+			default:
+				return nil
+			}
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&debug, "debug", "d", false,
+		"Print detailed debugging output during resource operations")
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+
+	// Flags for engine.UpdateOptions.
+	cmd.PersistentFlags().BoolVar(
+		&jsonDisplay, "json", false,
+		"Emit the operation's events as a stream of JSON objects, one per line")
+	cmd.PersistentFlags().IntVarP(
+		&parallel, "parallel", "p", defaultParallel,
+		"Allow P resource operations to run in parallel at once (<=1 for no parallelism)")
+	cmd.PersistentFlags().BoolVar(
+		&showSames, "show-sames", false,
+		"Show resources that haven't drifted, alongside those that have")
+	cmd.PersistentFlags().BoolVar(
+		&suppressOutputs, "suppress-outputs", false,
+		"Suppress display of stack outputs (in case they contain sensitive values)")
+
+	return cmd
+}
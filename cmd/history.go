@@ -0,0 +1,232 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+// updateInfoJSON is the shape of the --json output of the `pulumi history` commands.
+type updateInfoJSON struct {
+	Version         int               `json:"version"`
+	Kind            string            `json:"kind"`
+	Message         string            `json:"message,omitempty"`
+	Result          string            `json:"result"`
+	StartTime       string            `json:"startTime"`
+	EndTime         string            `json:"endTime,omitempty"`
+	ResourceChanges map[string]int    `json:"resourceChanges,omitempty"`
+	Environment     map[string]string `json:"environment,omitempty"`
+	Config          map[string]string `json:"config,omitempty"`
+}
+
+func newHistoryCmd() *cobra.Command {
+	var stack string
+	var jsonOut bool
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the update history of a stack",
+		Long: "Show the update history of a stack\n" +
+			"\n" +
+			"This command lists the updates that have been applied to a stack, newest first, along with\n" +
+			"their results and the number of resources they changed. Each update is identified by its\n" +
+			"version, which may be passed to `pulumi history show` to display the update in detail, or to\n" +
+			"`pulumi state rollback` to restore the state that the update left behind.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			updates, err := getStackHistory(stack)
+			if err != nil {
+				return err
+			}
+
+			if jsonOut {
+				entries := make([]updateInfoJSON, len(updates))
+				for i, update := range updates {
+					if entries[i], err = makeUpdateInfoJSON(update); err != nil {
+						return err
+					}
+				}
+				return printJSON(entries)
+			}
+
+			if len(updates) == 0 {
+				fmt.Println("Stack has never been updated")
+				return nil
+			}
+
+			formatDirective := "%-8s %-9s %-12s %-24s %-32s %s\n"
+			fmt.Printf(formatDirective, "VERSION", "KIND", "RESULT", "TIME", "CHANGES", "MESSAGE")
+			for _, update := range updates {
+				fmt.Printf(formatDirective, strconv.Itoa(update.Version), update.Kind, update.Result,
+					humanize.Time(time.Unix(update.StartTime, 0)), formatResourceChanges(update.ResourceChanges),
+					strings.SplitN(update.Message, "\n", 2)[0])
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false, "Emit output as JSON")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "show <version>",
+		Short: "Show an update from a stack's history in detail",
+		Args:  cmdutil.ExactArgs(1),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			version, err := strconv.Atoi(args[0])
+			if err != nil {
+				return errors.Errorf("'%s' is not a valid update version", args[0])
+			}
+
+			updates, err := getStackHistory(stack)
+			if err != nil {
+				return err
+			}
+			update, ok := findUpdate(updates, version)
+			if !ok {
+				return errors.Errorf("no update with version %d exists in the stack's history", version)
+			}
+
+			info, err := makeUpdateInfoJSON(update)
+			if err != nil {
+				return err
+			}
+			if jsonOut {
+				return printJSON(info)
+			}
+
+			fmt.Printf("Version: %d\n", info.Version)
+			fmt.Printf("Kind: %s\n", info.Kind)
+			fmt.Printf("Result: %s\n", info.Result)
+			if info.Message != "" {
+				fmt.Printf("Message: %s\n", info.Message)
+			}
+			fmt.Printf("Started: %s (%s)\n", humanize.Time(time.Unix(update.StartTime, 0)), info.StartTime)
+			if update.EndTime != 0 {
+				fmt.Printf("Duration: %v\n", time.Duration(update.EndTime-update.StartTime)*time.Second)
+			}
+			fmt.Printf("Resource changes: %s\n", formatResourceChanges(update.ResourceChanges))
+			printSortedMap("Environment", info.Environment)
+			printSortedMap("Config", info.Config)
+			return nil
+		}),
+	})
+
+	return cmd
+}
+
+// getStackHistory returns the update history of the stack with the given name, or of the current stack if the name is
+// empty, newest first.
+func getStackHistory(stackName string) ([]backend.UpdateInfo, error) {
+	opts := display.Options{
+		Color: cmdutil.GetGlobalColorization(),
+	}
+	s, err := requireStack(stackName, false, opts, false /*setCurrent*/)
+	if err != nil {
+		return nil, err
+	}
+	return s.Backend().GetHistory(commandContext(), s.Ref())
+}
+
+// findUpdate returns the update with the given version from the given history, if any.
+func findUpdate(updates []backend.UpdateInfo, version int) (backend.UpdateInfo, bool) {
+	for _, update := range updates {
+		if update.Version == version {
+			return update, true
+		}
+	}
+	return backend.UpdateInfo{}, false
+}
+
+// makeUpdateInfoJSON converts an update to its JSON form. Secret configuration values are not revealed.
+func makeUpdateInfoJSON(update backend.UpdateInfo) (updateInfoJSON, error) {
+	info := updateInfoJSON{
+		Version:     update.Version,
+		Kind:        string(update.Kind),
+		Message:     update.Message,
+		Result:      string(update.Result),
+		StartTime:   time.Unix(update.StartTime, 0).UTC().Format(time.RFC3339),
+		Environment: update.Environment,
+	}
+	if update.EndTime != 0 {
+		info.EndTime = time.Unix(update.EndTime, 0).UTC().Format(time.RFC3339)
+	}
+	if len(update.ResourceChanges) > 0 {
+		info.ResourceChanges = make(map[string]int)
+		for op, count := range update.ResourceChanges {
+			info.ResourceChanges[string(op)] = count
+		}
+	}
+	if len(update.Config) > 0 {
+		info.Config = make(map[string]string)
+		decrypter := config.NewBlindingDecrypter()
+		for key, value := range update.Config {
+			v, err := value.Value(decrypter)
+			if err != nil {
+				return updateInfoJSON{}, errors.Wrap(err, "could not decrypt configuration value")
+			}
+			info.Config[prettyKey(key)] = v
+		}
+	}
+	return info, nil
+}
+
+// formatResourceChanges renders the counts of an update's resource changes in the canonical order of their operations.
+func formatResourceChanges(changes map[deploy.StepOp]int) string {
+	var parts []string
+	for _, op := range deploy.StepOps {
+		if count := changes[op]; count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count, op))
+		}
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printSortedMap prints the entries of a map under the given heading, ordered by key.
+func printSortedMap(heading string, m map[string]string) {
+	if len(m) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Printf("%s:\n", heading)
+	for _, k := range keys {
+		fmt.Printf("    %s: %s\n", k, m[k])
+	}
+}
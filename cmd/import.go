@@ -0,0 +1,167 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newImportCmd() *cobra.Command {
+	var debug bool
+	var message string
+	var stack string
+
+	// Flags for engine.UpdateOptions.
+	var diffDisplay bool
+	var retry deploy.RetryPolicy
+	var parallel int
+	var showConfig bool
+	var skipPreview bool
+	var suppressOutputs bool
+	var yes bool
+
+	var cmd = &cobra.Command{
+		Use:   "import <type> <name> <id>",
+		Short: "Import an existing resource into a stack",
+		Long: "Import an existing resource into a stack.\n" +
+			"\n" +
+			"This command reads the state of an existing cloud resource with the given type and ID and\n" +
+			"adopts it into the current stack under the given name. Once imported, the resource is fully\n" +
+			"managed by Pulumi: it will be updated or deleted by subsequent operations just like any other\n" +
+			"resource in the stack. The resource's current state becomes its desired state, so the program\n" +
+			"should be updated to describe the resource before the next update; otherwise, the resource\n" +
+			"will be deleted.\n" +
+			"\n" +
+			"The type must be a fully-qualified type token, e.g. `aws:s3/bucket:Bucket`. The resource is\n" +
+			"imported as a top-level resource managed by the default provider for its package.",
+		Args: cmdutil.SpecificArgs([]string{"type", "name", "id"}),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			typ, err := tokens.ParseTypeToken(args[0])
+			if err != nil {
+				return errors.Wrap(err, "parsing resource type")
+			}
+			if !tokens.IsQName(args[1]) {
+				return errors.Errorf("'%s' is not a valid resource name", args[1])
+			}
+			imp := deploy.Import{Type: typ, Name: tokens.QName(args[1]), ID: resource.ID(args[2])}
+
+			interactive := cmdutil.Interactive()
+			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
+			if err != nil {
+				return err
+			}
+
+			opts.Display = display.Options{
+				Color:           cmdutil.GetGlobalColorization(),
+				ShowConfig:      showConfig,
+				SuppressOutputs: suppressOutputs,
+				IsInteractive:   interactive,
+				DiffDisplay:     diffDisplay,
+				Debug:           debug,
+			}
+
+			s, err := requireStack(stack, true, opts.Display, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+
+			proj, root, err := readProject()
+			if err != nil {
+				return err
+			}
+
+			m, err := getUpdateMetadata(message, root)
+			if err != nil {
+				return errors.Wrap(err, "gathering environment metadata")
+			}
+
+			opts.Engine = engine.UpdateOptions{
+				Parallel: parallel,
+				Retry:    retry,
+				Debug:    debug,
+				Imports:  []deploy.Import{imp},
+			}
+
+			_, err = s.Update(commandContext(), backend.UpdateOperation{
+				Proj:   proj,
+				Root:   root,
+				M:      m,
+				Opts:   opts,
+				Scopes: cancellationScopes,
+			})
+			switch {
+			case err == context.Canceled:
+				return errors.New("import cancelled")
+			case err != nil:
+				return PrintEngineError(err)
+			default:
+				return nil
+			}
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&debug, "debug", "d", false,
+		"Print detailed debugging output during resource operations")
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+
+	cmd.PersistentFlags().StringVarP(
+		&message, "message", "m", "",
+		"Optional message to associate with the import operation")
+
+	// Flags for engine.UpdateOptions.
+	cmd.PersistentFlags().BoolVar(
+		&diffDisplay, "diff", false,
+		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().IntVarP(
+		&parallel, "parallel", "p", defaultParallel,
+		"Allow P resource operations to run in parallel at once (<=1 for no parallelism)")
+	cmd.PersistentFlags().IntVar(
+		&retry.MaxAttempts, "max-attempts", defaultMaxAttempts,
+		"Attempt each resource operation at most N times if it fails with a transient error (<=1 for no retries)")
+	cmd.PersistentFlags().DurationVar(
+		&retry.Delay, "retry-delay", defaultRetryDelay,
+		"Wait this long before the first retry of a resource operation that failed with a transient error")
+	cmd.PersistentFlags().DurationVar(
+		&retry.MaxDelay, "retry-max-delay", defaultRetryMaxDelay,
+		"Wait at most this long between retries of a resource operation; the delay grows after each retry")
+	cmd.PersistentFlags().BoolVar(
+		&showConfig, "show-config", false,
+		"Show configuration keys and variables")
+	cmd.PersistentFlags().BoolVar(
+		&skipPreview, "skip-preview", false,
+		"Do not perform a preview before performing the import")
+	cmd.PersistentFlags().BoolVar(
+		&suppressOutputs, "suppress-outputs", false,
+		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Automatically approve and perform the import after previewing it")
+
+	return cmd
+}
@@ -61,7 +61,16 @@ func newLoginCmd() *cobra.Command {
 			"\n" +
 			"As a shortcut, you may pass --local to use your home directory (this is an alias for file://~):\n" +
 			"\n" +
-			"    $ pulumi login --local\n",
+			"    $ pulumi login --local\n" +
+			"\n" +
+			"State may also be stored in an Amazon S3 bucket, by passing s3://<bucket>/<prefix>. For instance,\n" +
+			"\n" +
+			"    $ pulumi login s3://my-pulumi-state/prod\n" +
+			"\n" +
+			"will store your state information in the my-pulumi-state S3 bucket underneath prod/.pulumi. An\n" +
+			"S3-compatible service (such as MinIO) may be used by adding an endpoint, as in\n" +
+			"s3://<bucket>?endpoint=http://localhost:9000. Credentials are read from the environment or the\n" +
+			"shared AWS configuration in the usual way.\n",
 		Args: cmdutil.MaximumNArgs(1),
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			displayOptions := display.Options{
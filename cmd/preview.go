@@ -21,6 +21,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 )
 
@@ -33,11 +34,16 @@ func newPreviewCmd() *cobra.Command {
 	// Flags for engine.UpdateOptions.
 	var analyzers []string
 	var diffDisplay bool
+	var jsonDisplay bool
+	var savePlan string
 	var parallel int
 	var showConfig bool
 	var showReplacementSteps bool
 	var showSames bool
 	var suppressOutputs bool
+	var replaces []string
+	var targets []string
+	var targetDependents bool
 
 	var cmd = &cobra.Command{
 		Use:        "preview",
@@ -57,11 +63,24 @@ func newPreviewCmd() *cobra.Command {
 			"`--cwd` flag to use a different directory.",
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			targetURNs, err := parseResourceURNs(targets)
+			if err != nil {
+				return err
+			}
+
+			replaceURNs, err := parseResourceURNs(replaces)
+			if err != nil {
+				return err
+			}
+
 			opts := backend.UpdateOptions{
 				Engine: engine.UpdateOptions{
-					Analyzers: analyzers,
-					Parallel:  parallel,
-					Debug:     debug,
+					Analyzers:        analyzers,
+					Parallel:         parallel,
+					Debug:            debug,
+					Targets:          targetURNs,
+					TargetDependents: targetDependents,
+					ReplaceTargets:   replaceURNs,
 				},
 				Display: display.Options{
 					Color:                cmdutil.GetGlobalColorization(),
@@ -69,8 +88,9 @@ func newPreviewCmd() *cobra.Command {
 					ShowReplacementSteps: showReplacementSteps,
 					ShowSameResources:    showSames,
 					SuppressOutputs:      suppressOutputs,
-					IsInteractive:        cmdutil.Interactive(),
+					IsInteractive:        cmdutil.Interactive() && !jsonDisplay,
 					DiffDisplay:          diffDisplay,
+					JSONDisplay:          jsonDisplay,
 					Debug:                debug,
 				},
 			}
@@ -90,6 +110,13 @@ func newPreviewCmd() *cobra.Command {
 				return errors.Wrap(err, "gathering environment metadata")
 			}
 
+			// If the plan is to be saved, record the steps that the preview generates.
+			var plan *deploy.UpdatePlan
+			if savePlan != "" {
+				plan = &deploy.UpdatePlan{}
+				opts.Engine.RecordPlan = plan
+			}
+
 			changes, err := s.Preview(commandContext(), backend.UpdateOperation{
 				Proj:   proj,
 				Root:   root,
@@ -102,6 +129,8 @@ func newPreviewCmd() *cobra.Command {
 				return PrintEngineError(err)
 			case expectNop && changes != nil && changes.HasChanges():
 				return errors.New("error: no changes were expected but changes were proposed")
+			case plan != nil:
+				return writePlan(savePlan, plan, s)
 			default:
 				return nil
 			}
@@ -117,6 +146,9 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVarP(
 		&stack, "stack", "s", "",
 		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().StringVar(
+		&savePlan, "save-plan", "",
+		"Save the operations proposed by the preview to a plan file, which may be applied using `pulumi up --plan`")
 
 	cmd.PersistentFlags().StringVarP(
 		&message, "message", "m", "",
@@ -129,6 +161,9 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().BoolVar(
+		&jsonDisplay, "json", false,
+		"Emit the operation's events as a stream of JSON objects, one per line")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (<=1 for no parallelism)")
@@ -144,6 +179,17 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&suppressOutputs, "suppress-outputs", false,
 		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().StringArrayVar(
+		&replaces, "replace", []string{},
+		"Specify a single resource URN to replace, even if its provider reports no changes. "+
+			"Multiple resources can be specified using --replace urn1 --replace urn2")
+	cmd.PersistentFlags().StringArrayVarP(
+		&targets, "target", "t", []string{},
+		"Specify a single resource URN to preview. Other resources will not be changed. "+
+			"Multiple resources can be specified using --target urn1 --target urn2")
+	cmd.PersistentFlags().BoolVar(
+		&targetDependents, "target-dependents", false,
+		"Also preview any resources that depend upon the resources specified with --target")
 
 	return cmd
 }
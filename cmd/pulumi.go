@@ -153,6 +153,7 @@ func NewPulumiCmd() *cobra.Command {
 	//     - Stack Management Commands:
 	cmd.AddCommand(newStackCmd())
 	cmd.AddCommand(newConfigCmd())
+	cmd.AddCommand(newHistoryCmd())
 	//     - Service Commands:
 	cmd.AddCommand(newLoginCmd())
 	cmd.AddCommand(newLogoutCmd())
@@ -160,6 +161,8 @@ func NewPulumiCmd() *cobra.Command {
 	//     - Advanced Commands:
 	cmd.AddCommand(newCancelCmd())
 	cmd.AddCommand(newRefreshCmd())
+	cmd.AddCommand(newDriftCmd())
+	cmd.AddCommand(newImportCmd())
 	cmd.AddCommand(newStateCmd())
 	//     - Other Commands:
 	cmd.AddCommand(newLogsCmd())
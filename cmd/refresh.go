@@ -23,6 +23,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 )
 
@@ -35,6 +36,8 @@ func newRefreshCmd() *cobra.Command {
 	// Flags for engine.UpdateOptions.
 	var analyzers []string
 	var diffDisplay bool
+	var jsonDisplay bool
+	var retry deploy.RetryPolicy
 	var parallel int
 	var showConfig bool
 	var showReplacementSteps bool
@@ -57,11 +60,8 @@ func newRefreshCmd() *cobra.Command {
 			"`--cwd` flag to use a different directory.",
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
-			interactive := cmdutil.Interactive()
-			if !interactive {
-				yes = true // auto-approve changes, since we cannot prompt.
-			}
-
+			// A JSON event stream is meant for other programs and so never prompts.
+			interactive := cmdutil.Interactive() && !jsonDisplay
 			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
 			if err != nil {
 				return err
@@ -75,6 +75,7 @@ func newRefreshCmd() *cobra.Command {
 				SuppressOutputs:      suppressOutputs,
 				IsInteractive:        interactive,
 				DiffDisplay:          diffDisplay,
+				JSONDisplay:          jsonDisplay,
 				Debug:                debug,
 			}
 
@@ -96,6 +97,7 @@ func newRefreshCmd() *cobra.Command {
 			opts.Engine = engine.UpdateOptions{
 				Analyzers: analyzers,
 				Parallel:  parallel,
+				Retry:     retry,
 				Debug:     debug,
 			}
 
@@ -140,9 +142,21 @@ func newRefreshCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().BoolVar(
+		&jsonDisplay, "json", false,
+		"Emit the operation's events as a stream of JSON objects, one per line")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (<=1 for no parallelism)")
+	cmd.PersistentFlags().IntVar(
+		&retry.MaxAttempts, "max-attempts", defaultMaxAttempts,
+		"Attempt each resource operation at most N times if it fails with a transient error (<=1 for no retries)")
+	cmd.PersistentFlags().DurationVar(
+		&retry.Delay, "retry-delay", defaultRetryDelay,
+		"Wait this long before the first retry of a resource operation that failed with a transient error")
+	cmd.PersistentFlags().DurationVar(
+		&retry.MaxDelay, "retry-max-delay", defaultRetryMaxDelay,
+		"Wait at most this long between retries of a resource operation; the delay grows after each retry")
 	cmd.PersistentFlags().BoolVar(
 		&showReplacementSteps, "show-replacement-steps", false,
 		"Show detailed resource replacement creates and deletes instead of a single step")
@@ -153,6 +153,7 @@ func newStackCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(
 		&showURNs, "show-urns", "u", false, "Display each resource's Pulumi-assigned globally unique URN")
 
+	cmd.AddCommand(newStackChangeSecretsProviderCmd())
 	cmd.AddCommand(newStackExportCmd())
 	cmd.AddCommand(newStackGraphCmd())
 	cmd.AddCommand(newStackImportCmd())
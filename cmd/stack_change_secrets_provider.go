@@ -0,0 +1,75 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newStackChangeSecretsProviderCmd() *cobra.Command {
+	var stackName string
+	cmd := &cobra.Command{
+		Use:   "change-secrets-provider <provider>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Change the secrets provider for a stack",
+		Long: "Change the secrets provider for a stack.\n" +
+			"\n" +
+			"The secrets provider protects the key used to encrypt a stack's secure configuration\n" +
+			"values and the secrets in its state. This command decrypts all such values using the\n" +
+			"stack's current provider and re-encrypts them using the new one. Valid providers are:\n" +
+			"\n" +
+			"  passphrase          derive the key from a passphrase (the default)\n" +
+			"  keyfile:<path>      read a raw 32-byte key from the given file\n" +
+			"  command:<command>   generate a key and protect it with an external command, which is\n" +
+			"                      run as `<command> wrap` and `<command> unwrap` with the key on stdin\n" +
+			"\n" +
+			"Secrets providers are only supported for stacks that use the local backend.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stackName, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+
+			// Ensure that we are targeting the local backend.
+			backend, ok := s.Backend().(filestate.Backend)
+			if !ok {
+				return errors.New("the `stack change-secrets-provider` command is only supported for local stacks")
+			}
+
+			if err = backend.ChangeSecretsProvider(commandContext(), s.Ref(), args[0]); err != nil {
+				return err
+			}
+
+			fmt.Printf("Secrets provider for stack '%s' changed to '%s'.\n", s.Ref(), args[0])
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+
+	return cmd
+}
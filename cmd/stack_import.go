@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/diag"
 	"github.com/pulumi/pulumi/pkg/resource/stack"
@@ -75,7 +76,11 @@ func newStackImportCmd() *cobra.Command {
 			// We do, however, now want to unmarshal the json.RawMessage into a real, typed deployment.  We do this so
 			// we can check that the deployment doesn't contain resources from a stack other than the selected one. This
 			// catches errors wherein someone imports the wrong stack's deployment (which can seriously hork things).
-			snapshot, err := stack.DeserializeUntypedDeployment(&deployment)
+			crypter, err := backend.GetStackCrypter(s)
+			if err != nil {
+				return err
+			}
+			snapshot, err := stack.DeserializeUntypedDeployment(&deployment, crypter)
 			if err != nil {
 				switch err {
 				case stack.ErrDeploymentSchemaVersionTooOld:
@@ -121,7 +126,11 @@ func newStackImportCmd() *cobra.Command {
 
 				snapshot.PendingOperations = nil
 			}
-			bytes, err := json.Marshal(stack.SerializeDeployment(snapshot))
+			sdep, err := stack.SerializeDeployment(snapshot, crypter)
+			if err != nil {
+				return errors.Wrap(err, "could not serialize deployment")
+			}
+			bytes, err := json.Marshal(sdep)
 			if err != nil {
 				return err
 			}
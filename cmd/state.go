@@ -18,10 +18,9 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"github.com/pulumi/pulumi/pkg/util/contract"
-
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/resource"
@@ -47,6 +46,10 @@ troubleshooting a stack or when performing specific edits that otherwise would r
 
 	cmd.AddCommand(newStateDeleteCommand())
 	cmd.AddCommand(newStateUnprotectCommand())
+	cmd.AddCommand(newStateRenameCommand())
+	cmd.AddCommand(newStateMoveCommand())
+	cmd.AddCommand(newStateReparentCommand())
+	cmd.AddCommand(newStateRollbackCommand())
 	return cmd
 }
 
@@ -135,23 +138,13 @@ func runTotalStateEdit(operation func(opts display.Options, snap *deploy.Snapsho
 		return err
 	}
 
-	if cmdutil.Interactive() {
-		confirm := false
-		surveycore.DisableColor = true
-		surveycore.QuestionIcon = ""
-		surveycore.SelectFocusIcon = opts.Color.Colorize(colors.BrightGreen + ">" + colors.Reset)
-		prompt := opts.Color.Colorize(colors.Yellow + "warning" + colors.Reset + ": ")
-		prompt += "This command will edit your stack's state directly. Confirm?"
-		if err = survey.AskOne(&survey.Confirm{
-			Message: prompt,
-		}, &confirm, nil); err != nil || !confirm {
-			return errors.New("confirmation declined")
-		}
+	if err = confirmStateEdit(opts, stateEditConfirmation); err != nil {
+		return err
 	}
 
 	// The `operation` callback will mutate `snap` in-place. In order to validate the correctness of the transformation
 	// that we are doing here, we verify the integrity of the snapshot before the mutation. If the snapshot was valid
-	// before we mutated it, we'll assert that we didn't make it invalid by mutating it.
+	// before we mutated it, we'll refuse to write it back if we made it invalid by mutating it.
 	stackIsAlreadyHosed := snap.VerifyIntegrity() != nil
 	if err = operation(opts, snap); err != nil {
 		return err
@@ -159,11 +152,50 @@ func runTotalStateEdit(operation func(opts display.Options, snap *deploy.Snapsho
 
 	// If the stack is already broken, don't bother verifying the integrity here.
 	if !stackIsAlreadyHosed {
-		contract.AssertNoErrorf(snap.VerifyIntegrity(), "state edit produced an invalid snapshot")
+		if err = snap.VerifyIntegrity(); err != nil {
+			return errors.Wrap(err, "state edit produced an invalid snapshot")
+		}
 	}
 
 	// Once we've mutated the snapshot, import it back into the backend so that it can be persisted.
-	bytes, err := json.Marshal(stack.SerializeDeployment(snap))
+	return saveStackSnapshot(s, snap)
+}
+
+// stateEditConfirmation is the message with which the user is asked to confirm a direct edit to a stack's state.
+const stateEditConfirmation = "This command will edit your stack's state directly. Confirm?"
+
+// confirmStateEdit prompts the user with the given message to confirm that they wish to edit a stack's state directly,
+// if the current session is interactive.
+func confirmStateEdit(opts display.Options, message string) error {
+	if !cmdutil.Interactive() {
+		return nil
+	}
+
+	confirm := false
+	surveycore.DisableColor = true
+	surveycore.QuestionIcon = ""
+	surveycore.SelectFocusIcon = opts.Color.Colorize(colors.BrightGreen + ">" + colors.Reset)
+	prompt := opts.Color.Colorize(colors.Yellow + "warning" + colors.Reset + ": ")
+	prompt += message
+	if err := survey.AskOne(&survey.Confirm{
+		Message: prompt,
+	}, &confirm, nil); err != nil || !confirm {
+		return errors.New("confirmation declined")
+	}
+	return nil
+}
+
+// saveStackSnapshot serializes the given snapshot and imports it into the given stack, replacing its current state.
+func saveStackSnapshot(s backend.Stack, snap *deploy.Snapshot) error {
+	crypter, err := backend.GetStackCrypter(s)
+	if err != nil {
+		return err
+	}
+	sdep, err := stack.SerializeDeployment(snap, crypter)
+	if err != nil {
+		return errors.Wrap(err, "serializing deployment")
+	}
+	bytes, err := json.Marshal(sdep)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,142 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/edit"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+func newStateMoveCommand() *cobra.Command {
+	var source string
+	var dest string
+	cmd := &cobra.Command{
+		Use:   "move <urn>...",
+		Short: "Moves resources from one stack's state to another",
+		Long: `Moves resources from one stack's state to another
+
+This command moves one or more resources, along with all of their children, from the state of the source stack to
+the state of the destination stack. The URNs of the moved resources are rewritten to belong to the destination stack
+and to the project of the resources already in it. The providers used by the moved resources are copied to the
+destination stack, unless it already contains an equivalent provider.
+
+Resources can't be moved if they depend on resources that are not being moved, or if resources that are not being
+moved depend on them.`,
+		Args: cmdutil.MinimumNArgs(1),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			if dest == "" {
+				return errors.New("missing required flag: --dest")
+			}
+
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			sourceStack, err := requireStack(source, false, opts, false /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			destStack, err := requireStack(dest, false, opts, false /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			if sourceStack.Ref().String() == destStack.Ref().String() {
+				return errors.New("the source and destination stacks must differ")
+			}
+
+			sourceSnap, err := sourceStack.Snapshot(commandContext())
+			if err != nil {
+				return err
+			}
+			if sourceSnap == nil {
+				return errors.Errorf("stack '%s' has no resources to move", sourceStack.Ref())
+			}
+			destSnap, err := destStack.Snapshot(commandContext())
+			if err != nil {
+				return err
+			}
+			if destSnap == nil {
+				destSnap = deploy.NewSnapshot(deploy.Manifest{
+					Time:    time.Now(),
+					Version: version.Version,
+				}, nil, nil)
+			}
+
+			var resources []*resource.State
+			for _, arg := range args {
+				res, err := locateStackResource(opts, sourceSnap, resource.URN(arg))
+				if err != nil {
+					return err
+				}
+				resources = append(resources, res)
+			}
+
+			// The moved resources take on the project of the resources already in the destination, if any.
+			project := resources[0].URN.Project()
+			if len(destSnap.Resources) > 0 {
+				project = destSnap.Resources[0].URN.Project()
+			}
+
+			if err = confirmStateEdit(opts, stateEditConfirmation); err != nil {
+				return err
+			}
+
+			// Both snapshots must be valid before either one is written back; otherwise, the resources could be lost
+			// or duplicated.
+			sourceIsAlreadyHosed := sourceSnap.VerifyIntegrity() != nil
+			destIsAlreadyHosed := destSnap.VerifyIntegrity() != nil
+			err = edit.MoveResources(sourceSnap, destSnap, resources, destStack.Ref().Name(), project)
+			if err != nil {
+				return err
+			}
+			if !sourceIsAlreadyHosed {
+				if err = sourceSnap.VerifyIntegrity(); err != nil {
+					return errors.Wrap(err, "state edit produced an invalid source snapshot")
+				}
+			}
+			if !destIsAlreadyHosed {
+				if err = destSnap.VerifyIntegrity(); err != nil {
+					return errors.Wrap(err, "state edit produced an invalid destination snapshot")
+				}
+			}
+
+			// Write the destination first, so that a failure can't lose the moved resources.
+			if err = saveStackSnapshot(destStack, destSnap); err != nil {
+				return errors.Wrapf(err, "saving stack '%s'", destStack.Ref())
+			}
+			if err = saveStackSnapshot(sourceStack, sourceSnap); err != nil {
+				return errors.Wrapf(err, "saving stack '%s'", sourceStack.Ref())
+			}
+
+			fmt.Println("Resources moved successfully")
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&source, "source", "",
+		"The name of the stack to move resources from. Defaults to the current stack")
+	cmd.Flags().StringVar(&dest, "dest", "",
+		"The name of the stack to move resources to")
+	return cmd
+}
@@ -0,0 +1,59 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/edit"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+func newStateRenameCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rename <urn> <new-name>",
+		Short: "Renames a resource in a stack's state",
+		Long: `Renames a resource in a stack's state
+
+This command changes the name of a resource in a stack's state, rewriting its URN and all references to it. This is
+useful when a resource has been renamed in a program, and the existing resource should be adopted under its new name
+rather than replaced. The resource's children keep their names.`,
+		Args: cmdutil.ExactArgs(2),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			urn := resource.URN(args[0])
+			newName := tokens.QName(args[1])
+			if newName == "" || strings.Contains(string(newName), resource.URNNameDelimiter) {
+				return errors.Errorf("%q is not a valid resource name", newName)
+			}
+
+			err := runStateEdit(urn, func(snap *deploy.Snapshot, res *resource.State) error {
+				return edit.RenameResource(snap, res, newName)
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Println("Resource renamed successfully")
+			return nil
+		}),
+	}
+
+	return cmd
+}
@@ -0,0 +1,61 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/edit"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+func newStateReparentCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reparent <urn> <new-parent-urn>",
+		Short: "Changes the parent of a resource in a stack's state",
+		Long: `Changes the parent of a resource in a stack's state
+
+This command changes the parent of a resource in a stack's state. Because a resource's URN incorporates the types of
+its ancestors, the URNs of the resource and of all of its descendants are rewritten, along with all references to
+them.`,
+		Args: cmdutil.ExactArgs(2),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			urn := resource.URN(args[0])
+			parentURN := resource.URN(args[1])
+			err := runTotalStateEdit(func(opts display.Options, snap *deploy.Snapshot) error {
+				res, err := locateStackResource(opts, snap, urn)
+				if err != nil {
+					return err
+				}
+				parent, err := locateStackResource(opts, snap, parentURN)
+				if err != nil {
+					return err
+				}
+				return edit.ReparentResource(snap, res, parent)
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Println("Resource reparented successfully")
+			return nil
+		}),
+	}
+
+	return cmd
+}
@@ -0,0 +1,104 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newStateRollbackCommand() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "rollback <version>",
+		Short: "Restores a stack's state to the state left by an earlier update",
+		Long: `Restores a stack's state to the state left by an earlier update
+
+This command replaces the current stack's state with the checkpoint that was saved at the end of the update with the
+given version, as listed by 'pulumi history'. Only the stack's state is restored: no resources are created, updated or
+deleted, and the stack's configuration is unchanged. Rolling back is only supported by stacks in the local backend.`,
+		Args: cmdutil.ExactArgs(1),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			version, err := strconv.Atoi(args[0])
+			if err != nil {
+				return errors.Errorf("'%s' is not a valid update version", args[0])
+			}
+
+			// Rolling back discards the stack's current state, so it must be confirmed even when it cannot prompt.
+			if !cmdutil.Interactive() && !yes {
+				return errors.New("--yes must be passed in non-interactive mode")
+			}
+
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			s, err := requireCurrentStack(true, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			lb, ok := s.Backend().(filestate.Backend)
+			if !ok {
+				return errors.New("rolling back a stack's state is only supported by the local backend")
+			}
+
+			dep, err := lb.ExportDeploymentVersion(commandContext(), s.Ref(), version)
+			if err != nil {
+				return err
+			}
+
+			// Make sure that the checkpoint is valid before it replaces the stack's current state.
+			crypter, err := backend.GetStackCrypter(s)
+			if err != nil {
+				return err
+			}
+			snap, err := stack.DeserializeUntypedDeployment(dep, crypter)
+			if err != nil {
+				return errors.Wrapf(err, "reading the checkpoint of update %d", version)
+			}
+			if err = snap.VerifyIntegrity(); err != nil {
+				return errors.Wrapf(err, "the checkpoint of update %d is invalid", version)
+			}
+
+			if !yes {
+				prompt := fmt.Sprintf(
+					"This command will replace your stack's state with the state left by update %d. Confirm?", version)
+				if err = confirmStateEdit(opts, prompt); err != nil {
+					return err
+				}
+			}
+			if err = s.ImportDeployment(commandContext(), dep); err != nil {
+				return err
+			}
+			fmt.Printf("Stack state rolled back to update %d\n", version)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Skip confirmation prompts, and proceed with the rollback anyway")
+
+	return cmd
+}
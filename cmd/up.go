@@ -18,6 +18,7 @@ import (
 	"context"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
@@ -36,7 +37,10 @@ import (
 )
 
 const (
-	defaultParallel = 10
+	defaultParallel      = 10
+	defaultMaxAttempts   = 3
+	defaultRetryDelay    = 5 * time.Second
+	defaultRetryMaxDelay = time.Minute
 )
 
 // nolint: vetshadow, intentionally disabling here for cleaner err declaration/assignment.
@@ -50,6 +54,9 @@ func newUpCmd() *cobra.Command {
 	// Flags for engine.UpdateOptions.
 	var analyzers []string
 	var diffDisplay bool
+	var jsonDisplay bool
+	var planFile string
+	var retry deploy.RetryPolicy
 	var parallel int
 	var refresh bool
 	var showConfig bool
@@ -58,6 +65,9 @@ func newUpCmd() *cobra.Command {
 	var skipPreview bool
 	var suppressOutputs bool
 	var yes bool
+	var replaces []string
+	var targets []string
+	var targetDependents bool
 
 	// up implementation used when the source of the Pulumi program is in the current working directory.
 	upWorkingDirectory := func(opts backend.UpdateOptions) error {
@@ -88,11 +98,32 @@ func newUpCmd() *cobra.Command {
 			return errors.Wrap(err, "gathering environment metadata")
 		}
 
+		targetURNs, err := parseResourceURNs(targets)
+		if err != nil {
+			return err
+		}
+
+		replaceURNs, err := parseResourceURNs(replaces)
+		if err != nil {
+			return err
+		}
+
 		opts.Engine = engine.UpdateOptions{
-			Analyzers: analyzers,
-			Parallel:  parallel,
-			Debug:     debug,
-			Refresh:   refresh,
+			Analyzers:        analyzers,
+			Parallel:         parallel,
+			Retry:            retry,
+			Debug:            debug,
+			Refresh:          refresh,
+			Targets:          targetURNs,
+			TargetDependents: targetDependents,
+			ReplaceTargets:   replaceURNs,
+		}
+
+		// If a saved plan was supplied, constrain the update to the plan's operations.
+		if planFile != "" {
+			if opts.Engine.Plan, err = readPlan(planFile, s); err != nil {
+				return err
+			}
 		}
 
 		changes, err := s.Update(commandContext(), backend.UpdateOperation{
@@ -224,11 +255,19 @@ func newUpCmd() *cobra.Command {
 			return errors.Wrap(err, "gathering environment metadata")
 		}
 
+		targetURNs, err := parseResourceURNs(targets)
+		if err != nil {
+			return err
+		}
+
 		opts.Engine = engine.UpdateOptions{
-			Analyzers: analyzers,
-			Parallel:  parallel,
-			Debug:     debug,
-			Refresh:   refresh,
+			Analyzers:        analyzers,
+			Parallel:         parallel,
+			Retry:            retry,
+			Debug:            debug,
+			Refresh:          refresh,
+			Targets:          targetURNs,
+			TargetDependents: targetDependents,
 		}
 
 		// TODO for the URL case:
@@ -273,11 +312,8 @@ func newUpCmd() *cobra.Command {
 			"`--cwd` flag to use a different directory.",
 		Args: cmdutil.MaximumNArgs(1),
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
-			interactive := cmdutil.Interactive()
-			if !interactive {
-				yes = true // auto-approve changes, since we cannot prompt.
-			}
-
+			// A JSON event stream is meant for other programs and so never prompts.
+			interactive := cmdutil.Interactive() && !jsonDisplay
 			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
 			if err != nil {
 				return err
@@ -291,10 +327,14 @@ func newUpCmd() *cobra.Command {
 				SuppressOutputs:      suppressOutputs,
 				IsInteractive:        interactive,
 				DiffDisplay:          diffDisplay,
+				JSONDisplay:          jsonDisplay,
 				Debug:                debug,
 			}
 
 			if len(args) > 0 {
+				if planFile != "" {
+					return errors.New("--plan may not be used when deploying a template")
+				}
 				return upURL(args[0], opts)
 			}
 
@@ -314,6 +354,10 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().StringArrayVarP(
 		&configArray, "config", "c", []string{},
 		"Config to use during the update")
+	cmd.PersistentFlags().StringVar(
+		&planFile, "plan", "",
+		"Apply the operations from a plan file saved by `pulumi preview --save-plan`. The update fails if any "+
+			"of its operations diverge from the plan")
 
 	cmd.PersistentFlags().StringVarP(
 		&message, "message", "m", "",
@@ -326,9 +370,21 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().BoolVar(
+		&jsonDisplay, "json", false,
+		"Emit the operation's events as a stream of JSON objects, one per line")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (<=1 for no parallelism)")
+	cmd.PersistentFlags().IntVar(
+		&retry.MaxAttempts, "max-attempts", defaultMaxAttempts,
+		"Attempt each resource operation at most N times if it fails with a transient error (<=1 for no retries)")
+	cmd.PersistentFlags().DurationVar(
+		&retry.Delay, "retry-delay", defaultRetryDelay,
+		"Wait this long before the first retry of a resource operation that failed with a transient error")
+	cmd.PersistentFlags().DurationVar(
+		&retry.MaxDelay, "retry-max-delay", defaultRetryMaxDelay,
+		"Wait at most this long between retries of a resource operation; the delay grows after each retry")
 	cmd.PersistentFlags().BoolVarP(
 		&refresh, "refresh", "r", false,
 		"Refresh the state of the stack's resources before this update")
@@ -347,6 +403,17 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&suppressOutputs, "suppress-outputs", false,
 		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().StringArrayVar(
+		&replaces, "replace", []string{},
+		"Specify a single resource URN to replace, even if its provider reports no changes. "+
+			"Multiple resources can be specified using --replace urn1 --replace urn2")
+	cmd.PersistentFlags().StringArrayVarP(
+		&targets, "target", "t", []string{},
+		"Specify a single resource URN to update. Other resources will not be updated. "+
+			"Multiple resources can be specified using --target urn1 --target urn2")
+	cmd.PersistentFlags().BoolVar(
+		&targetDependents, "target-dependents", false,
+		"Also update any resources that depend upon the resources specified with --target")
 	cmd.PersistentFlags().BoolVarP(
 		&yes, "yes", "y", false,
 		"Automatically approve and perform the update after previewing it")
@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -35,6 +36,7 @@ import (
 	surveycore "gopkg.in/AlecAivazis/survey.v1/core"
 	git "gopkg.in/src-d/go-git.v4"
 
+	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/backend/filestate"
@@ -42,6 +44,9 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend/state"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/util/cancel"
 	"github.com/pulumi/pulumi/pkg/util/ciutil"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
@@ -567,6 +572,19 @@ func printJSON(v interface{}) error {
 	return nil
 }
 
+// parseResourceURNs converts the given strings to resource URNs, returning an error if any of them is malformed.
+func parseResourceURNs(urns []string) ([]resource.URN, error) {
+	var result []resource.URN
+	for _, s := range urns {
+		urn := resource.URN(s)
+		if !urn.IsValid() {
+			return nil, errors.Errorf("'%s' is not a valid resource URN", s)
+		}
+		result = append(result, urn)
+	}
+	return result, nil
+}
+
 // updateFlagsToOptions ensures that the given update flags represent a valid combination.  If so, an UpdateOptions
 // is returned with a nil-error; otherwise, the non-nil error contains information about why the combination is invalid.
 func updateFlagsToOptions(interactive, skipPreview, yes bool) (backend.UpdateOptions, error) {
@@ -580,3 +598,44 @@ func updateFlagsToOptions(interactive, skipPreview, yes bool) (backend.UpdateOpt
 		SkipPreview: skipPreview,
 	}, nil
 }
+
+// readPlan reads an update plan saved by `pulumi preview --save-plan`. Any secrets in the plan are decrypted using
+// the given stack's secrets manager.
+func readPlan(path string, s backend.Stack) (*deploy.UpdatePlan, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading plan")
+	}
+	var versioned apitype.VersionedPlan
+	if err = json.Unmarshal(bytes, &versioned); err != nil {
+		return nil, errors.Wrapf(err, "could not read plan '%s'", path)
+	}
+
+	crypter, err := backend.GetStackCrypter(s)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := stack.DeserializePlan(&versioned, crypter)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read plan '%s'", path)
+	}
+	return plan, nil
+}
+
+// writePlan saves an update plan so that it may be passed to `pulumi up --plan`. Any secrets in the plan are
+// encrypted using the given stack's secrets manager.
+func writePlan(path string, plan *deploy.UpdatePlan, s backend.Stack) error {
+	crypter, err := backend.GetStackCrypter(s)
+	if err != nil {
+		return err
+	}
+	versioned, err := stack.SerializePlan(plan, crypter)
+	if err != nil {
+		return errors.Wrap(err, "serializing plan")
+	}
+	bytes, err := json.MarshalIndent(versioned, "", "    ")
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(ioutil.WriteFile(path, bytes, 0600), "saving plan")
+}
@@ -103,6 +103,8 @@ const (
 	OperationTypeDeleting OperationType = "deleting"
 	// OperationTypeReading is the state of resources that are being read.
 	OperationTypeReading OperationType = "reading"
+	// OperationTypeImporting is the state of resources that are being imported.
+	OperationTypeImporting OperationType = "importing"
 )
 
 // OperationV1 represents an operation that the engine is performing. It consists of a Resource, which is the state
@@ -196,6 +198,12 @@ type ResourceV2 struct {
 	InitErrors []string `json:"initErrors" yaml:"initErrors,omitempty"`
 	// Provider is a reference to the provider that is associated with this resource.
 	Provider string `json:"provider,omitempty" yaml:"provider,omitempty"`
+	// CustomTimeouts overrides the default timeouts for the resource's create, update and delete operations.
+	CustomTimeouts *resource.CustomTimeouts `json:"customTimeouts,omitempty" yaml:"customTimeouts,omitempty"`
+	// DeleteBeforeReplace is set to true when this resource must be deleted before its replacement is created.
+	DeleteBeforeReplace bool `json:"deleteBeforeReplace,omitempty" yaml:"deleteBeforeReplace,omitempty"`
+	// RetainOnDelete is set to true when this resource should be dropped from the stack's state rather than deleted.
+	RetainOnDelete bool `json:"retainOnDelete,omitempty" yaml:"retainOnDelete,omitempty"`
 }
 
 // ManifestV1 captures meta-information about this checkpoint file, such as versions of binaries, etc.
@@ -224,6 +232,9 @@ type ConfigValue struct {
 	String string `json:"string"`
 	// Secret is true if this value is a secret and false otherwise.
 	Secret bool `json:"secret"`
+	// Object is true if this value is a JSON encoded object and false otherwise. Secure leaves of an object are
+	// represented as objects with a single "secure" property whose value is the base64-encoded ciphertext.
+	Object bool `json:"object,omitempty"`
 }
 
 // StackTagName is the key for the tags bag in stack. This is just a string, but we use a type alias to provide a richer
@@ -0,0 +1,196 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apitype
+
+// EngineEventSchemaVersion is the current version of the EngineEvent schema. It is incremented whenever a change is
+// made to these types that existing consumers would not understand.
+const EngineEventSchemaVersion = 1
+
+// CancelEvent is emitted when the user initiates a cancellation of the update in progress, or the update successfully
+// completes.
+type CancelEvent struct{}
+
+// StdoutEngineEvent is emitted whenever a generic message is written, for example warnings from the pulumi CLI itself.
+// Less common than DiagnosticEvent.
+type StdoutEngineEvent struct {
+	Message string `json:"message"`
+	Color   string `json:"color"`
+}
+
+// DiagnosticEvent is emitted whenever a diagnostic message is provided, for example errors from a cloud resource
+// provider while trying to create or update a resource.
+type DiagnosticEvent struct {
+	URN     string `json:"urn,omitempty"`
+	Prefix  string `json:"prefix,omitempty"`
+	Message string `json:"message"`
+	Color   string `json:"color"`
+	// Severity is one of "debug", "info", "info#err", "warning", or "error".
+	Severity  string `json:"severity"`
+	StreamID  int    `json:"streamID,omitempty"`
+	Ephemeral bool   `json:"ephemeral,omitempty"`
+}
+
+// PreludeEvent is emitted at the start of an update.
+type PreludeEvent struct {
+	// IsPreview is true if the update is a preview.
+	IsPreview bool `json:"isPreview,omitempty"`
+	// Config contains the keys and values for the update. Secret configuration values are blinded.
+	Config map[string]string `json:"config"`
+}
+
+// SummaryEvent is emitted at the end of an update, with a summary of the changes made.
+type SummaryEvent struct {
+	// IsPreview is true if the update is a preview.
+	IsPreview bool `json:"isPreview,omitempty"`
+	// MaybeCorrupt is set if one or more of the resources is in an invalid state.
+	MaybeCorrupt bool `json:"maybeCorrupt"`
+	// DurationSeconds is the number of seconds the update was executing.
+	DurationSeconds int `json:"durationSeconds"`
+	// ResourceChanges contains the count of resource changes by operation.
+	ResourceChanges map[OpType]int `json:"resourceChanges"`
+	// PolicyViolations contains the policy violations reported by analyzers during the update, if any.
+	PolicyViolations []PolicyEvent `json:"policyViolations,omitempty"`
+}
+
+// PolicyEvent is emitted whenever an analyzer reports that a resource violates a policy.
+type PolicyEvent struct {
+	// ResourceURN is the URN of the resource that violated the policy, if any.
+	ResourceURN string `json:"resourceUrn,omitempty"`
+	// Analyzer is the name of the analyzer that reported the violation.
+	Analyzer string `json:"analyzer"`
+	// Property is the name of the property that violated the policy, if any.
+	Property string `json:"property,omitempty"`
+	// Message describes the violation.
+	Message string `json:"message"`
+	// EnforcementLevel is one of "advisory" or "mandatory".
+	EnforcementLevel string `json:"enforcementLevel"`
+}
+
+// StepEventMetadata describes a "step" within the Pulumi engine, which is any concrete action to migrate a set of
+// cloud resources from one state to another.
+type StepEventMetadata struct {
+	// Op is the operation being performed.
+	Op   OpType `json:"op"`
+	URN  string `json:"urn"`
+	Type string `json:"type"`
+
+	// Old is the state of the resource before performing the step.
+	Old *StepEventStateMetadata `json:"old"`
+	// New is the state of the resource after performing the step.
+	New *StepEventStateMetadata `json:"new"`
+
+	// Keys causing a replacement (only applicable for "create-replacement" and "replace" Ops).
+	Keys []string `json:"keys,omitempty"`
+	// Logical is set if the step is a logical operation in the program.
+	Logical bool `json:"logical,omitempty"`
+	// Provider is the provider actually performing the step.
+	Provider string `json:"provider"`
+	// DetailedDiff is the provider's detailed diff of the resource's properties, keyed by property path (only
+	// applicable for "update", "replace" and "create-replacement" Ops).
+	DetailedDiff map[string]PropertyDiff `json:"detailedDiff,omitempty"`
+}
+
+// DiffKind describes the kind of change made to a single property.
+type DiffKind string
+
+const (
+	// DiffAdd indicates that the property was added.
+	DiffAdd DiffKind = "add"
+	// DiffAddReplace indicates that the property was added and that this change requires a replace.
+	DiffAddReplace DiffKind = "add-replace"
+	// DiffDelete indicates that the property was removed.
+	DiffDelete DiffKind = "delete"
+	// DiffDeleteReplace indicates that the property was removed and that this change requires a replace.
+	DiffDeleteReplace DiffKind = "delete-replace"
+	// DiffUpdate indicates that the property's value was changed.
+	DiffUpdate DiffKind = "update"
+	// DiffUpdateReplace indicates that the property's value was changed and that this change requires a replace.
+	DiffUpdateReplace DiffKind = "update-replace"
+)
+
+// PropertyDiff describes the change made to a single property.
+type PropertyDiff struct {
+	Kind DiffKind `json:"kind"`
+}
+
+// StepEventStateMetadata is the more detailed state information for a resource as it relates to a step being
+// performed.
+type StepEventStateMetadata struct {
+	Type string `json:"type"`
+	URN  string `json:"urn"`
+
+	// Custom indicates if the resource is managed by a plugin.
+	Custom bool `json:"custom,omitempty"`
+	// Delete is true when the resource is pending deletion due to a replacement.
+	Delete bool `json:"delete,omitempty"`
+	// ID is the resource's unique ID, assigned by the resource provider (or blank if none/uncreated).
+	ID string `json:"id"`
+	// Parent is an optional parent URN that this resource belongs to.
+	Parent string `json:"parent"`
+	// Protect is true to "protect" this resource (protected resources cannot be deleted).
+	Protect bool `json:"protect,omitempty"`
+	// Inputs contains the resource's input properties (as specified by the program). Secrets have been replaced by
+	// the string "[secret]", and large assets have been stripped of their contents.
+	Inputs map[string]interface{} `json:"inputs"`
+	// Outputs contains the resource's complete output state (as returned by the resource provider), filtered in the
+	// same way as its inputs.
+	Outputs map[string]interface{} `json:"outputs"`
+	// Provider is the resource's provider reference.
+	Provider string `json:"provider"`
+	// InitErrors is the set of errors encountered in the process of initializing the resource.
+	InitErrors []string `json:"initErrors,omitempty"`
+}
+
+// ResourcePreEvent is emitted before a resource is modified.
+type ResourcePreEvent struct {
+	Metadata StepEventMetadata `json:"metadata"`
+	Planning bool              `json:"planning,omitempty"`
+}
+
+// ResOutputsEvent is emitted when a resource is finished being provisioned.
+type ResOutputsEvent struct {
+	Metadata StepEventMetadata `json:"metadata"`
+	Planning bool              `json:"planning,omitempty"`
+}
+
+// ResOpFailedEvent is emitted when a resource operation fails. Typically a DiagnosticEvent is emitted before this
+// event, indicating the root cause of the error.
+type ResOpFailedEvent struct {
+	Metadata StepEventMetadata `json:"metadata"`
+	Status   int               `json:"status"`
+	Steps    int               `json:"steps"`
+}
+
+// EngineEvent describes a Pulumi engine event, such as a change to a resource or a diagnostic message. EngineEvent is
+// a discriminated union of all possible event types, and exactly one of the event fields will be non-nil.
+type EngineEvent struct {
+	// Version is the version of the EngineEvent schema to which this event conforms.
+	Version int `json:"version"`
+	// Sequence is a unique and monotonically increasing number for each event in a stream, so that events may be
+	// placed into a total ordering.
+	Sequence int `json:"sequence"`
+	// Timestamp is a Unix timestamp (seconds) of when the event was emitted.
+	Timestamp int64 `json:"timestamp"`
+
+	CancelEvent      *CancelEvent       `json:"cancelEvent,omitempty"`
+	StdoutEvent      *StdoutEngineEvent `json:"stdoutEvent,omitempty"`
+	DiagnosticEvent  *DiagnosticEvent   `json:"diagnosticEvent,omitempty"`
+	PreludeEvent     *PreludeEvent      `json:"preludeEvent,omitempty"`
+	SummaryEvent     *SummaryEvent      `json:"summaryEvent,omitempty"`
+	ResourcePreEvent *ResourcePreEvent  `json:"resourcePreEvent,omitempty"`
+	ResOutputsEvent  *ResOutputsEvent   `json:"resOutputsEvent,omitempty"`
+	ResOpFailedEvent *ResOpFailedEvent  `json:"resOpFailedEvent,omitempty"`
+	PolicyEvent      *PolicyEvent       `json:"policyEvent,omitempty"`
+}
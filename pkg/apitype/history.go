@@ -73,6 +73,14 @@ const (
 	OpCreateReplacement OpType = "create-replacement"
 	// OpDeleteReplaced indiciates an existing resource was deleted after replacement.
 	OpDeleteReplaced OpType = "delete-replaced"
+	// OpRead indicates reading an existing resource.
+	OpRead OpType = "read"
+	// OpReadReplacement indicates reading an existing resource for a replacement.
+	OpReadReplacement OpType = "read-replacement"
+	// OpRefresh indicates refreshing an existing resource.
+	OpRefresh OpType = "refresh"
+	// OpImport indicates importing an existing resource.
+	OpImport OpType = "import"
 )
 
 // UpdateInfo describes a previous update.
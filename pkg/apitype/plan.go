@@ -0,0 +1,53 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apitype
+
+import (
+	"encoding/json"
+)
+
+const (
+	// PlanSchemaVersionCurrent is the current version of the `Plan` schema.
+	// Any plans newer than this version will be rejected.
+	PlanSchemaVersionCurrent = 1
+)
+
+// VersionedPlan is a version number plus a json document. The version number describes what version of the Plan
+// structure the Plan member's json document can decode into.
+type VersionedPlan struct {
+	Version int             `json:"version"`
+	Plan    json.RawMessage `json:"plan"`
+}
+
+// PlanV1 is the serialized form of an update plan saved by a preview.
+type PlanV1 struct {
+	// Steps contains the steps generated by the preview, in the order in which they were generated.
+	Steps []PlannedStepV1 `json:"steps"`
+}
+
+// PlannedStepV1 is the serialized form of a single step of a saved update plan.
+type PlannedStepV1 struct {
+	// Op is the operation performed by the step.
+	Op OpType `json:"op"`
+	// URN is the URN of the resource affected by the step.
+	URN string `json:"urn"`
+	// OldInputs contains the resource's inputs before the step, if any. Values that were unknown during the preview
+	// are recorded as the unknown sentinel string.
+	OldInputs map[string]interface{} `json:"oldInputs,omitempty"`
+	// NewInputs contains the resource's inputs after the step, if any, recorded in the same way as its old inputs.
+	NewInputs map[string]interface{} `json:"newInputs,omitempty"`
+	// Keys contains the keys causing a replacement (only applicable for "create-replacement" and "replace" Ops).
+	Keys []string `json:"keys,omitempty"`
+}
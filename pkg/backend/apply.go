@@ -78,6 +78,13 @@ const (
 
 func PreviewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack,
 	op UpdateOperation, apply Applier) (engine.ResourceChanges, error) {
+	// Analyzers inspect the stack as a whole only once the program has finished, by which point an update has already
+	// changed its resources. Only the preview can enforce their mandatory policies before anything is changed.
+	if op.Opts.SkipPreview && kind == apitype.UpdateUpdate && hasAnalyzers(op) {
+		return nil, errors.New("--skip-preview cannot be used with analyzers, which enforce their policies " +
+			"during the preview")
+	}
+
 	// create a channel to hear about the update events from the engine. this will be used so that
 	// we can build up the diff display in case the user asks to see the details of the diff
 
@@ -120,7 +127,7 @@ func PreviewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack
 	}
 
 	// If there are no changes, or we're auto-approving or just previewing, we can skip the confirmation prompt.
-	if op.Opts.AutoApprove || kind == apitype.PreviewUpdate {
+	if op.Opts.AutoApprove || op.Opts.PreviewOnly || kind == apitype.PreviewUpdate {
 		close(eventsChannel)
 		return changes, nil
 	}
@@ -190,11 +197,20 @@ func confirmBeforeUpdating(kind apitype.UpdateKind, stack Stack,
 	}
 }
 
+// hasAnalyzers returns true if any analyzers are enabled for the given operation, either by its project or by its
+// options.
+func hasAnalyzers(op UpdateOperation) bool {
+	if len(op.Opts.Engine.Analyzers) != 0 {
+		return true
+	}
+	return op.Proj != nil && op.Proj.Analyzers != nil && len(*op.Proj.Analyzers) != 0
+}
+
 func PreviewThenPromptThenExecute(ctx context.Context, kind apitype.UpdateKind, stack Stack,
 	op UpdateOperation, apply Applier) (engine.ResourceChanges, error) {
 	// Preview the operation to the user and ask them if they want to proceed.
 	changes, err := PreviewThenPrompt(ctx, kind, stack, op, apply)
-	if err != nil || kind == apitype.PreviewUpdate {
+	if err != nil || op.Opts.PreviewOnly || kind == apitype.PreviewUpdate {
 		return changes, err
 	}
 
@@ -0,0 +1,57 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func TestSkipPreviewWithAnalyzers(t *testing.T) {
+	var applied bool
+	apply := func(ctx context.Context, kind apitype.UpdateKind, stack Stack, op UpdateOperation,
+		opts ApplierOptions, events chan<- engine.Event) (engine.ResourceChanges, error) {
+		applied = true
+		return nil, nil
+	}
+
+	// Analyzers enabled by the command line or by the project both require a preview.
+	analyzers := workspace.Analyzers{tokens.QName("policy")}
+	ops := []UpdateOperation{
+		{Opts: UpdateOptions{SkipPreview: true, AutoApprove: true, Engine: engine.UpdateOptions{
+			Analyzers: []string{"policy"},
+		}}},
+		{Proj: &workspace.Project{Analyzers: &analyzers}, Opts: UpdateOptions{SkipPreview: true, AutoApprove: true}},
+	}
+	for _, op := range ops {
+		_, err := PreviewThenPromptThenExecute(context.Background(), apitype.UpdateUpdate, nil, op, apply)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "--skip-preview cannot be used with analyzers")
+		}
+		assert.False(t, applied)
+	}
+
+	// Destroys do not analyze the stack, and so may skip the preview.
+	_, err := PreviewThenPromptThenExecute(context.Background(), apitype.DestroyUpdate, nil, ops[0], apply)
+	assert.NoError(t, err)
+	assert.True(t, applied)
+}
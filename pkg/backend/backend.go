@@ -26,7 +26,10 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/engine"
 	"github.com/pulumi/pulumi/pkg/operations"
+	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/cancel"
 	"github.com/pulumi/pulumi/pkg/workspace"
@@ -142,6 +145,8 @@ type UpdateOptions struct {
 	AutoApprove bool
 	// SkipPreview, when true, causes the preview step to be skipped.
 	SkipPreview bool
+	// PreviewOnly, when true, causes the operation to stop after its preview without applying any changes.
+	PreviewOnly bool
 }
 
 // CancellationScope provides a scoped source of cancellation and termination requests.
@@ -181,3 +186,47 @@ func TracingOptionsFromContext(ctx context.Context) TracingOptions {
 	opts, _ := ctx.Value(tracingOptionsKey).(TracingOptions)
 	return opts
 }
+
+// backendClient implements the builtin provider's BackendClient interface on top of a Backend.
+type backendClient struct {
+	backend Backend
+}
+
+// NewBackendClient returns a client that the engine may use to read the state of other stacks in the given backend.
+func NewBackendClient(backend Backend) providers.BackendClient {
+	return &backendClient{backend: backend}
+}
+
+// GetStackOutputs returns the outputs of the root stack resource of the named stack. Secret outputs remain secret.
+func (c *backendClient) GetStackOutputs(ctx context.Context, name string) (resource.PropertyMap, error) {
+	ref, err := c.backend.ParseStackReference(name)
+	if err != nil {
+		return nil, err
+	}
+	s, err := c.backend.GetStack(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, errors.Errorf("unknown stack %q", name)
+	}
+
+	deployment, err := c.backend.ExportDeployment(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	crypter, err := c.backend.GetStackCrypter(ref)
+	if err != nil {
+		return nil, err
+	}
+	snap, err := stack.DeserializeUntypedDeployment(deployment, crypter)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _ := stack.GetRootStackResource(snap)
+	if res == nil || res.Outputs == nil {
+		return resource.PropertyMap{}, nil
+	}
+	return res.Outputs, nil
+}
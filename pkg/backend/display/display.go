@@ -21,7 +21,9 @@ import (
 	"math"
 	"os"
 	"sort"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/dustin/go-humanize/english"
 
@@ -31,6 +33,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/engine"
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/util/contract"
@@ -41,7 +44,9 @@ import (
 // channel so the caller can await all the events being written.
 func ShowEvents(op string, action apitype.UpdateKind, stack tokens.QName, proj tokens.PackageName,
 	events <-chan engine.Event, done chan<- bool, opts Options) {
-	if opts.DiffDisplay {
+	if opts.JSONDisplay {
+		ShowJSONEvents(op, action, events, done, opts)
+	} else if opts.DiffDisplay {
 		ShowDiffEvents(op, action, events, done, opts)
 	} else {
 		ShowProgressEvents(op, action, stack, proj, events, done, opts)
@@ -81,6 +86,10 @@ func ShowDiffEvents(op string, action apitype.UpdateKind,
 
 	seen := make(map[resource.URN]engine.StepEventMetadata)
 
+	// Policy violations are collected as they arrive and printed in their own section just before the summary (or at
+	// the end of the operation, if it fails before producing a summary).
+	var policyViolations []engine.PolicyViolationEventPayload
+
 	for {
 		select {
 		case <-ticker.C:
@@ -88,6 +97,16 @@ func ShowDiffEvents(op string, action apitype.UpdateKind,
 		case event := <-events:
 			spinner.Reset()
 
+			switch event.Type {
+			case engine.PolicyViolationEvent:
+				policyViolations = append(policyViolations, event.Payload.(engine.PolicyViolationEventPayload))
+			case engine.SummaryEvent, engine.CancelEvent:
+				if len(policyViolations) > 0 {
+					fprintIgnoreError(os.Stdout, renderPolicyViolations(policyViolations, opts))
+					policyViolations = nil
+				}
+			}
+
 			out := os.Stdout
 			if event.Type == engine.DiagEvent {
 				payload := event.Payload.(engine.DiagEventPayload)
@@ -136,6 +155,10 @@ func RenderDiffEvent(action apitype.UpdateKind, event engine.Event,
 	case engine.DiagEvent:
 		return renderDiffDiagEvent(event.Payload.(engine.DiagEventPayload), opts)
 
+		// Policy violations are displayed in their own section once the operation has finished.
+	case engine.PolicyViolationEvent:
+		return ""
+
 	default:
 		contract.Failf("unknown event type '%s'", event.Type)
 		return ""
@@ -202,6 +225,36 @@ func renderSummaryEvent(action apitype.UpdateKind, event engine.SummaryEventPayl
 	return out.String()
 }
 
+func renderPolicyViolations(violations []engine.PolicyViolationEventPayload, opts Options) string {
+	out := &bytes.Buffer{}
+	fprintIgnoreError(out, opts.Color.Colorize(
+		fmt.Sprintf("%sPolicy Violations:%s\n", colors.SpecHeadline, colors.Reset)))
+
+	for _, v := range violations {
+		levelColor := colors.SpecError
+		if v.EnforcementLevel == plugin.Advisory {
+			levelColor = colors.SpecWarning
+		}
+
+		var subject string
+		if v.URN != "" {
+			subject = fmt.Sprintf(" %s (%s)", v.URN.Type(), v.URN.Name())
+		}
+		if v.Property != "" {
+			subject += fmt.Sprintf(" property '%s'", v.Property)
+		}
+
+		fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf("    %s[%s]%s %s%s\n",
+			levelColor, v.EnforcementLevel, colors.Reset, v.Analyzer, subject)))
+		for _, line := range strings.Split(strings.TrimRightFunc(v.Message, unicode.IsSpace), "\n") {
+			fprintfIgnoreError(out, "        %s\n", line)
+		}
+	}
+	fprintIgnoreError(out, "\n")
+
+	return out.String()
+}
+
 func renderPreludeEvent(event engine.PreludeEventPayload, opts Options) string {
 	// Only if we have been instructed to show configuration values will we print anything during the prelude.
 	if !opts.ShowConfig {
@@ -0,0 +1,211 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// ShowJSONEvents renders the engine events as a stream of JSON objects, one per line, for consumption by other
+// programs. Each object is an apitype.EngineEvent.
+func ShowJSONEvents(op string, action apitype.UpdateKind,
+	events <-chan engine.Event, done chan<- bool, opts Options) {
+
+	defer func() {
+		done <- true
+	}()
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+
+	sequence := 0
+	for e := range events {
+		apiEvent := ConvertEngineEvent(e)
+		apiEvent.Sequence, apiEvent.Timestamp = sequence, time.Now().Unix()
+		sequence++
+
+		contract.IgnoreError(encoder.Encode(apiEvent))
+
+		if e.Type == engine.CancelEvent {
+			return
+		}
+	}
+}
+
+// ConvertEngineEvent converts an engine event into its API representation. Any secrets in the event's property maps
+// are replaced by the string "[secret]". The returned event has no sequence number or timestamp.
+func ConvertEngineEvent(e engine.Event) apitype.EngineEvent {
+	apiEvent := apitype.EngineEvent{Version: apitype.EngineEventSchemaVersion}
+
+	switch e.Type {
+	case engine.CancelEvent:
+		apiEvent.CancelEvent = &apitype.CancelEvent{}
+
+	case engine.StdoutColorEvent:
+		p := e.Payload.(engine.StdoutEventPayload)
+		apiEvent.StdoutEvent = &apitype.StdoutEngineEvent{
+			Message: p.Message,
+			Color:   string(p.Color),
+		}
+
+	case engine.DiagEvent:
+		p := e.Payload.(engine.DiagEventPayload)
+		apiEvent.DiagnosticEvent = &apitype.DiagnosticEvent{
+			URN:       string(p.URN),
+			Prefix:    p.Prefix,
+			Message:   p.Message,
+			Color:     string(p.Color),
+			Severity:  string(p.Severity),
+			StreamID:  int(p.StreamID),
+			Ephemeral: p.Ephemeral,
+		}
+
+	case engine.PreludeEvent:
+		p := e.Payload.(engine.PreludeEventPayload)
+		// Copy the config map so that the event does not alias the engine's own state.
+		cfg := make(map[string]string)
+		for k, v := range p.Config {
+			cfg[k] = v
+		}
+		apiEvent.PreludeEvent = &apitype.PreludeEvent{
+			IsPreview: p.IsPreview,
+			Config:    cfg,
+		}
+
+	case engine.SummaryEvent:
+		p := e.Payload.(engine.SummaryEventPayload)
+		changes := make(map[apitype.OpType]int)
+		for op, count := range p.ResourceChanges {
+			changes[apitype.OpType(op)] = count
+		}
+		var violations []apitype.PolicyEvent
+		for _, v := range p.PolicyViolations {
+			violations = append(violations, convertPolicyViolation(v))
+		}
+		apiEvent.SummaryEvent = &apitype.SummaryEvent{
+			IsPreview:        p.IsPreview,
+			MaybeCorrupt:     p.MaybeCorrupt,
+			DurationSeconds:  int(p.Duration.Seconds()),
+			ResourceChanges:  changes,
+			PolicyViolations: violations,
+		}
+
+	case engine.PolicyViolationEvent:
+		violation := convertPolicyViolation(e.Payload.(engine.PolicyViolationEventPayload))
+		apiEvent.PolicyEvent = &violation
+
+	case engine.ResourcePreEvent:
+		p := e.Payload.(engine.ResourcePreEventPayload)
+		apiEvent.ResourcePreEvent = &apitype.ResourcePreEvent{
+			Metadata: convertStepEventMetadata(p.Metadata),
+			Planning: p.Planning,
+		}
+
+	case engine.ResourceOutputsEvent:
+		p := e.Payload.(engine.ResourceOutputsEventPayload)
+		apiEvent.ResOutputsEvent = &apitype.ResOutputsEvent{
+			Metadata: convertStepEventMetadata(p.Metadata),
+			Planning: p.Planning,
+		}
+
+	case engine.ResourceOperationFailed:
+		p := e.Payload.(engine.ResourceOperationFailedPayload)
+		apiEvent.ResOpFailedEvent = &apitype.ResOpFailedEvent{
+			Metadata: convertStepEventMetadata(p.Metadata),
+			Status:   int(p.Status),
+			Steps:    p.Steps,
+		}
+
+	default:
+		contract.Failf("unknown event type '%s'", e.Type)
+	}
+
+	return apiEvent
+}
+
+func convertPolicyViolation(v engine.PolicyViolationEventPayload) apitype.PolicyEvent {
+	return apitype.PolicyEvent{
+		ResourceURN:      string(v.URN),
+		Analyzer:         string(v.Analyzer),
+		Property:         string(v.Property),
+		Message:          v.Message,
+		EnforcementLevel: v.EnforcementLevel.String(),
+	}
+}
+
+func convertStepEventMetadata(md engine.StepEventMetadata) apitype.StepEventMetadata {
+	keys := make([]string, len(md.Keys))
+	for i, k := range md.Keys {
+		keys[i] = string(k)
+	}
+
+	var detailedDiff map[string]apitype.PropertyDiff
+	if md.DetailedDiff != nil {
+		detailedDiff = make(map[string]apitype.PropertyDiff)
+		for path, diff := range md.DetailedDiff {
+			detailedDiff[path] = apitype.PropertyDiff{Kind: apitype.DiffKind(diff.Kind.String())}
+		}
+	}
+
+	return apitype.StepEventMetadata{
+		Op:           apitype.OpType(md.Op),
+		URN:          string(md.URN),
+		Type:         string(md.Type),
+		Old:          convertStepEventStateMetadata(md.Old),
+		New:          convertStepEventStateMetadata(md.New),
+		Keys:         keys,
+		Logical:      md.Logical,
+		Provider:     md.Provider,
+		DetailedDiff: detailedDiff,
+	}
+}
+
+func convertStepEventStateMetadata(md *engine.StepEventStateMetadata) *apitype.StepEventStateMetadata {
+	if md == nil {
+		return nil
+	}
+
+	return &apitype.StepEventStateMetadata{
+		Type:       string(md.Type),
+		URN:        string(md.URN),
+		Custom:     md.Custom,
+		Delete:     md.Delete,
+		ID:         string(md.ID),
+		Parent:     string(md.Parent),
+		Protect:    md.Protect,
+		Inputs:     serializeBlindedProperties(md.Inputs),
+		Outputs:    serializeBlindedProperties(md.Outputs),
+		Provider:   md.Provider,
+		InitErrors: md.InitErrors,
+	}
+}
+
+// serializeBlindedProperties serializes a property map after replacing each of its secrets with "[secret]". Because
+// no secrets remain, the serializer's crypter is never consulted.
+func serializeBlindedProperties(props resource.PropertyMap) map[string]interface{} {
+	blinded := stack.BlindSecrets(resource.NewObjectProperty(props)).ObjectValue()
+	serialized, err := stack.SerializeProperties(blinded, config.NewPanicCrypter())
+	contract.AssertNoError(err)
+	return serialized
+}
@@ -0,0 +1,123 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+)
+
+func TestConvertResourcePreEvent(t *testing.T) {
+	urn := resource.URN("urn:pulumi:test::test::pkgA:m:typA::resA")
+	props := resource.PropertyMap{
+		"plain":  resource.NewStringProperty("visible"),
+		"secret": resource.MakeSecret(resource.NewStringProperty("hidden")),
+		"nested": resource.NewObjectProperty(resource.PropertyMap{
+			"secret": resource.MakeSecret(resource.NewNumberProperty(42)),
+		}),
+		"unknown": resource.MakeComputed(resource.NewStringProperty("")),
+	}
+
+	event := ConvertEngineEvent(engine.Event{
+		Type: engine.ResourcePreEvent,
+		Payload: engine.ResourcePreEventPayload{
+			Metadata: engine.StepEventMetadata{
+				Op:   deploy.OpCreate,
+				URN:  urn,
+				Type: "pkgA:m:typA",
+				New: &engine.StepEventStateMetadata{
+					Type:   "pkgA:m:typA",
+					URN:    urn,
+					Custom: true,
+					Inputs: props,
+				},
+				Keys: []resource.PropertyKey{"plain"},
+				DetailedDiff: map[string]plugin.PropertyDiff{
+					"nested.secret": {Kind: plugin.DiffUpdateReplace},
+				},
+			},
+			Planning: true,
+		},
+	})
+
+	assert.Equal(t, apitype.EngineEventSchemaVersion, event.Version)
+	if !assert.NotNil(t, event.ResourcePreEvent) {
+		return
+	}
+	assert.Nil(t, event.DiagnosticEvent)
+
+	md := event.ResourcePreEvent.Metadata
+	assert.Equal(t, apitype.OpCreate, md.Op)
+	assert.Equal(t, string(urn), md.URN)
+	assert.Equal(t, []string{"plain"}, md.Keys)
+	assert.Equal(t, map[string]apitype.PropertyDiff{
+		"nested.secret": {Kind: apitype.DiffUpdateReplace},
+	}, md.DetailedDiff)
+	assert.Nil(t, md.Old)
+	assert.Equal(t, map[string]interface{}{
+		"plain":  "visible",
+		"secret": "[secret]",
+		"nested": map[string]interface{}{"secret": "[secret]"},
+	}, md.New.Inputs)
+
+	// The secrets must not appear anywhere in the serialized event.
+	bytes, err := json.Marshal(event)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(bytes), "hidden")
+	assert.Contains(t, string(bytes), `"resourcePreEvent"`)
+}
+
+func TestConvertSummaryEvent(t *testing.T) {
+	event := ConvertEngineEvent(engine.Event{
+		Type: engine.SummaryEvent,
+		Payload: engine.SummaryEventPayload{
+			ResourceChanges: engine.ResourceChanges{deploy.OpCreate: 2, deploy.OpSame: 1},
+		},
+	})
+
+	if assert.NotNil(t, event.SummaryEvent) {
+		assert.Equal(t, map[apitype.OpType]int{apitype.OpCreate: 2, apitype.OpSame: 1},
+			event.SummaryEvent.ResourceChanges)
+	}
+}
+
+func TestConvertPolicyViolationEvent(t *testing.T) {
+	event := ConvertEngineEvent(engine.Event{
+		Type: engine.PolicyViolationEvent,
+		Payload: engine.PolicyViolationEventPayload{
+			URN:              "urn:pulumi:test::test::pkgA:m:typA::resA",
+			Analyzer:         "policy",
+			Property:         "acl",
+			Message:          "buckets must not be public",
+			EnforcementLevel: plugin.Advisory,
+		},
+	})
+
+	assert.Equal(t, &apitype.PolicyEvent{
+		ResourceURN:      "urn:pulumi:test::test::pkgA:m:typA::resA",
+		Analyzer:         "policy",
+		Property:         "acl",
+		Message:          "buckets must not be public",
+		EnforcementLevel: "advisory",
+	}, event.PolicyEvent)
+}
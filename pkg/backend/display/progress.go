@@ -111,6 +111,10 @@ type ProgressDisplay struct {
 	// messages we're outputting for them.
 	summaryEventPayload *engine.SummaryEventPayload
 
+	// The policy violations reported during the operation.  These are displayed in their own section
+	// once the operation has finished.
+	policyViolations []engine.PolicyViolationEventPayload
+
 	// Any system events we've received.  They will be printed at the bottom of all the status rows
 	systemEventPayloads []engine.StdoutEventPayload
 
@@ -728,6 +732,17 @@ func (display *ProgressDisplay) processEndSteps() {
 		}
 	}
 
+	// Print any policy violations in their own section.
+	var wrotePolicyViolations bool
+	if len(display.policyViolations) > 0 {
+		if !wroteDiagnosticHeader {
+			display.writeBlankLine()
+		}
+
+		wrotePolicyViolations = true
+		display.writeSimpleMessage(renderPolicyViolations(display.policyViolations, display.opts))
+	}
+
 	// If we get stack outputs, display them at the end.
 	var wroteOutputs bool
 	if display.stackUrn != "" && display.seenStackOutputs && !display.opts.SuppressOutputs {
@@ -735,7 +750,7 @@ func (display *ProgressDisplay) processEndSteps() {
 		props := engine.GetResourceOutputsPropertiesString(
 			stackStep, 1, display.isPreview, display.opts.Debug, false /* refresh */)
 		if props != "" {
-			if !wroteDiagnosticHeader {
+			if !wroteDiagnosticHeader && !wrotePolicyViolations {
 				display.writeBlankLine()
 			}
 
@@ -747,7 +762,7 @@ func (display *ProgressDisplay) processEndSteps() {
 
 	// print the summary
 	if display.summaryEventPayload != nil {
-		if !wroteDiagnosticHeader && !wroteOutputs {
+		if !wroteDiagnosticHeader && !wrotePolicyViolations && !wroteOutputs {
 			display.writeBlankLine()
 		}
 
@@ -866,6 +881,12 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 		payload := event.Payload.(engine.SummaryEventPayload)
 		display.summaryEventPayload = &payload
 		return
+	case engine.PolicyViolationEvent:
+		// keep track of policy violations so that we can display them in their own section
+		// once the operation has finished.
+		payload := event.Payload.(engine.PolicyViolationEventPayload)
+		display.policyViolations = append(display.policyViolations, payload)
+		return
 	case engine.DiagEvent:
 		msg := display.renderProgressDiagEvent(event.Payload.(engine.DiagEventPayload), true /*includePrefix:*/)
 		if msg == "" {
@@ -1043,6 +1064,8 @@ func (display *ProgressDisplay) getStepDoneDescription(step engine.StepEventMeta
 				return "reading failed"
 			case deploy.OpRefresh:
 				return "refreshing failed"
+			case deploy.OpImport:
+				return "importing failed"
 			}
 		} else {
 			switch op {
@@ -1067,6 +1090,8 @@ func (display *ProgressDisplay) getStepDoneDescription(step engine.StepEventMeta
 				return "read for replacement"
 			case deploy.OpRefresh:
 				return "refresh"
+			case deploy.OpImport:
+				return "imported"
 			}
 		}
 
@@ -1104,6 +1129,8 @@ func (display *ProgressDisplay) getPreviewText(step engine.StepEventMetadata) st
 		return "read for replacement"
 	case deploy.OpRefresh:
 		return "refreshing"
+	case deploy.OpImport:
+		return "import"
 	}
 
 	contract.Failf("Unrecognized resource step op: %v", step.Op)
@@ -1129,6 +1156,8 @@ func (display *ProgressDisplay) getPreviewDoneText(step engine.StepEventMetadata
 		return "read"
 	case deploy.OpRefresh:
 		return "refresh"
+	case deploy.OpImport:
+		return "import"
 	}
 
 	contract.Failf("Unrecognized resource step op: %v", step.Op)
@@ -1199,6 +1228,8 @@ func (display *ProgressDisplay) getStepInProgressDescription(step engine.StepEve
 			return "reading for replacement"
 		case deploy.OpRefresh:
 			return "refreshing"
+		case deploy.OpImport:
+			return "importing"
 		}
 
 		contract.Failf("Unrecognized resource step op: %v", op)
@@ -18,11 +18,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"os/user"
-	"path/filepath"
+	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -50,12 +50,30 @@ const localBackendURLPrefix = "file://"
 // Backend extends the base backend interface with specific information about local backends.
 type Backend interface {
 	backend.Backend
-	local() // at the moment, no local specific info, so just use a marker function.
+	local() // a marker function that distinguishes local backends.
+
+	// ChangeSecretsProvider switches the given stack to a new secrets provider, re-encrypting its secure
+	// configuration values and the secrets in its state with the new provider's key.
+	ChangeSecretsProvider(ctx context.Context, stackRef backend.StackReference, secretsProvider string) error
+	// CancelCurrentUpdate removes the lock held by the update currently being applied to the given stack, if any,
+	// unless that update is still running on this host.
+	CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error
+	// ExportDeploymentVersion exports the deployment that the given stack's update with the given version left behind,
+	// as recorded in the stack's history.
+	ExportDeploymentVersion(ctx context.Context, stackRef backend.StackReference,
+		version int) (*apitype.UntypedDeployment, error)
 }
 
 type localBackend struct {
-	d   diag.Sink
-	url string
+	d      diag.Sink
+	url    string
+	bucket Bucket // the bucket in which the backend's state is stored.
+
+	crypters     map[tokens.QName]*lazyCrypter // the crypters for each stack's secret state, created on demand.
+	cryptersLock sync.Mutex                    // a lock that protects the crypters map.
+
+	locks     map[tokens.QName]lockContent // the stack locks held by this backend.
+	locksLock sync.Mutex                   // a lock that protects the locks map.
 }
 
 type localBackendReference struct {
@@ -70,17 +88,30 @@ func (r localBackendReference) Name() tokens.QName {
 	return r.name
 }
 
+// IsLocalBackendURL returns true if the given URL refers to a backend that keeps its state directly in a file system
+// or blob store (e.g. file:// or s3://), rather than in the Pulumi service.
 func IsLocalBackendURL(url string) bool {
-	return strings.HasPrefix(url, localBackendURLPrefix)
+	for _, scheme := range bucketSchemes {
+		if strings.HasPrefix(url, scheme+"://") {
+			return true
+		}
+	}
+	return false
 }
 
 func New(d diag.Sink, url string) (Backend, error) {
 	if !IsLocalBackendURL(url) {
-		return nil, errors.Errorf("local URL %s has an illegal prefix; expected %s", url, localBackendURLPrefix)
+		return nil, errors.Errorf("local URL %s has an illegal prefix; expected one of %s://",
+			url, strings.Join(bucketSchemes, "://, "))
+	}
+	bucket, err := openBucket(url)
+	if err != nil {
+		return nil, err
 	}
 	return &localBackend{
-		d:   d,
-		url: url,
+		d:      d,
+		url:    url,
+		bucket: bucket,
 	}, nil
 }
 
@@ -95,6 +126,10 @@ func Login(d diag.Sink, url string) (Backend, error) {
 func (b *localBackend) local() {}
 
 func (b *localBackend) Name() string {
+	if !strings.HasPrefix(b.url, localBackendURLPrefix) {
+		return b.url
+	}
+
 	name, err := os.Hostname()
 	contract.IgnoreError(err)
 	if name == "" {
@@ -107,25 +142,6 @@ func (b *localBackend) URL() string {
 	return b.url
 }
 
-func (b *localBackend) Dir() string {
-	path := b.url[len(localBackendURLPrefix):]
-	if path == "~" {
-		user, err := user.Current()
-		contract.AssertNoErrorf(err, "could not determine current user")
-		path = user.HomeDir
-	} else if path == "." {
-		pwd, err := os.Getwd()
-		contract.AssertNoErrorf(err, "could not determine current working directory")
-		path = pwd
-	}
-	return path
-}
-
-func (b *localBackend) StateDir() string {
-	dir := b.Dir()
-	return filepath.Join(dir, workspace.BookkeepingDir)
-}
-
 func (b *localBackend) ParseStackReference(stackRefName string) (backend.StackReference, error) {
 	return localBackendReference{name: tokens.QName(stackRefName)}, nil
 }
@@ -213,7 +229,84 @@ func (b *localBackend) RemoveStack(ctx context.Context, stackRef backend.StackRe
 }
 
 func (b *localBackend) GetStackCrypter(stackRef backend.StackReference) (config.Crypter, error) {
-	return symmetricCrypter(stackRef.Name())
+	return b.stackCrypter(stackRef.Name()), nil
+}
+
+func (b *localBackend) ChangeSecretsProvider(ctx context.Context, stackRef backend.StackReference,
+	secretsProvider string) error {
+
+	stackName := stackRef.Name()
+	provider, err := config.NewSecretsProvider(secretsProvider, readPassphrase)
+	if err != nil {
+		return err
+	}
+
+	// Lock the stack so that no update can write state encrypted with the old key while it is being re-encrypted.
+	if err = b.lockStack(stackName); err != nil {
+		return err
+	}
+	defer func() {
+		if err := b.unlockStack(stackName); err != nil {
+			b.d.Warningf(diag.Message("" /*urn*/, "failed to release lock for stack %s: %v"), stackName, err)
+		}
+	}()
+
+	// Load the stack's configuration and state using its current crypter.
+	info, err := workspace.DetectProjectStack(stackName)
+	if err != nil {
+		return err
+	}
+	stackConfig, snap, _, err := b.getStack(stackName)
+	if err != nil {
+		return err
+	}
+
+	// Create the new crypter. Any state recorded by the old provider is discarded.
+	var state config.SecretsProviderState
+	newCrypter, _, err := provider.Crypter(&state)
+	if err != nil {
+		return err
+	}
+
+	oldInfo := *info
+	oldCrypter := b.stackCrypter(stackName)
+	if info.Config, err = reencryptConfig(info.Config, oldCrypter, newCrypter); err != nil {
+		return err
+	}
+	if stackConfig, err = reencryptConfig(stackConfig, oldCrypter, newCrypter); err != nil {
+		return err
+	}
+
+	// Persist the configuration before the state. If the configuration cannot be saved, nothing has changed; if the
+	// state cannot be saved afterwards, the old configuration is restored so that the stack's secrets can still be
+	// read using its old provider.
+	info.SecretsProvider = secretsProvider
+	info.EncryptionSalt, info.EncryptedKey = state.EncryptionSalt, state.EncryptedKey
+	if err = workspace.SaveProjectStack(stackName, info); err != nil {
+		return err
+	}
+
+	// Swap in the new crypter so that the state's secrets are written using the new key.
+	b.cryptersLock.Lock()
+	b.crypters[stackName] = &lazyCrypter{stackName: stackName, crypter: newCrypter}
+	b.cryptersLock.Unlock()
+
+	if _, err = b.saveStack(stackName, stackConfig, snap); err != nil {
+		b.cryptersLock.Lock()
+		b.crypters[stackName] = oldCrypter.(*lazyCrypter)
+		b.cryptersLock.Unlock()
+
+		if restoreErr := workspace.SaveProjectStack(stackName, &oldInfo); restoreErr != nil {
+			return errors.Wrapf(err, "saving the re-encrypted state failed, and the stack's original configuration "+
+				"could not be restored (%v)", restoreErr)
+		}
+		return err
+	}
+	return nil
+}
+
+func (b *localBackend) CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error {
+	return b.cancelLock(stackRef.Name())
 }
 
 func (b *localBackend) GetLatestConfiguration(ctx context.Context,
@@ -279,10 +372,25 @@ func (b *localBackend) apply(ctx context.Context, kind apitype.UpdateKind, stack
 	stackRef := stack.Ref()
 	stackName := stackRef.Name()
 
-	// Print a banner so it's clear this is a local deployment.
+	// Print a banner so it's clear this is a local deployment, unless the events are being emitted as JSON.
 	actionLabel := backend.ActionLabel(kind, opts.DryRun)
-	fmt.Printf(op.Opts.Display.Color.Colorize(
-		colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stackRef)
+	if !op.Opts.Display.JSONDisplay {
+		fmt.Printf(op.Opts.Display.Color.Colorize(
+			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stackRef)
+	}
+
+	// Lock the stack so that no other update can modify its state while this one is running. Previews do not modify
+	// state and so do not need the lock.
+	if !opts.DryRun {
+		if err := b.lockStack(stackName); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err := b.unlockStack(stackName); err != nil {
+				b.d.Warningf(diag.Message("" /*urn*/, "failed to release lock for stack %s: %v"), stackName, err)
+			}
+		}()
+	}
 
 	// Start the update.
 	update, err := b.newUpdate(stackName, op.Proj, op.Root)
@@ -318,7 +426,12 @@ func (b *localBackend) apply(ctx context.Context, kind apitype.UpdateKind, stack
 	// Create the management machinery.
 	persister := b.newSnapshotPersister(stackName)
 	manager := backend.NewSnapshotManager(persister, update.GetTarget().Snapshot)
-	engineCtx := &engine.Context{Cancel: scope.Context(), Events: engineEvents, SnapshotManager: manager}
+	engineCtx := &engine.Context{
+		Cancel:          scope.Context(),
+		Events:          engineEvents,
+		SnapshotManager: manager,
+		BackendClient:   backend.NewBackendClient(b),
+	}
 
 	// Perform the update
 	start := time.Now().Unix()
@@ -390,11 +503,11 @@ func (b *localBackend) apply(ctx context.Context, kind apitype.UpdateKind, stack
 	}
 
 	// Make sure to print a link to the stack's checkpoint before exiting.
-	if opts.ShowLink {
+	if opts.ShowLink && !op.Opts.Display.JSONDisplay {
 		fmt.Printf(
 			op.Opts.Display.Color.Colorize(
 				colors.SpecHeadline+"Permalink: "+
-					colors.Underline+colors.BrightBlue+"file://%s"+colors.Reset+"\n"), stack.(*localStack).Path())
+					colors.Underline+colors.BrightBlue+"%s"+colors.Reset+"\n"), stack.(*localStack).Path())
 	}
 
 	return changes, nil
@@ -453,7 +566,35 @@ func (b *localBackend) ExportDeployment(ctx context.Context,
 		snap = deploy.NewSnapshot(deploy.Manifest{}, nil, nil)
 	}
 
-	data, err := json.Marshal(stack.SerializeDeployment(snap))
+	sdep, err := stack.SerializeDeployment(snap, b.stackCrypter(stackName))
+	if err != nil {
+		return nil, errors.Wrap(err, "serializing deployment")
+	}
+
+	data, err := json.Marshal(sdep)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apitype.UntypedDeployment{
+		Version:    2,
+		Deployment: json.RawMessage(data),
+	}, nil
+}
+
+func (b *localBackend) ExportDeploymentVersion(ctx context.Context, stackRef backend.StackReference,
+	version int) (*apitype.UntypedDeployment, error) {
+
+	chk, err := b.getHistoryCheckpoint(stackRef.Name(), version)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := chk.Latest
+	if latest == nil {
+		latest = &apitype.DeploymentV2{}
+	}
+	data, err := json.Marshal(latest)
 	if err != nil {
 		return nil, err
 	}
@@ -473,7 +614,7 @@ func (b *localBackend) ImportDeployment(ctx context.Context, stackRef backend.St
 		return err
 	}
 
-	snap, err := stack.DeserializeUntypedDeployment(deployment)
+	snap, err := stack.DeserializeUntypedDeployment(deployment, b.stackCrypter(stackName))
 	if err != nil {
 		return err
 	}
@@ -497,23 +638,22 @@ func (b *localBackend) CurrentUser() (string, error) {
 func (b *localBackend) getLocalStacks() ([]tokens.QName, error) {
 	var stacks []tokens.QName
 
-	// Read the stack directory.
-	path := b.stackPath("")
-
-	files, err := ioutil.ReadDir(path)
-	if err != nil && !os.IsNotExist(err) {
+	// List the stack directory.
+	dir := b.stackPath("") + "/"
+	keys, err := b.bucket.List(dir)
+	if err != nil {
 		return nil, errors.Errorf("could not read stacks: %v", err)
 	}
 
-	for _, file := range files {
-		// Ignore directories.
-		if file.IsDir() {
+	for _, key := range keys {
+		// Ignore anything in subdirectories.
+		stackfn := strings.TrimPrefix(key, dir)
+		if strings.Contains(stackfn, "/") {
 			continue
 		}
 
 		// Skip files without valid extensions (e.g., *.bak files).
-		stackfn := file.Name()
-		ext := filepath.Ext(stackfn)
+		ext := path.Ext(stackfn)
 		if _, has := encoding.Marshalers[ext]; !has {
 			continue
 		}
@@ -0,0 +1,213 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// Bucket is a minimal blob store in which a backend keeps its state. Keys are slash-separated paths relative to the
+// root of the bucket.
+type Bucket interface {
+	// URL returns a URL that identifies the blob with the given key, suitable for display.
+	URL(key string) string
+	// ReadAll returns the contents of the blob with the given key. If no such blob exists, the returned error
+	// satisfies os.IsNotExist.
+	ReadAll(key string) ([]byte, error)
+	// WriteAll creates or replaces the blob with the given key.
+	WriteAll(key string, data []byte) error
+	// WriteNew creates the blob with the given key, failing if it already exists. If it does, the returned error
+	// satisfies os.IsExist. This is used to implement locking, so it must be atomic. If the blob already holds
+	// exactly the given data, as it does when an earlier attempt at the same write succeeded, WriteNew succeeds.
+	WriteNew(key string, data []byte) error
+	// Delete removes the blob with the given key. It is not an error if no such blob exists.
+	Delete(key string) error
+	// List returns the sorted keys of all blobs whose keys begin with the given prefix.
+	List(prefix string) ([]string, error)
+}
+
+const (
+	fileBucketScheme = "file"
+	s3BucketScheme   = "s3"
+)
+
+// bucketSchemes are the URL schemes for which this package provides a bucket.
+var bucketSchemes = []string{fileBucketScheme, s3BucketScheme}
+
+// openBucket returns the bucket identified by the given URL.
+func openBucket(rawurl string) (Bucket, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing backend URL '%s'", rawurl)
+	}
+
+	switch u.Scheme {
+	case fileBucketScheme:
+		return newFileBucket(strings.TrimPrefix(rawurl, localBackendURLPrefix)), nil
+	case s3BucketScheme:
+		return newS3Bucket(u)
+	default:
+		return nil, errors.Errorf("unsupported backend URL scheme '%s'", u.Scheme)
+	}
+}
+
+// bucketPrefix returns the key prefix given by a bucket URL's path, with a trailing slash if it is non-empty.
+func bucketPrefix(u *url.URL) string {
+	prefix := strings.Trim(u.Path, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return prefix
+}
+
+// notExistError returns an error for a missing blob that satisfies os.IsNotExist.
+func notExistError(op string, key string) error {
+	return &os.PathError{Op: op, Path: key, Err: os.ErrNotExist}
+}
+
+// existError returns an error for an already-existing blob that satisfies os.IsExist.
+func existError(op string, key string) error {
+	return &os.PathError{Op: op, Path: key, Err: os.ErrExist}
+}
+
+// writeNewConflict is called when WriteNew finds that a blob already exists. Requests whose responses are lost are
+// retried, so the blob may have been created by an earlier attempt at this same write; callers of WriteNew write
+// data that identifies them, so in that case the blob holds exactly the data being written and the write succeeded.
+func writeNewConflict(b Bucket, key string, data []byte) error {
+	existing, err := b.ReadAll(key)
+	if err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+	return existError("write", b.URL(key))
+}
+
+// fileBucket is a bucket that stores blobs as files underneath a directory on the local file system.
+type fileBucket struct {
+	root string
+}
+
+// newFileBucket creates a bucket rooted at the given directory. The special directories "~" and "." refer to the
+// current user's home directory and the current working directory, respectively.
+func newFileBucket(dir string) *fileBucket {
+	if dir == "~" {
+		user, err := user.Current()
+		contract.AssertNoErrorf(err, "could not determine current user")
+		dir = user.HomeDir
+	} else if dir == "." {
+		pwd, err := os.Getwd()
+		contract.AssertNoErrorf(err, "could not determine current working directory")
+		dir = pwd
+	}
+	return &fileBucket{root: dir}
+}
+
+func (b *fileBucket) path(key string) string {
+	return filepath.Join(b.root, filepath.FromSlash(key))
+}
+
+func (b *fileBucket) URL(key string) string {
+	return localBackendURLPrefix + filepath.ToSlash(b.path(key))
+}
+
+func (b *fileBucket) ReadAll(key string) ([]byte, error) {
+	return ioutil.ReadFile(b.path(key))
+}
+
+func (b *fileBucket) WriteAll(key string, data []byte) error {
+	path := b.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+func (b *fileBucket) WriteNew(key string, data []byte) error {
+	path := b.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if os.IsExist(err) {
+			return writeNewConflict(b, key, data)
+		}
+		return err
+	}
+	if _, err = f.Write(data); err != nil {
+		contract.IgnoreClose(f)
+		contract.IgnoreError(os.Remove(path))
+		return err
+	}
+	if err = f.Close(); err != nil {
+		contract.IgnoreError(os.Remove(path))
+		return err
+	}
+	return nil
+}
+
+func (b *fileBucket) Delete(key string) error {
+	if err := os.Remove(b.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (b *fileBucket) List(prefix string) ([]string, error) {
+	// Walk the deepest directory that contains every key with the given prefix.
+	dir := prefix
+	if i := strings.LastIndex(dir, "/"); i >= 0 {
+		dir = dir[:i]
+	} else {
+		dir = ""
+	}
+
+	var keys []string
+	err := filepath.Walk(b.path(dir), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(b.root, path)
+		contract.AssertNoError(err)
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
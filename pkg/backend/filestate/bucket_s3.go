@@ -0,0 +1,165 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// s3Bucket is a bucket stored in Amazon S3 or in an S3-compatible store such as MinIO.
+type s3Bucket struct {
+	client s3iface.S3API // the client used to make requests.
+	bucket string        // the name of the bucket.
+	prefix string        // the prefix added to every key.
+}
+
+// newS3Bucket creates a bucket for an s3://bucket/prefix URL. The region may be given with a `region` query parameter,
+// and an S3-compatible service may be used instead of Amazon S3 by passing its URL as an `endpoint` parameter.
+// Credentials and the default region are found the same way the AWS CLI finds them, from the standard AWS
+// environment variables or the shared AWS config and credentials files.
+func newS3Bucket(u *url.URL) (Bucket, error) {
+	if u.Host == "" {
+		return nil, errors.New("an s3:// URL must name a bucket, e.g. s3://my-bucket/prefix")
+	}
+
+	var config aws.Config
+	query := u.Query()
+	if region := query.Get("region"); region != "" {
+		config.Region = aws.String(region)
+	}
+	if endpoint := query.Get("endpoint"); endpoint != "" {
+		config.Endpoint = aws.String(endpoint)
+		config.S3ForcePathStyle = aws.Bool(true)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            config,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "creating AWS session")
+	}
+	if aws.StringValue(sess.Config.Region) == "" {
+		sess = sess.Copy(&aws.Config{Region: aws.String("us-east-1")})
+	}
+
+	return &s3Bucket{client: s3.New(sess), bucket: u.Host, prefix: bucketPrefix(u)}, nil
+}
+
+func (b *s3Bucket) URL(key string) string {
+	return fmt.Sprintf("%s://%s/%s%s", s3BucketScheme, b.bucket, b.prefix, key)
+}
+
+func (b *s3Bucket) ReadAll(key string) ([]byte, error) {
+	out, err := b.client.GetObjectWithContext(context.Background(), &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.prefix + key),
+	})
+	if err != nil {
+		if isS3Status(err, http.StatusNotFound) {
+			return nil, notExistError("read", b.URL(key))
+		}
+		return nil, errors.Wrapf(err, "reading %s", b.URL(key))
+	}
+	defer contract.IgnoreClose(out.Body)
+
+	return ioutil.ReadAll(out.Body)
+}
+
+func (b *s3Bucket) WriteAll(key string, data []byte) error {
+	if err := b.put(key, data); err != nil {
+		return errors.Wrapf(err, "writing %s", b.URL(key))
+	}
+	return nil
+}
+
+func (b *s3Bucket) WriteNew(key string, data []byte) error {
+	err := b.put(key, data, func(req *request.Request) {
+		req.HTTPRequest.Header.Set("If-None-Match", "*")
+	})
+	switch {
+	case err == nil:
+		return nil
+	case isS3Status(err, http.StatusPreconditionFailed):
+		return writeNewConflict(b, key, data)
+	default:
+		return errors.Wrapf(err, "writing %s", b.URL(key))
+	}
+}
+
+func (b *s3Bucket) put(key string, data []byte, opts ...request.Option) error {
+	_, err := b.client.PutObjectWithContext(context.Background(), &s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.prefix + key),
+		Body:   bytes.NewReader(data),
+	}, opts...)
+	return err
+}
+
+func (b *s3Bucket) Delete(key string) error {
+	_, err := b.client.DeleteObjectWithContext(context.Background(), &s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.prefix + key),
+	})
+	if err != nil && !isS3Status(err, http.StatusNotFound) {
+		return errors.Wrapf(err, "deleting %s", b.URL(key))
+	}
+	return nil
+}
+
+func (b *s3Bucket) List(prefix string) ([]string, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(b.bucket),
+		Prefix: aws.String(b.prefix + prefix),
+	}
+
+	var keys []string
+	err := b.client.ListObjectsV2PagesWithContext(context.Background(), input,
+		func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+			for _, obj := range page.Contents {
+				keys = append(keys, strings.TrimPrefix(aws.StringValue(obj.Key), b.prefix))
+			}
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing %s", b.URL(prefix))
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// isS3Status returns true if the given error is from an S3 request that failed with the given HTTP status.
+func isS3Status(err error, status int) bool {
+	reqErr, ok := err.(awserr.RequestFailure)
+	return ok && reqErr.StatusCode() == status
+}
@@ -0,0 +1,233 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+// fakeS3 is a minimal in-memory implementation of the parts of the S3 API used by s3Bucket.
+type fakeS3 struct {
+	s3iface.S3API
+
+	bucket  string
+	objects map[string][]byte
+	lock    sync.Mutex
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{bucket: "state", objects: make(map[string][]byte)}
+}
+
+// s3Failure returns the error the S3 client reports for a request that failed with the given status.
+func s3Failure(code string, status int) error {
+	return awserr.NewRequestFailure(awserr.New(code, http.StatusText(status), nil), status, "")
+}
+
+func (s *fakeS3) GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput,
+	opts ...request.Option) (*s3.GetObjectOutput, error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	data, ok := s.objects[aws.StringValue(input.Key)]
+	if aws.StringValue(input.Bucket) != s.bucket || !ok {
+		return nil, s3Failure(s3.ErrCodeNoSuchKey, http.StatusNotFound)
+	}
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(strings.NewReader(string(data)))}, nil
+}
+
+func (s *fakeS3) PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput,
+	opts ...request.Option) (*s3.PutObjectOutput, error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Apply the request options to find any conditions they add to the request.
+	req := &request.Request{HTTPRequest: &http.Request{Header: make(http.Header)}}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	key := aws.StringValue(input.Key)
+	if _, ok := s.objects[key]; ok && req.HTTPRequest.Header.Get("If-None-Match") == "*" {
+		return nil, s3Failure("PreconditionFailed", http.StatusPreconditionFailed)
+	}
+	data, err := ioutil.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	s.objects[key] = data
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (s *fakeS3) DeleteObjectWithContext(ctx aws.Context, input *s3.DeleteObjectInput,
+	opts ...request.Option) (*s3.DeleteObjectOutput, error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.objects, aws.StringValue(input.Key))
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func (s *fakeS3) ListObjectsV2PagesWithContext(ctx aws.Context, input *s3.ListObjectsV2Input,
+	fn func(*s3.ListObjectsV2Output, bool) bool, opts ...request.Option) error {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var keys []string
+	for k := range s.objects {
+		if strings.HasPrefix(k, aws.StringValue(input.Prefix)) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	// Return one key per page to exercise paging.
+	for i, k := range keys {
+		page := &s3.ListObjectsV2Output{Contents: []*s3.Object{{Key: aws.String(k)}}}
+		if !fn(page, i == len(keys)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+// testBucket exercises the basic operations of a bucket.
+func testBucket(t *testing.T, b Bucket) {
+	_, err := b.ReadAll("a/missing")
+	assert.True(t, os.IsNotExist(err))
+
+	assert.NoError(t, b.WriteAll("a/one", []byte("1")))
+	assert.NoError(t, b.WriteAll("a/two", []byte("2")))
+	assert.NoError(t, b.WriteAll("b/three", []byte("3")))
+
+	data, err := b.ReadAll("a/one")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", string(data))
+
+	assert.NoError(t, b.WriteAll("a/one", []byte("one")))
+	data, err = b.ReadAll("a/one")
+	assert.NoError(t, err)
+	assert.Equal(t, "one", string(data))
+
+	keys, err := b.List("a/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a/one", "a/two"}, keys)
+
+	keys, err = b.List("c/")
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	// WriteNew must not replace an existing blob.
+	assert.NoError(t, b.WriteNew("lock", []byte("mine")))
+	err = b.WriteNew("lock", []byte("yours"))
+	assert.True(t, os.IsExist(err))
+	data, err = b.ReadAll("lock")
+	assert.NoError(t, err)
+	assert.Equal(t, "mine", string(data))
+
+	// Retrying a WriteNew that already succeeded, as happens when its response is lost, succeeds.
+	assert.NoError(t, b.WriteNew("lock", []byte("mine")))
+
+	assert.NoError(t, b.Delete("lock"))
+	assert.NoError(t, b.Delete("lock"))
+	_, err = b.ReadAll("lock")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFileBucket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-bucket")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, os.RemoveAll(dir)) }()
+
+	testBucket(t, newFileBucket(dir))
+}
+
+func TestS3Bucket(t *testing.T) {
+	s3 := newFakeS3()
+	b := &s3Bucket{client: s3, bucket: "state", prefix: "prefix/"}
+	testBucket(t, b)
+
+	// All keys must be stored underneath the bucket's prefix.
+	for key := range s3.objects {
+		assert.True(t, strings.HasPrefix(key, "prefix/"), key)
+	}
+	assert.Equal(t, "s3://state/prefix/a/one", b.URL("a/one"))
+}
+
+func TestS3Backend(t *testing.T) {
+	s3 := newFakeS3()
+	url := "s3://state/prefix?region=us-west-2"
+	assert.True(t, IsLocalBackendURL(url))
+	be, err := New(diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{}), url)
+	assert.NoError(t, err)
+	b := be.(*localBackend)
+	b.bucket.(*s3Bucket).client = s3
+
+	// Stacks written to the bucket can be listed and read back.
+	stackName := tokens.QName("dev")
+	_, err = b.saveStack(stackName, nil, nil)
+	assert.NoError(t, err)
+	_, ok := s3.objects["prefix/.pulumi/stacks/dev.json"]
+	assert.True(t, ok)
+
+	stacks, err := b.getLocalStacks()
+	assert.NoError(t, err)
+	assert.Equal(t, []tokens.QName{stackName}, stacks)
+
+	// Saving again keeps a backup of the previous checkpoint, which is not listed as a stack.
+	_, err = b.saveStack(stackName, nil, nil)
+	assert.NoError(t, err)
+	_, ok = s3.objects["prefix/.pulumi/stacks/dev.json.bak"]
+	assert.True(t, ok)
+	stacks, err = b.getLocalStacks()
+	assert.NoError(t, err)
+	assert.Equal(t, []tokens.QName{stackName}, stacks)
+
+	// Only one update may hold the stack's lock at a time.
+	assert.NoError(t, b.lockStack(stackName))
+	err = b.lockStack(stackName)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "pulumi cancel")
+	}
+	assert.NoError(t, b.unlockStack(stackName))
+	assert.NoError(t, b.lockStack(stackName))
+	assert.NoError(t, b.unlockStack(stackName))
+
+	// Removing the stack removes its checkpoint.
+	assert.NoError(t, b.removeStack(stackName))
+	stacks, err = b.getLocalStacks()
+	assert.NoError(t, err)
+	assert.Empty(t, stacks)
+}
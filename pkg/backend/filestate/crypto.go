@@ -15,13 +15,8 @@
 package filestate
 
 import (
-	cryptorand "crypto/rand"
-	"encoding/base64"
-	"fmt"
 	"os"
-	"strings"
-
-	"github.com/pkg/errors"
+	"sync"
 
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/tokens"
@@ -37,114 +32,106 @@ func readPassphrase(prompt string) (string, error) {
 	return cmdutil.ReadConsoleNoEcho(prompt)
 }
 
-// defaultCrypter gets the right value encrypter/decrypter given the project configuration.
-func defaultCrypter(stackName tokens.QName, cfg config.Map) (config.Crypter, error) {
-	// If there is no config, we can use a standard panic crypter.
-	if !cfg.HasSecureValue() {
-		return config.NewPanicCrypter(), nil
-	}
-
-	// Otherwise, we will use an encrypted one.
-	return symmetricCrypter(stackName)
+// lazyCrypter is a crypter that defers creating the stack's symmetric crypter--and thus prompting for a
+// passphrase--until a value actually needs to be encrypted or decrypted. This allows stacks whose state contains no
+// secrets to be read and written without a passphrase.
+type lazyCrypter struct {
+	stackName tokens.QName
+	crypter   config.Crypter
+	lock      sync.Mutex
 }
 
-// symmetricCrypter gets the right value encrypter/decrypter for this project.
-func symmetricCrypter(stackName tokens.QName) (config.Crypter, error) {
-	contract.Assertf(stackName != "", "stackName", "!= \"\"")
-
-	info, err := workspace.DetectProjectStack(stackName)
-	if err != nil {
-		return nil, err
-	}
+func (c *lazyCrypter) get() (config.Crypter, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
 
-	// If we have a salt, we can just use it.
-	if info.EncryptionSalt != "" {
-		phrase, phraseErr := readPassphrase("Enter your passphrase to unlock config/secrets\n" +
-			"    (set PULUMI_CONFIG_PASSPHRASE to remember)")
-		if phraseErr != nil {
-			return nil, phraseErr
+	if c.crypter == nil {
+		crypter, err := symmetricCrypter(c.stackName)
+		if err != nil {
+			return nil, err
 		}
-
-		crypter, crypterErr := symmetricCrypterFromPhraseAndState(phrase, info.EncryptionSalt)
-		if crypterErr != nil {
-			return nil, crypterErr
-		}
-
-		return crypter, nil
+		c.crypter = crypter
 	}
+	return c.crypter, nil
+}
 
-	// Here, the stack does not have an EncryptionSalt, so we will get a passphrase and create one
-	phrase, err := readPassphrase("Enter your passphrase to protect config/secrets")
+func (c *lazyCrypter) EncryptValue(plaintext string) (string, error) {
+	crypter, err := c.get()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	confirm, err := readPassphrase("Re-enter your passphrase to confirm")
+	return crypter.EncryptValue(plaintext)
+}
+
+func (c *lazyCrypter) DecryptValue(ciphertext string) (string, error) {
+	crypter, err := c.get()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	if phrase != confirm {
-		return nil, errors.New("passphrases do not match")
-	}
-
-	// Produce a new salt.
-	salt := make([]byte, 8)
-	_, err = cryptorand.Read(salt)
-	contract.Assertf(err == nil, "could not read from system random")
+	return crypter.DecryptValue(ciphertext)
+}
 
-	// Encrypt a message and store it with the salt so we can test if the password is correct later.
-	crypter := config.NewSymmetricCrypterFromPassphrase(phrase, salt)
-	msg, err := crypter.EncryptValue("pulumi")
-	contract.AssertNoError(err)
+// stackCrypter returns the crypter used to encrypt and decrypt the secret values in the given stack's state. The
+// crypter is shared by all operations on the stack so that the user is prompted for a passphrase at most once.
+func (b *localBackend) stackCrypter(stackName tokens.QName) config.Crypter {
+	b.cryptersLock.Lock()
+	defer b.cryptersLock.Unlock()
 
-	// Now store the result and save it.
-	info.EncryptionSalt = fmt.Sprintf("v1:%s:%s", base64.StdEncoding.EncodeToString(salt), msg)
-	if err = workspace.SaveProjectStack(stackName, info); err != nil {
-		return nil, err
+	if b.crypters == nil {
+		b.crypters = make(map[tokens.QName]*lazyCrypter)
 	}
-
-	return crypter, nil
+	crypter, ok := b.crypters[stackName]
+	if !ok {
+		crypter = &lazyCrypter{stackName: stackName}
+		b.crypters[stackName] = crypter
+	}
+	return crypter
 }
 
-// given a passphrase and an encryption state, construct a Crypter from it. Our encryption
-// state value is a version tag followed by version specific state information. Presently, we only have one version
-// we support (`v1`) which is AES-256-GCM using a key derived from a passphrase using 1,000,000 iterations of PDKDF2
-// using SHA256.
-func symmetricCrypterFromPhraseAndState(phrase string, state string) (config.Crypter, error) {
-	splits := strings.SplitN(state, ":", 3)
-	if len(splits) != 3 {
-		return nil, errors.New("malformed state value")
-	}
+// symmetricCrypter gets the right value encrypter/decrypter for this project, using the stack's secrets provider.
+func symmetricCrypter(stackName tokens.QName) (config.Crypter, error) {
+	contract.Require(stackName != "", "stackName")
 
-	if splits[0] != "v1" {
-		return nil, errors.New("unknown state version")
+	info, err := workspace.DetectProjectStack(stackName)
+	if err != nil {
+		return nil, err
 	}
 
-	salt, err := base64.StdEncoding.DecodeString(splits[1])
+	provider, err := config.NewSecretsProvider(info.SecretsProvider, readPassphrase)
 	if err != nil {
 		return nil, err
 	}
 
-	decrypter := config.NewSymmetricCrypterFromPassphrase(phrase, salt)
-	decrypted, err := decrypter.DecryptValue(state[indexN(state, ":", 2)+1:])
-	if err != nil || decrypted != "pulumi" {
-		return nil, errors.New("incorrect passphrase")
+	// If the provider had to create new state for this stack, save it so that we can recover the key later.
+	state := config.SecretsProviderState{EncryptionSalt: info.EncryptionSalt, EncryptedKey: info.EncryptedKey}
+	crypter, changed, err := provider.Crypter(&state)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		info.EncryptionSalt, info.EncryptedKey = state.EncryptionSalt, state.EncryptedKey
+		if err = workspace.SaveProjectStack(stackName, info); err != nil {
+			return nil, err
+		}
 	}
 
-	return decrypter, nil
+	return crypter, nil
 }
 
-func indexN(s string, substr string, n int) int {
-	contract.Require(n > 0, "n")
-	scratch := s
+// reencryptConfig returns a copy of the given configuration in which each secure value, including any secure leaves
+// of objects, has been decrypted using dec and re-encrypted using enc.
+func reencryptConfig(m config.Map, dec config.Decrypter, enc config.Encrypter) (config.Map, error) {
+	if m == nil {
+		return nil, nil
+	}
 
-	for i := n; i > 0; i-- {
-		idx := strings.Index(scratch, substr)
-		if i == -1 {
-			return -1
+	result := make(config.Map)
+	for k, v := range m {
+		copied, err := v.Copy(dec, enc)
+		if err != nil {
+			return nil, err
 		}
-
-		scratch = scratch[idx+1:]
+		result[k] = copied
 	}
-
-	return len(s) - (len(scratch) + len(substr))
+	return result, nil
 }
@@ -0,0 +1,192 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"os/user"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// lockContent is the information recorded in a stack's lock file, describing who holds the lock.
+type lockContent struct {
+	Owner     string    `json:"owner"`     // the user that acquired the lock.
+	PID       int       `json:"pid"`       // the process that acquired the lock.
+	Host      string    `json:"host"`      // the host on which that process is running.
+	Nonce     string    `json:"nonce"`     // a random value that distinguishes this acquisition from any other.
+	Timestamp time.Time `json:"timestamp"` // the time at which the lock was acquired.
+}
+
+// errMalformedLock is returned when a stack's lock file cannot be parsed.
+var errMalformedLock = errors.New("the lock file is malformed")
+
+// heldBySame returns true if the given lock content records the same acquisition of a lock as this one does.
+func (c lockContent) heldBySame(other lockContent) bool {
+	return c.Owner == other.Owner && c.PID == other.PID && c.Host == other.Host && c.Nonce == other.Nonce
+}
+
+// lockPath returns the path of the advisory lock file for the given stack.
+func (b *localBackend) lockPath(stack tokens.QName) string {
+	contract.Require(stack != "", "stack")
+	return path.Join(workspace.BookkeepingDir, workspace.LockDir, qnamePath(stack)+".json")
+}
+
+// lockStack acquires the advisory lock for the given stack, failing if another process already holds it. The lock
+// file is created exclusively, so that two processes sharing the same state cannot both acquire it.
+func (b *localBackend) lockStack(stack tokens.QName) error {
+	nonce := make([]byte, 16)
+	_, err := cryptorand.Read(nonce)
+	contract.AssertNoError(err)
+
+	content := lockContent{
+		PID:       os.Getpid(),
+		Nonce:     hex.EncodeToString(nonce),
+		Timestamp: time.Now(),
+	}
+	if u, uerr := user.Current(); uerr == nil {
+		content.Owner = u.Username
+	}
+	if host, herr := os.Hostname(); herr == nil {
+		content.Host = host
+	}
+	byts, err := json.Marshal(content)
+	contract.AssertNoError(err)
+
+	lockPath := b.lockPath(stack)
+	if err = b.bucket.WriteNew(lockPath, byts); err != nil {
+		if os.IsExist(err) {
+			return b.lockedError(stack)
+		}
+		return errors.Wrap(err, "acquiring stack lock")
+	}
+
+	b.locksLock.Lock()
+	if b.locks == nil {
+		b.locks = make(map[tokens.QName]lockContent)
+	}
+	b.locks[stack] = content
+	b.locksLock.Unlock()
+
+	logging.V(7).Infof("Acquired lock for stack %s: %s", stack, lockPath)
+	return nil
+}
+
+// unlockStack releases the advisory lock for the given stack, which must have been acquired by this backend. If the
+// lock is no longer ours, because it was removed by `pulumi cancel` and perhaps acquired by another update since, it
+// is left in place.
+func (b *localBackend) unlockStack(stack tokens.QName) error {
+	b.locksLock.Lock()
+	content, ok := b.locks[stack]
+	delete(b.locks, stack)
+	b.locksLock.Unlock()
+	if !ok {
+		return errors.Errorf("the lock for stack '%s' is not held by this process", stack)
+	}
+
+	if err := b.removeLock(stack, content); err != nil {
+		return err
+	}
+
+	logging.V(7).Infof("Released lock for stack %s: %s", stack, b.lockPath(stack))
+	return nil
+}
+
+// readLock reads the content of the given stack's lock file.
+func (b *localBackend) readLock(stack tokens.QName) (lockContent, error) {
+	var content lockContent
+	byts, err := b.bucket.ReadAll(b.lockPath(stack))
+	if err != nil {
+		return content, err
+	}
+	if err = json.Unmarshal(byts, &content); err != nil {
+		return content, errMalformedLock
+	}
+	return content, nil
+}
+
+// removeLock deletes the given stack's lock file, provided that it still records the given acquisition of the lock.
+// The bucket offers no conditional delete, so the lock could in principle change hands between the check and the
+// delete; that would require the lock to be released and re-acquired in that window, though, which is unlikely.
+func (b *localBackend) removeLock(stack tokens.QName, content lockContent) error {
+	current, err := b.readLock(stack)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.Errorf("the lock for stack '%s' was removed by another process", stack)
+		}
+		return errors.Wrap(err, "releasing stack lock")
+	}
+	if !current.heldBySame(content) {
+		return errors.Errorf("the lock for stack '%s' is now held by %s on %s (pid %d); leaving it in place",
+			stack, current.Owner, current.Host, current.PID)
+	}
+
+	if err = b.bucket.Delete(b.lockPath(stack)); err != nil {
+		return errors.Wrap(err, "releasing stack lock")
+	}
+	return nil
+}
+
+// cancelLock forcibly removes the given stack's lock, on behalf of `pulumi cancel`. A lock held by a process that is
+// still running on this host is left alone, as removing it would let another update run alongside that one.
+func (b *localBackend) cancelLock(stack tokens.QName) error {
+	content, err := b.readLock(stack)
+	switch {
+	case os.IsNotExist(err):
+		return errors.Errorf("the stack '%s' has no update in progress", stack)
+	case err == errMalformedLock:
+		// There is no owner to check, so remove the lock outright.
+		logging.V(7).Infof("Removing malformed lock for stack %s", stack)
+		return b.bucket.Delete(b.lockPath(stack))
+	case err != nil:
+		return err
+	}
+
+	if host, herr := os.Hostname(); herr == nil && host == content.Host && processExists(content.PID) {
+		return errors.Errorf("the update holding the lock for stack '%s' is still running (pid %d); stop that "+
+			"process instead of canceling its update", stack, content.PID)
+	}
+
+	if err = b.removeLock(stack, content); err != nil {
+		return err
+	}
+
+	logging.V(7).Infof("Removed lock for stack %s held by %s on %s (pid %d) since %s", stack, content.Owner,
+		content.Host, content.PID, content.Timestamp.Format(time.RFC1123))
+	return nil
+}
+
+// lockedError returns an error describing the current holder of the given stack's lock.
+func (b *localBackend) lockedError(stack tokens.QName) error {
+	content, err := b.readLock(stack)
+	if err == errMalformedLock {
+		return errors.Errorf("the stack '%s' is locked by another update (the lock file is malformed)", stack)
+	} else if err != nil {
+		return errors.Errorf("the stack '%s' is locked by another update", stack)
+	}
+	return errors.Errorf("the stack '%s' is locked by %s on %s (pid %d) since %s; if that update is no longer "+
+		"running, use `pulumi cancel` to remove the lock", stack, content.Owner, content.Host, content.PID,
+		content.Timestamp.Format(time.RFC1123))
+}
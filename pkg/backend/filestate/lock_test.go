@@ -0,0 +1,161 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+// newLockTestBackends returns two backends that share the same file system state, as two processes would.
+func newLockTestBackends(t *testing.T) (*localBackend, *localBackend, func()) {
+	dir, err := ioutil.TempDir("", "filestate-lock")
+	assert.NoError(t, err)
+
+	sink := diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{})
+	b1, err := New(sink, "file://"+dir)
+	assert.NoError(t, err)
+	b2, err := New(sink, "file://"+dir)
+	assert.NoError(t, err)
+	return b1.(*localBackend), b2.(*localBackend), func() { _ = os.RemoveAll(dir) }
+}
+
+// writeLock replaces a stack's lock file with one recording the given content.
+func writeLock(t *testing.T, b *localBackend, stack tokens.QName, content lockContent) {
+	byts, err := json.Marshal(content)
+	assert.NoError(t, err)
+	assert.NoError(t, b.bucket.WriteAll(b.lockPath(stack), byts))
+}
+
+func TestLockStack(t *testing.T) {
+	b1, b2, cleanup := newLockTestBackends(t)
+	defer cleanup()
+	stack := tokens.QName("dev")
+
+	// Only one backend may hold the lock at a time, and the error names its holder.
+	assert.NoError(t, b1.lockStack(stack))
+	err := b2.lockStack(stack)
+	if assert.Error(t, err) {
+		content, rerr := b1.readLock(stack)
+		assert.NoError(t, rerr)
+		assert.Equal(t, os.Getpid(), content.PID)
+		assert.NotEmpty(t, content.Nonce)
+		assert.Contains(t, err.Error(), fmt.Sprintf("the stack 'dev' is locked by %s on %s (pid %d) since",
+			content.Owner, content.Host, content.PID))
+		assert.Contains(t, err.Error(), "use `pulumi cancel` to remove the lock")
+	}
+
+	// A backend that does not hold the lock may not release it.
+	assert.Error(t, b2.unlockStack(stack))
+
+	// Once released, the lock may be acquired by the other backend.
+	assert.NoError(t, b1.unlockStack(stack))
+	assert.NoError(t, b2.lockStack(stack))
+	assert.NoError(t, b2.unlockStack(stack))
+	_, err = b1.readLock(stack)
+	assert.True(t, os.IsNotExist(err))
+
+	// A malformed lock file still blocks other updates.
+	assert.NoError(t, b1.bucket.WriteAll(b1.lockPath(stack), []byte("{")))
+	err = b2.lockStack(stack)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "the lock file is malformed")
+	}
+}
+
+func TestChangeSecretsProviderLocked(t *testing.T) {
+	b1, b2, cleanup := newLockTestBackends(t)
+	defer cleanup()
+	stack := tokens.QName("dev")
+
+	// Changing the secrets provider rewrites the stack's state, so it may not proceed while an update holds the lock.
+	assert.NoError(t, b1.lockStack(stack))
+	err := b2.ChangeSecretsProvider(context.Background(), localBackendReference{name: stack}, "keyfile:/unused")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "the stack 'dev' is locked by")
+	}
+	assert.NoError(t, b1.unlockStack(stack))
+}
+
+func TestUnlockStackNotOwner(t *testing.T) {
+	b1, b2, cleanup := newLockTestBackends(t)
+	defer cleanup()
+	stack := tokens.QName("dev")
+
+	// If the lock has changed hands since it was acquired, releasing it must leave the new holder's lock in place.
+	assert.NoError(t, b1.lockStack(stack))
+	other := lockContent{Owner: "other", PID: 1, Host: "elsewhere", Nonce: "other", Timestamp: time.Now()}
+	writeLock(t, b1, stack, other)
+	err := b1.unlockStack(stack)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is now held by other on elsewhere (pid 1)")
+	}
+	content, err := b2.readLock(stack)
+	assert.NoError(t, err)
+	assert.Equal(t, "other", content.Nonce)
+
+	// If the lock has been removed, releasing it reports as much.
+	assert.NoError(t, b1.bucket.Delete(b1.lockPath(stack)))
+	assert.NoError(t, b1.lockStack(stack))
+	assert.NoError(t, b2.bucket.Delete(b2.lockPath(stack)))
+	err = b1.unlockStack(stack)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "was removed by another process")
+	}
+}
+
+func TestCancelCurrentUpdate(t *testing.T) {
+	b1, b2, cleanup := newLockTestBackends(t)
+	defer cleanup()
+	stack := tokens.QName("dev")
+	ref := localBackendReference{name: stack}
+
+	err := b2.CancelCurrentUpdate(context.Background(), ref)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "has no update in progress")
+	}
+
+	// A lock held by a process that is still running on this host is not removed.
+	assert.NoError(t, b1.lockStack(stack))
+	err = b2.CancelCurrentUpdate(context.Background(), ref)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is still running")
+	}
+	_, err = b2.readLock(stack)
+	assert.NoError(t, err)
+	assert.NoError(t, b1.unlockStack(stack))
+
+	// A lock held by a process on another host cannot be checked, so it is removed.
+	writeLock(t, b1, stack, lockContent{Owner: "other", PID: 1, Host: "elsewhere", Nonce: "other"})
+	assert.NoError(t, b2.CancelCurrentUpdate(context.Background(), ref))
+	_, err = b2.readLock(stack)
+	assert.True(t, os.IsNotExist(err))
+
+	// So is a malformed lock.
+	assert.NoError(t, b1.bucket.WriteAll(b1.lockPath(stack), []byte("{")))
+	assert.NoError(t, b2.CancelCurrentUpdate(context.Background(), ref))
+	_, err = b2.readLock(stack)
+	assert.True(t, os.IsNotExist(err))
+}
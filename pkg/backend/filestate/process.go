@@ -0,0 +1,27 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !windows
+
+package filestate
+
+import "syscall"
+
+// processExists returns true if a process with the given ID is running on this host.
+func processExists(pid int) bool {
+	// Signal 0 performs the existence and permission checks without sending anything. EPERM means that the process
+	// exists but belongs to another user.
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
@@ -0,0 +1,34 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build windows
+
+package filestate
+
+import (
+	"os"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// processExists returns true if a process with the given ID is running on this host.
+func processExists(pid int) bool {
+	// On Windows, FindProcess opens a handle to the process, which fails if there is no such process.
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	contract.IgnoreError(p.Release())
+	return true
+}
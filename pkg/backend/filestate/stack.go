@@ -29,23 +29,23 @@ import (
 // Stack is a local stack.  This simply adds some local-specific properties atop the standard backend stack interface.
 type Stack interface {
 	backend.Stack
-	Path() string // a path to the stack's checkpoint file on disk.
+	Path() string // a URL that identifies the stack's checkpoint file.
 }
 
 // localStack is a local stack descriptor.
 type localStack struct {
 	ref      backend.StackReference // the stack's reference (qualified name).
-	path     string                 // a path to the stack's checkpoint file on disk.
+	path     string                 // a URL that identifies the stack's checkpoint file.
 	config   config.Map             // the stack's config bag.
 	snapshot *deploy.Snapshot       // a snapshot representing the latest deployment state.
 	b        *localBackend          // a pointer to the backend this stack belongs to.
 }
 
-func newStack(ref backend.StackReference, path string, config config.Map,
+func newStack(ref backend.StackReference, key string, config config.Map,
 	snapshot *deploy.Snapshot, b *localBackend) Stack {
 	return &localStack{
 		ref:      ref,
-		path:     path,
+		path:     b.bucket.URL(key),
 		config:   config,
 		snapshot: snapshot,
 		b:        b,
@@ -17,7 +17,6 @@ package filestate
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
@@ -42,6 +41,11 @@ import (
 
 const DisableCheckpointBackupsEnvVar = "PULUMI_DISABLE_CHECKPOINT_BACKUPS"
 
+const (
+	historyFileSuffix    = ".history.json"    // the suffix of the files that record each update's information.
+	checkpointFileSuffix = ".checkpoint.json" // the suffix of the files that hold the checkpoint of each update.
+)
+
 // DisableIntegrityChecking can be set to true to disable checkpoint state integrity verification.  This is not
 // recommended, because it could mean proceeding even in the face of a corrupted checkpoint state file, but can
 // be used as a last resort when a command absolutely must be run.
@@ -90,10 +94,6 @@ func (b *localBackend) getTarget(stackName tokens.QName) (*deploy.Target, error)
 	if err != nil {
 		return nil, err
 	}
-	decrypter, err := defaultCrypter(stackName, stk.Config)
-	if err != nil {
-		return nil, err
-	}
 	_, snapshot, _, err := b.getStack(stackName)
 	if err != nil {
 		return nil, err
@@ -101,7 +101,7 @@ func (b *localBackend) getTarget(stackName tokens.QName) (*deploy.Target, error)
 	return &deploy.Target{
 		Name:      stackName,
 		Config:    stk.Config,
-		Decrypter: decrypter,
+		Decrypter: b.stackCrypter(stackName),
 		Snapshot:  snapshot,
 	}, nil
 }
@@ -119,7 +119,7 @@ func (b *localBackend) getStack(name tokens.QName) (config.Map, *deploy.Snapshot
 	}
 
 	// Materialize an actual snapshot object.
-	snapshot, err := stack.DeserializeCheckpoint(chk)
+	snapshot, err := stack.DeserializeCheckpoint(chk, b.stackCrypter(name))
 	if err != nil {
 		return nil, nil, "", err
 	}
@@ -138,7 +138,7 @@ func (b *localBackend) getStack(name tokens.QName) (config.Map, *deploy.Snapshot
 // GetCheckpoint loads a checkpoint file for the given stack in this project, from the current project workspace.
 func (b *localBackend) getCheckpoint(stackName tokens.QName) (*apitype.CheckpointV2, error) {
 	chkpath := b.stackPath(stackName)
-	bytes, err := ioutil.ReadFile(chkpath)
+	bytes, err := b.bucket.ReadAll(chkpath)
 	if err != nil {
 		return nil, err
 	}
@@ -154,25 +154,23 @@ func (b *localBackend) saveStack(name tokens.QName,
 	if m == nil {
 		return "", errors.Errorf("resource serialization failed; illegal markup extension: '%v'", ext)
 	}
-	if filepath.Ext(file) == "" {
+	if path.Ext(file) == "" {
 		file = file + ext
 	}
-	chk := stack.SerializeCheckpoint(name, config, snap)
+	chk, err := stack.SerializeCheckpoint(name, config, snap, b.stackCrypter(name))
+	if err != nil {
+		return "", errors.Wrap(err, "serializing checkpoint")
+	}
 	byts, err := m.Marshal(chk)
 	if err != nil {
 		return "", errors.Wrap(err, "An IO error occurred during the current operation")
 	}
 
 	// Back up the existing file if it already exists.
-	bck := backupTarget(file)
-
-	// Ensure the directory exists.
-	if err = os.MkdirAll(filepath.Dir(file), 0700); err != nil {
-		return "", errors.Wrap(err, "An IO error occurred during the current operation")
-	}
+	bck := b.backupTarget(file)
 
 	// And now write out the new snapshot file, overwriting that location.
-	if err = ioutil.WriteFile(file, byts, 0600); err != nil {
+	if err = b.bucket.WriteAll(file, byts); err != nil {
 		return "", errors.Wrap(err, "An IO error occurred during the current operation")
 	}
 
@@ -180,7 +178,7 @@ func (b *localBackend) saveStack(name tokens.QName,
 
 	// And if we are retaining historical checkpoint information, write it out again
 	if cmdutil.IsTruthy(os.Getenv("PULUMI_RETAIN_CHECKPOINTS")) {
-		if err = ioutil.WriteFile(fmt.Sprintf("%v.%v", file, time.Now().UnixNano()), byts, 0600); err != nil {
+		if err = b.bucket.WriteAll(fmt.Sprintf("%v.%v", file, time.Now().UnixNano()), byts); err != nil {
 			return "", errors.Wrap(err, "An IO error occurred during the current operation")
 		}
 	}
@@ -205,19 +203,30 @@ func (b *localBackend) removeStack(name tokens.QName) error {
 
 	// Just make a backup of the file and don't write out anything new.
 	file := b.stackPath(name)
-	backupTarget(file)
+	b.backupTarget(file)
+	if err := b.bucket.Delete(file); err != nil {
+		return err
+	}
 
-	historyDir := b.historyDirectory(name)
-	return os.RemoveAll(historyDir)
+	historyFiles, err := b.bucket.List(b.historyDirectory(name) + "/")
+	if err != nil {
+		return err
+	}
+	for _, historyFile := range historyFiles {
+		if err = b.bucket.Delete(historyFile); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// backupTarget makes a backup of an existing file, in preparation for writing a new one.  Instead of a copy, it
-// simply renames the file, which is simpler, more efficient, etc.
-func backupTarget(file string) string {
+// backupTarget makes a backup of an existing file, in preparation for writing a new one.
+func (b *localBackend) backupTarget(file string) string {
 	contract.Require(file != "", "file")
 	bck := file + ".bak"
-	err := os.Rename(file, bck)
-	contract.IgnoreError(err) // ignore errors.
+	if byts, err := b.bucket.ReadAll(file); err == nil {
+		contract.IgnoreError(b.bucket.WriteAll(bck, byts)) // ignore errors.
+	}
 	// IDEA: consider multiple backups (.bak.bak.bak...etc).
 	return bck
 }
@@ -233,7 +242,7 @@ func (b *localBackend) backupStack(name tokens.QName) error {
 
 	// Read the current checkpoint file. (Assuming it aleady exists.)
 	stackPath := b.stackPath(name)
-	byts, err := ioutil.ReadFile(stackPath)
+	byts, err := b.bucket.ReadAll(stackPath)
 	if err != nil {
 		return err
 	}
@@ -241,36 +250,38 @@ func (b *localBackend) backupStack(name tokens.QName) error {
 	// Get the backup directory.
 	backupDir := b.backupDirectory(name)
 
-	// Ensure the backup directory exists.
-	if err = os.MkdirAll(backupDir, 0700); err != nil {
-		return err
-	}
-
 	// Write out the new backup checkpoint file.
-	stackFile := filepath.Base(stackPath)
-	ext := filepath.Ext(stackFile)
+	stackFile := path.Base(stackPath)
+	ext := path.Ext(stackFile)
 	base := strings.TrimSuffix(stackFile, ext)
 	backupFile := fmt.Sprintf("%s.%v%s", base, time.Now().UnixNano(), ext)
-	return ioutil.WriteFile(filepath.Join(backupDir, backupFile), byts, 0600)
+	return b.bucket.WriteAll(path.Join(backupDir, backupFile), byts)
 }
 
+// stackPath returns the key of the given stack's checkpoint file within the backend's bucket or, if stack is empty,
+// the key of the directory that holds all checkpoint files.
 func (b *localBackend) stackPath(stack tokens.QName) string {
-	path := filepath.Join(b.StateDir(), workspace.StackDir)
+	p := path.Join(workspace.BookkeepingDir, workspace.StackDir)
 	if stack != "" {
-		path = filepath.Join(path, fsutil.QnamePath(stack)+".json")
+		p = path.Join(p, qnamePath(stack)+".json")
 	}
 
-	return path
+	return p
 }
 
 func (b *localBackend) historyDirectory(stack tokens.QName) string {
 	contract.Require(stack != "", "stack")
-	return filepath.Join(b.StateDir(), workspace.HistoryDir, fsutil.QnamePath(stack))
+	return path.Join(workspace.BookkeepingDir, workspace.HistoryDir, qnamePath(stack))
 }
 
 func (b *localBackend) backupDirectory(stack tokens.QName) string {
 	contract.Require(stack != "", "stack")
-	return filepath.Join(b.StateDir(), workspace.BackupDir, fsutil.QnamePath(stack))
+	return path.Join(workspace.BookkeepingDir, workspace.BackupDir, qnamePath(stack))
+}
+
+// qnamePath returns the slash-separated key for the given QName.
+func qnamePath(nm tokens.QName) string {
+	return filepath.ToSlash(fsutil.QnamePath(nm))
 }
 
 // getHistory returns locally stored update history. The first element of the result will be
@@ -278,38 +289,28 @@ func (b *localBackend) backupDirectory(stack tokens.QName) string {
 func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, error) {
 	contract.Require(name != "", "name")
 
-	dir := b.historyDirectory(name)
-	allFiles, err := ioutil.ReadDir(dir)
+	historyFiles, err := b.historyFiles(name)
 	if err != nil {
-		// History doesn't exist until a stack has been updated.
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
 		return nil, err
 	}
 
 	var updates []backend.UpdateInfo
 
-	// os.ReadDir returns the array sorted by file name, but because of how we name files, older updates come before
-	// newer ones. Loop backwards so we added the newest updates to the array we will return first.
-	for i := len(allFiles) - 1; i >= 0; i-- {
-		file := allFiles[i]
-		filepath := path.Join(dir, file.Name())
-
-		// Open all of the history files, ignoring the checkpoints.
-		if !strings.HasSuffix(filepath, ".history.json") {
-			continue
-		}
+	// Loop backwards so we added the newest updates to the array we will return first. Updates are numbered in the
+	// order in which they occurred, starting from 1.
+	for i := len(historyFiles) - 1; i >= 0; i-- {
+		filepath := historyFiles[i]
 
 		var update backend.UpdateInfo
-		b, err := ioutil.ReadFile(filepath)
+		byts, err := b.bucket.ReadAll(filepath)
 		if err != nil {
 			return nil, errors.Wrapf(err, "reading history file %s", filepath)
 		}
-		err = json.Unmarshal(b, &update)
+		err = json.Unmarshal(byts, &update)
 		if err != nil {
 			return nil, errors.Wrapf(err, "reading history file %s", filepath)
 		}
+		update.Version = i + 1
 
 		updates = append(updates, update)
 	}
@@ -317,14 +318,51 @@ func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, erro
 	return updates, nil
 }
 
+// historyFiles returns the keys of the given stack's update history files, oldest first.
+func (b *localBackend) historyFiles(name tokens.QName) ([]string, error) {
+	// History doesn't exist until a stack has been updated, in which case this will return no files.
+	dir := b.historyDirectory(name)
+	allFiles, err := b.bucket.List(dir + "/")
+	if err != nil {
+		return nil, err
+	}
+
+	// List returns the array sorted by file name, and because of how we name files, older updates come before
+	// newer ones. Skip the checkpoints that accompany each history file.
+	var historyFiles []string
+	for _, file := range allFiles {
+		if strings.HasSuffix(file, historyFileSuffix) {
+			historyFiles = append(historyFiles, file)
+		}
+	}
+	return historyFiles, nil
+}
+
+// getHistoryCheckpoint returns the checkpoint that was saved at the end of the update with the given version.
+func (b *localBackend) getHistoryCheckpoint(name tokens.QName, version int) (*apitype.CheckpointV2, error) {
+	contract.Require(name != "", "name")
+
+	historyFiles, err := b.historyFiles(name)
+	if err != nil {
+		return nil, err
+	}
+	if version < 1 || version > len(historyFiles) {
+		return nil, errors.Errorf("stack '%s' has no update with version %d", name, version)
+	}
+
+	checkpointFile := strings.TrimSuffix(historyFiles[version-1], historyFileSuffix) + checkpointFileSuffix
+	byts, err := b.bucket.ReadAll(checkpointFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading checkpoint file %s", checkpointFile)
+	}
+	return stack.UnmarshalVersionedCheckpointToLatestCheckpoint(byts)
+}
+
 // addToHistory saves the UpdateInfo and makes a copy of the current Checkpoint file.
 func (b *localBackend) addToHistory(name tokens.QName, update backend.UpdateInfo) error {
 	contract.Require(name != "", "name")
 
 	dir := b.historyDirectory(name)
-	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-		return err
-	}
 
 	// Prefix for the update and checkpoint files.
 	pathPrefix := path.Join(dir, fmt.Sprintf("%s-%d", name, time.Now().UnixNano()))
@@ -335,17 +373,17 @@ func (b *localBackend) addToHistory(name tokens.QName, update backend.UpdateInfo
 		return err
 	}
 
-	historyFile := fmt.Sprintf("%s.history.json", pathPrefix)
-	if err = ioutil.WriteFile(historyFile, byts, os.ModePerm); err != nil {
+	historyFile := pathPrefix + historyFileSuffix
+	if err = b.bucket.WriteAll(historyFile, byts); err != nil {
 		return err
 	}
 
 	// Make a copy of the checkpoint file. (Assuming it aleady exists.)
-	byts, err = ioutil.ReadFile(b.stackPath(name))
+	byts, err = b.bucket.ReadAll(b.stackPath(name))
 	if err != nil {
 		return err
 	}
 
-	checkpointFile := fmt.Sprintf("%s.checkpoint.json", pathPrefix)
-	return ioutil.WriteFile(checkpointFile, byts, os.ModePerm)
+	checkpointFile := pathPrefix + checkpointFileSuffix
+	return b.bucket.WriteAll(checkpointFile, byts)
 }
@@ -0,0 +1,92 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func TestHistoryVersions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate-history")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	b, err := New(diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{}), "file://"+dir)
+	assert.NoError(t, err)
+	lb := b.(*localBackend)
+
+	// Record two updates, each of which leaves a different resource behind.
+	name := tokens.QName("history")
+	for i, resName := range []tokens.QName{"first", "second"} {
+		res := &resource.State{
+			Type:    "a:b:c",
+			URN:     resource.NewURN(name, "proj", "", "a:b:c", resName),
+			Custom:  true,
+			ID:      resource.ID(resName),
+			Inputs:  resource.PropertyMap{},
+			Outputs: resource.PropertyMap{},
+		}
+		snap := deploy.NewSnapshot(deploy.Manifest{Time: time.Now()}, []*resource.State{res}, nil)
+		_, err = lb.saveStack(name, nil, snap)
+		assert.NoError(t, err)
+		err = lb.addToHistory(name, backend.UpdateInfo{
+			Kind:      apitype.UpdateUpdate,
+			StartTime: int64(i),
+			Result:    backend.SucceededResult,
+		})
+		assert.NoError(t, err)
+	}
+
+	// The history is returned newest first, and updates are numbered from oldest to newest.
+	ref := localBackendReference{name: name}
+	updates, err := lb.GetHistory(context.Background(), ref)
+	assert.NoError(t, err)
+	if assert.Len(t, updates, 2) {
+		assert.Equal(t, 2, updates[0].Version)
+		assert.Equal(t, int64(1), updates[0].StartTime)
+		assert.Equal(t, 1, updates[1].Version)
+		assert.Equal(t, int64(0), updates[1].StartTime)
+	}
+
+	// Each version's deployment is the one that its update left behind.
+	for version, resName := range map[int]tokens.QName{1: "first", 2: "second"} {
+		dep, err := lb.ExportDeploymentVersion(context.Background(), ref, version)
+		assert.NoError(t, err)
+		snap, err := stack.DeserializeUntypedDeployment(dep, lb.stackCrypter(name))
+		assert.NoError(t, err)
+		if assert.Len(t, snap.Resources, 1) {
+			assert.Equal(t, resName, snap.Resources[0].URN.Name())
+		}
+	}
+
+	_, err = lb.ExportDeploymentVersion(context.Background(), ref, 3)
+	assert.Error(t, err)
+	_, err = lb.ExportDeploymentVersion(context.Background(), ref, 0)
+	assert.Error(t, err)
+}
@@ -707,10 +707,12 @@ func (b *cloudBackend) createAndStartUpdate(
 // apply actually performs the provided type of update on a stack hosted in the Pulumi Cloud.
 func (b *cloudBackend) apply(ctx context.Context, kind apitype.UpdateKind, stack backend.Stack,
 	op backend.UpdateOperation, opts backend.ApplierOptions, events chan<- engine.Event) (engine.ResourceChanges, error) {
-	// Print a banner so it's clear this is going to the cloud.
-	actionLabel := backend.ActionLabel(kind, opts.DryRun)
-	fmt.Printf(op.Opts.Display.Color.Colorize(
-		colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stack.Ref())
+	// Print a banner so it's clear this is going to the cloud, unless the events are being emitted as JSON.
+	if !op.Opts.Display.JSONDisplay {
+		actionLabel := backend.ActionLabel(kind, opts.DryRun)
+		fmt.Printf(op.Opts.Display.Color.Colorize(
+			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stack.Ref())
+	}
 
 	// Create an update object to persist results.
 	update, version, token, err := b.createAndStartUpdate(ctx, kind, stack.Ref(), op, opts.DryRun)
@@ -718,7 +720,7 @@ func (b *cloudBackend) apply(ctx context.Context, kind apitype.UpdateKind, stack
 		return nil, err
 	}
 
-	if opts.ShowLink {
+	if opts.ShowLink && !op.Opts.Display.JSONDisplay {
 		// Print a URL at the end of the update pointing to the Pulumi Service.
 		var link string
 		base := b.cloudConsoleStackPath(update.StackIdentifier)
@@ -778,7 +780,12 @@ func (b *cloudBackend) runEngineAction(
 		return nil, err
 	}
 
-	persister := b.newSnapshotPersister(ctx, u.update, u.tokenSource)
+	crypter, err := b.GetStackCrypter(stackRef)
+	if err != nil {
+		return nil, err
+	}
+
+	persister := b.newSnapshotPersister(ctx, u.update, u.tokenSource, crypter)
 	manager := backend.NewSnapshotManager(persister, u.GetTarget().Snapshot)
 	displayEvents := make(chan engine.Event)
 	displayDone := make(chan bool)
@@ -805,7 +812,12 @@ func (b *cloudBackend) runEngineAction(
 
 	// Depending on the action, kick off the relevant engine activity.  Note that we don't immediately check and
 	// return error conditions, because we will do so below after waiting for the display channels to close.
-	engineCtx := &engine.Context{Cancel: scope.Context(), Events: engineEvents, SnapshotManager: manager}
+	engineCtx := &engine.Context{
+		Cancel:          scope.Context(),
+		Events:          engineEvents,
+		SnapshotManager: manager,
+		BackendClient:   backend.NewBackendClient(b),
+	}
 	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
 		engineCtx.ParentSpan = parentSpan.Context()
 	}
@@ -899,6 +911,7 @@ func (b *cloudBackend) GetHistory(ctx context.Context, stackRef backend.StackRef
 			StartTime:       update.StartTime,
 			EndTime:         update.EndTime,
 			ResourceChanges: convertResourceChanges(update.ResourceChanges),
+			Version:         update.Version,
 		})
 	}
 
@@ -933,7 +946,9 @@ func convertConfig(apiConfig map[string]apitype.ConfigValue) (config.Map, error)
 		if err != nil {
 			return nil, err
 		}
-		if rawV.Secret {
+		if rawV.Object {
+			c[k] = config.NewObjectValue(rawV.String)
+		} else if rawV.Secret {
 			c[k] = config.NewSecureValue(rawV.String)
 		} else {
 			c[k] = config.NewValue(rawV.String)
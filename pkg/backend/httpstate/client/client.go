@@ -193,7 +193,9 @@ func (pc *Client) GetLatestConfiguration(ctx context.Context, stackID StackIdent
 		if err != nil {
 			return nil, err
 		}
-		if v.Secret {
+		if v.Object {
+			cfg[newKey] = config.NewObjectValue(v.String)
+		} else if v.Secret {
 			cfg[newKey] = config.NewSecureValue(v.String)
 		} else {
 			cfg[newKey] = config.NewValue(v.String)
@@ -347,6 +349,7 @@ func (pc *Client) CreateUpdate(
 		wireConfig[k.String()] = apitype.ConfigValue{
 			String: v,
 			Secret: cv.Secure(),
+			Object: cv.Object(),
 		}
 	}
 
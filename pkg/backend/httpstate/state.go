@@ -241,7 +241,12 @@ func (b *cloudBackend) getSnapshot(ctx context.Context, stackRef backend.StackRe
 		return nil, err
 	}
 
-	snapshot, err := stack.DeserializeUntypedDeployment(untypedDeployment)
+	crypter, err := b.GetStackCrypter(stackRef)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := stack.DeserializeUntypedDeployment(untypedDeployment, crypter)
 	if err != nil {
 		return nil, err
 	}
@@ -64,6 +64,9 @@ type SnapshotManager struct {
 	mutationRequests chan<- mutationRequest   // The queue of mutation requests, to be retired serially by the manager
 	cancel           chan bool                // A channel used to request cancellation of any new mutation requests.
 	done             <-chan error             // A channel that sends a single result when the manager has shut down.
+
+	// The set of old URNs that have been renamed, mapped to their new URNs
+	aliases map[resource.URN]resource.URN
 }
 
 var _ engine.SnapshotManager = (*SnapshotManager)(nil)
@@ -149,6 +152,8 @@ func (sm *SnapshotManager) BeginMutation(step deploy.Step) (engine.SnapshotMutat
 		return &replaceSnapshotMutation{sm}, nil
 	case deploy.OpRead, deploy.OpReadReplacement:
 		return sm.doRead(step)
+	case deploy.OpImport:
+		return sm.doImport(step)
 	case deploy.OpRefresh:
 		return &refreshSnapshotMutation{sm}, nil
 	}
@@ -175,10 +180,14 @@ type sameSnapshotMutation struct {
 // this step can be elided.
 func (ssm *sameSnapshotMutation) mustWrite(old, new *resource.State) bool {
 	contract.Assert(old.Type == new.Type)
-	contract.Assert(old.URN == new.URN)
 	contract.Assert(old.Delete == new.Delete)
 	contract.Assert(old.External == new.External)
 
+	// If this resource has been renamed via an alias, we must write the checkpoint.
+	if old.URN != new.URN {
+		return true
+	}
+
 	// If the kind of this resource has changed, we must write the checkpoint.
 	if old.Custom != new.Custom {
 		return true
@@ -224,6 +233,7 @@ func (ssm *sameSnapshotMutation) End(step deploy.Step, successful bool) error {
 	return ssm.manager.mutate(func() bool {
 		ssm.manager.markDone(step.Old())
 		ssm.manager.markNew(step.New())
+		ssm.manager.markAliased(step.Old(), step.New())
 
 		// Note that "Same" steps only consider input and provider diffs, so it is possible to see a same step for a
 		// resource with new dependencies, outputs, parent, protection. etc.
@@ -271,6 +281,9 @@ func (csm *createSnapshotMutation) End(step deploy.Step, successful bool) error
 			// (we have pointers to engine-allocated objects), this transparently
 			// "just works" for the SnapshotManager.
 			csm.manager.markNew(step.New())
+			if old := step.Old(); old != nil {
+				csm.manager.markAliased(old, step.New())
+			}
 		}
 		return true
 	})
@@ -301,6 +314,7 @@ func (usm *updateSnapshotMutation) End(step deploy.Step, successful bool) error
 		if successful {
 			usm.manager.markDone(step.Old())
 			usm.manager.markNew(step.New())
+			usm.manager.markAliased(step.Old(), step.New())
 		}
 		return true
 	})
@@ -378,6 +392,41 @@ func (rsm *readSnapshotMutation) End(step deploy.Step, successful bool) error {
 	})
 }
 
+func (sm *SnapshotManager) doImport(step deploy.Step) (engine.SnapshotMutation, error) {
+	logging.V(9).Infof("SnapshotManager.doImport(%s)", step.URN())
+	err := sm.mutate(func() bool {
+		sm.markOperationPending(step.New(), resource.OperationTypeImporting)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &importSnapshotMutation{sm}, nil
+}
+
+type importSnapshotMutation struct {
+	manager *SnapshotManager
+}
+
+func (ism *importSnapshotMutation) End(step deploy.Step, successful bool) error {
+	contract.Require(step != nil, "step != nil")
+	contract.Require(step.Op() == deploy.OpImport, "step.Op() == deploy.OpImport")
+	logging.V(9).Infof("SnapshotManager: importSnapshotMutation.End(..., %v)", successful)
+	return ism.manager.mutate(func() bool {
+		ism.manager.markOperationComplete(step.New())
+		if successful {
+			// If this import adopted an external resource, the external resource's state is superseded.
+			if step.Old() != nil {
+				ism.manager.markDone(step.Old())
+			}
+
+			ism.manager.markNew(step.New())
+		}
+		return true
+	})
+}
+
 type refreshSnapshotMutation struct {
 	manager *SnapshotManager
 }
@@ -412,6 +461,17 @@ func (sm *SnapshotManager) markNew(state *resource.State) {
 	logging.V(9).Infof("Appended new state snapshot to be written: %v", state.URN)
 }
 
+// markAliased records that the given old state has been renamed to the URN of the given new state. All references to
+// the old URN will refer to the new URN in the snapshot.
+func (sm *SnapshotManager) markAliased(old, new *resource.State) {
+	contract.Assert(old != nil)
+	contract.Assert(new != nil)
+	if old.URN != new.URN {
+		sm.aliases[old.URN] = new.URN
+		logging.V(9).Infof("Marked old state snapshot as aliased: %v -> %v", old.URN, new.URN)
+	}
+}
+
 // markOperationPending marks a resource as undergoing an operation that will now be considered pending.
 func (sm *SnapshotManager) markOperationPending(state *resource.State, op resource.OperationType) {
 	contract.Assert(state != nil)
@@ -486,6 +546,7 @@ func (sm *SnapshotManager) snap() *deploy.Snapshot {
 	}
 
 	manifest.Magic = manifest.NewMagic()
+	resources = deploy.NormalizeURNReferences(resources, sm.aliases)
 	return deploy.NewSnapshot(manifest, resources, operations)
 }
 
@@ -517,6 +578,7 @@ func NewSnapshotManager(persister SnapshotPersister, baseSnap *deploy.Snapshot)
 		baseSnapshot:     baseSnap,
 		dones:            make(map[*resource.State]bool),
 		completeOps:      make(map[*resource.State]bool),
+		aliases:          make(map[resource.URN]resource.URN),
 		doVerify:         true,
 		mutationRequests: mutationRequests,
 		cancel:           cancel,
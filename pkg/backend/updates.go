@@ -98,4 +98,7 @@ type UpdateInfo struct {
 	Result          UpdateResult           `json:"result"`
 	EndTime         int64                  `json:"endTime"`
 	ResourceChanges engine.ResourceChanges `json:"resourceChanges,omitempty"`
+
+	// Version is the update's number within its stack's history, starting from 1.
+	Version int `json:"version"`
 }
@@ -52,3 +52,35 @@ func GetAnalyzeResourceFailureError(urn resource.URN) *Diag {
 func GetPreviewFailedError(urn resource.URN) *Diag {
 	return newError(urn, 2005, "Preview failed: %v")
 }
+
+func GetUntargetedCreateError(urn resource.URN) *Diag {
+	return newError(urn, 2006,
+		"Resource '%v' must be created but was not specified in the --target list; "+
+			"target it explicitly or pass --target-dependents")
+}
+
+func GetUntargetedDependentDeleteError(urn resource.URN) *Diag {
+	return newError(urn, 2007,
+		"Resource '%v' would be deleted, but resource '%v' depends on it and was not specified in the --target list")
+}
+
+func GetImportManagedResourceError(urn resource.URN) *Diag {
+	return newError(urn, 2008, "Resource '%v' is already managed by this stack and cannot be imported with ID '%v'")
+}
+
+func GetDuplicateResourceAliasError(urn resource.URN) *Diag {
+	return newError(urn, 2009, "Resource '%v' refers to existing resource '%v', which is already claimed by '%v'")
+}
+
+func GetAnalyzeResourceFailureWarning(urn resource.URN) *Diag {
+	return newError(urn, 2010,
+		"Analyzer '%v' reported an advisory resource warning:\n"+
+			"\tResource: %v\n"+
+			"\tProperty: %v\n"+
+			"\tReason: %v")
+}
+
+func GetResourceOperationRetryWarning(urn resource.URN) *Diag {
+	return newError(urn, 2011,
+		"%v of resource '%v' failed with a transient error (attempt %v of %v); retrying in %v: %v")
+}
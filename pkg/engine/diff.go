@@ -28,7 +28,9 @@ import (
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
 )
 
 // GetIndent computes a step's parent indentation.
@@ -165,7 +167,7 @@ func GetResourcePropertiesSummary(step StepEventMetadata, indent int) string {
 			contract.Assert(err == nil)
 
 			// Elide references to default providers.
-			if prov.URN().Name() != "default" {
+			if !providers.IsDefaultProvider(prov.URN()) {
 				writeWithIndentNoPrefix(&b, indent+1, simplePropOp, "[provider=%s]\n", step.Provider)
 			}
 		}
@@ -195,6 +197,16 @@ func GetResourcePropertiesDetails(
 		if !summary {
 			printObject(&b, old.Inputs, planning, indent, step.Op, false, debug)
 		}
+	} else if step.DetailedDiff != nil {
+		// If the provider returned a detailed diff, render it rather than our own diff of the resource's properties:
+		// the provider's diff describes what it will actually do. The provider diffed the old state of the resource
+		// against its new inputs.
+		olds := old.Outputs
+		if len(olds) == 0 {
+			olds = old.Inputs
+		}
+		diff := translateDetailedDiff(step.DetailedDiff, olds, new.Inputs)
+		printObjectDiff(&b, diff, planning, indent, summary, debug)
 	} else if len(new.Outputs) > 0 {
 		printOldNewDiffs(&b, old.Outputs, new.Outputs, planning, indent, step.Op, summary, debug)
 	} else {
@@ -238,13 +250,14 @@ func GetResourceOutputsPropertiesString(
 	// We should only print outputs if the outputs are known to be complete. This will be the case if we are
 	//   1) not doing a preview
 	//   2) doing a refresh
-	//   3) doing a read
+	//   3) doing a read or an import
 	//
 	// Technically, 2 and 3 are the same, since they're both bottoming out at a provider's implementation of Read, but
 	// the upshot is that either way we're ending up with outputs that are exactly accurate. If we are not sure that we
 	// are in one of the above states, we shouldn't try to print outputs.
 	if planning {
-		printOutputDuringPlanning := refresh || step.Op == deploy.OpRead || step.Op == deploy.OpReadReplacement
+		printOutputDuringPlanning := refresh || step.Op == deploy.OpRead || step.Op == deploy.OpReadReplacement ||
+			step.Op == deploy.OpImport
 		if !printOutputDuringPlanning {
 			return ""
 		}
@@ -456,6 +469,139 @@ func printOldNewDiffs(
 	}
 }
 
+// translateDetailedDiff converts a provider's detailed diff, which maps property paths to the kinds of changes made to
+// them, into an ObjectDiff that may be rendered by printObjectDiff. The values of the changed properties are taken from
+// the given old and new properties; all other properties are considered unchanged. Malformed paths are ignored.
+func translateDetailedDiff(detailedDiff map[string]plugin.PropertyDiff,
+	olds, news resource.PropertyMap) resource.ObjectDiff {
+
+	root := newDetailedValueDiff(resource.NewObjectProperty(olds), resource.NewObjectProperty(news), false)
+
+	paths := make([]string, 0, len(detailedDiff))
+	for path := range detailedDiff {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		elements, err := resource.ParsePropertyPath(path)
+		if err != nil || len(elements) == 0 {
+			logging.V(7).Infof("ignoring malformed detailed diff path %q", path)
+			continue
+		}
+		addDetailedDiff(&root, elements, detailedDiff[path].Kind)
+	}
+	return *root.Object
+}
+
+// newDetailedValueDiff returns a diff of an object or array in which each element of the new value is unchanged.
+func newDetailedValueDiff(old, new resource.PropertyValue, array bool) resource.ValueDiff {
+	diff := resource.ValueDiff{Old: old, New: new}
+	if array {
+		diff.Array = &resource.ArrayDiff{
+			Adds:    make(map[int]resource.PropertyValue),
+			Deletes: make(map[int]resource.PropertyValue),
+			Sames:   make(map[int]resource.PropertyValue),
+			Updates: make(map[int]resource.ValueDiff),
+		}
+		if new.IsArray() {
+			for i, v := range new.ArrayValue() {
+				diff.Array.Sames[i] = v
+			}
+		}
+	} else {
+		diff.Object = &resource.ObjectDiff{
+			Adds:    make(resource.PropertyMap),
+			Deletes: make(resource.PropertyMap),
+			Sames:   make(resource.PropertyMap),
+			Updates: make(map[resource.PropertyKey]resource.ValueDiff),
+		}
+		if new.IsObject() {
+			for k, v := range new.ObjectValue() {
+				diff.Object.Sames[k] = v
+			}
+		}
+	}
+	return diff
+}
+
+// addDetailedDiff records a change of the given kind to the element at the given path within the given diff.
+func addDetailedDiff(parent *resource.ValueDiff, path resource.PropertyPath, kind plugin.DiffKind) {
+	old, _ := path[:1].Get(parent.Old)
+	new, _ := path[:1].Get(parent.New)
+
+	// Compute the diff of the element itself: either a leaf change, or a nested diff of its elements.
+	var added, deleted *resource.PropertyValue
+	var update resource.ValueDiff
+	switch {
+	case len(path) > 1:
+		_, array := path[1].(int)
+		update = newDetailedValueDiff(old, new, array)
+		if existing, ok := existingDetailedDiff(parent, path[0]); ok {
+			if (array && existing.Array != nil) || (!array && existing.Object != nil) {
+				update = existing
+			}
+		}
+		addDetailedDiff(&update, path[1:], kind)
+	case kind == plugin.DiffAdd || kind == plugin.DiffAddReplace:
+		added = &new
+	case kind == plugin.DiffDelete || kind == plugin.DiffDeleteReplace:
+		deleted = &old
+	default:
+		update = resource.ValueDiff{Old: old, New: new}
+		if diff := old.Diff(new); diff != nil {
+			update = *diff
+		}
+	}
+
+	switch element := path[0].(type) {
+	case string:
+		if parent.Object == nil {
+			return
+		}
+		key := resource.PropertyKey(element)
+		delete(parent.Object.Sames, key)
+		switch {
+		case added != nil:
+			parent.Object.Adds[key] = *added
+		case deleted != nil:
+			parent.Object.Deletes[key] = *deleted
+		default:
+			parent.Object.Updates[key] = update
+		}
+	case int:
+		if parent.Array == nil {
+			return
+		}
+		delete(parent.Array.Sames, element)
+		switch {
+		case added != nil:
+			parent.Array.Adds[element] = *added
+		case deleted != nil:
+			parent.Array.Deletes[element] = *deleted
+		default:
+			parent.Array.Updates[element] = update
+		}
+	}
+}
+
+// existingDetailedDiff returns the nested diff that has already been recorded for the given element of a diff, if any.
+func existingDetailedDiff(parent *resource.ValueDiff, element interface{}) (resource.ValueDiff, bool) {
+	var diff resource.ValueDiff
+	var ok bool
+	switch element := element.(type) {
+	case string:
+		if parent.Object != nil {
+			diff, ok = parent.Object.Updates[resource.PropertyKey(element)]
+		}
+	case int:
+		if parent.Array != nil {
+			diff, ok = parent.Array.Updates[element]
+		}
+	}
+	return diff, ok
+}
+
 func printObjectDiff(b *bytes.Buffer, diff resource.ObjectDiff,
 	planning bool, indent int, summary bool, debug bool) {
 
@@ -563,7 +709,7 @@ func printPropertyValueDiff(
 
 func isPrimitive(value resource.PropertyValue) bool {
 	return value.IsNull() || value.IsString() || value.IsNumber() ||
-		value.IsBool() || value.IsComputed() || value.IsOutput()
+		value.IsBool() || value.IsComputed() || value.IsOutput() || value.IsSecret()
 }
 
 func printPrimitivePropertyValue(b *bytes.Buffer, v resource.PropertyValue, planning bool, op deploy.StepOp) {
@@ -577,6 +723,9 @@ func printPrimitivePropertyValue(b *bytes.Buffer, v resource.PropertyValue, plan
 		write(b, op, "%v", v.NumberValue())
 	} else if v.IsString() {
 		write(b, op, "%q", v.StringValue())
+	} else if v.IsSecret() {
+		// Never print the contents of a secret; just note that one is present.
+		writeVerbatim(b, op, "[secret]")
 	} else if v.IsComputed() || v.IsOutput() {
 		// We render computed and output values differently depending on whether or not we are
 		// planning or deploying: in the former case, we display `computed<type>` or `output<type>`;
@@ -0,0 +1,83 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+)
+
+func TestTranslateDetailedDiff(t *testing.T) {
+	olds := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"name": "old",
+		"tags": map[string]interface{}{
+			"env":  "dev",
+			"team": "core",
+		},
+		"rules": []interface{}{
+			map[string]interface{}{"port": 80},
+			map[string]interface{}{"port": 443},
+		},
+		"legacy": true,
+	})
+	news := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"name": "new",
+		"tags": map[string]interface{}{
+			"env":  "prod",
+			"team": "core",
+		},
+		"rules": []interface{}{
+			map[string]interface{}{"port": 80},
+			map[string]interface{}{"port": 8443},
+		},
+		"description": "a resource",
+	})
+
+	diff := translateDetailedDiff(map[string]plugin.PropertyDiff{
+		"tags.env":      {Kind: plugin.DiffUpdate},
+		"rules[1].port": {Kind: plugin.DiffUpdateReplace},
+		"legacy":        {Kind: plugin.DiffDelete},
+		"description":   {Kind: plugin.DiffAdd},
+		"not a [path":   {Kind: plugin.DiffUpdate},
+	}, olds, news)
+
+	// Properties that the provider did not report are unchanged, even though their values differ.
+	assert.Equal(t, news["name"], diff.Sames["name"])
+
+	assert.Equal(t, olds["legacy"], diff.Deletes["legacy"])
+	assert.Equal(t, news["description"], diff.Adds["description"])
+
+	tags := diff.Updates["tags"].Object
+	if assert.NotNil(t, tags) {
+		assert.Equal(t, resource.NewStringProperty("dev"), tags.Updates["env"].Old)
+		assert.Equal(t, resource.NewStringProperty("prod"), tags.Updates["env"].New)
+		assert.Equal(t, resource.NewStringProperty("core"), tags.Sames["team"])
+	}
+
+	rules := diff.Updates["rules"].Array
+	if assert.NotNil(t, rules) {
+		assert.Equal(t, 2, rules.Len())
+		assert.Equal(t, news["rules"].ArrayValue()[0], rules.Sames[0])
+		port := rules.Updates[1].Object
+		if assert.NotNil(t, port) {
+			assert.Equal(t, resource.NewNumberProperty(443), port.Updates["port"].Old)
+			assert.Equal(t, resource.NewNumberProperty(8443), port.Updates["port"].New)
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/opentracing/opentracing-go"
 
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/util/cancel"
 	"github.com/pulumi/pulumi/pkg/workspace"
 )
@@ -36,10 +37,12 @@ type UpdateInfo interface {
 }
 
 // Context provides cancellation, termination, and eventing options for an engine operation. It also provides
-// a way for the engine to persist snapshots, using the `SnapshotManager`.
+// a way for the engine to persist snapshots, using the `SnapshotManager`, and to read the state of other stacks, using
+// the `BackendClient`.
 type Context struct {
 	Cancel          *cancel.Context
 	Events          chan<- Event
 	SnapshotManager SnapshotManager
+	BackendClient   providers.BackendClient
 	ParentSpan      opentracing.SpanContext
 }
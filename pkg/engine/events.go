@@ -23,6 +23,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
@@ -47,6 +48,7 @@ const (
 	ResourcePreEvent        EventType = "resource-pre"
 	ResourceOutputsEvent    EventType = "resource-outputs"
 	ResourceOperationFailed EventType = "resource-operationfailed"
+	PolicyViolationEvent    EventType = "policy-violation"
 )
 
 func cancelEvent() Event {
@@ -75,10 +77,20 @@ type PreludeEventPayload struct {
 }
 
 type SummaryEventPayload struct {
-	IsPreview       bool            // true if this summary is for a plan operation
-	MaybeCorrupt    bool            // true if one or more resources may be corrupt
-	Duration        time.Duration   // the duration of the entire update operation (zero values for previews)
-	ResourceChanges ResourceChanges // count of changed resources, useful for reporting
+	IsPreview        bool                          // true if this summary is for a plan operation
+	MaybeCorrupt     bool                          // true if one or more resources may be corrupt
+	Duration         time.Duration                 // the duration of the entire update operation (zero for previews)
+	ResourceChanges  ResourceChanges               // count of changed resources, useful for reporting
+	PolicyViolations []PolicyViolationEventPayload // the policy violations reported by analyzers, if any
+}
+
+// PolicyViolationEventPayload is the payload for an event with type `policy-violation`.
+type PolicyViolationEventPayload struct {
+	URN              resource.URN            // the resource that violated the policy, if any.
+	Analyzer         tokens.QName            // the analyzer that reported the violation.
+	Property         resource.PropertyKey    // the property that violated the policy, if any.
+	Message          string                  // a description of the violation.
+	EnforcementLevel plugin.EnforcementLevel // whether the violation is advisory or mandatory.
 }
 
 type ResourceOperationFailedPayload struct {
@@ -109,6 +121,10 @@ type StepEventMetadata struct {
 	Keys     []resource.PropertyKey  // the keys causing replacement (only for CreateStep and ReplaceStep).
 	Logical  bool                    // true if this step represents a logical operation in the program.
 	Provider string                  // the provider that performed this step.
+
+	// DetailedDiff is the provider's detailed diff of the resource's properties, if any (only for UpdateStep,
+	// ReplaceStep, and CreateStep replacements).
+	DetailedDiff map[string]plugin.PropertyDiff
 }
 
 type StepEventStateMetadata struct {
@@ -148,18 +164,14 @@ func makeEventEmitter(events chan<- Event, update UpdateInfo) (eventEmitter, err
 	var secrets []string
 	if target.Config.HasSecureValue() {
 		for k, v := range target.Config {
-			if !v.Secure() {
-				continue
-			}
-
-			secret, err := v.Value(target.Decrypter)
+			values, err := v.SecureValues(target.Decrypter)
 			if err != nil {
 				return eventEmitter{}, DecryptError{
 					Key: k,
 					Err: err,
 				}
 			}
-			secrets = append(secrets, secret)
+			secrets = append(secrets, values...)
 		}
 	}
 
@@ -178,22 +190,29 @@ func makeStepEventMetadata(op deploy.StepOp, step deploy.Step, debug bool) StepE
 	contract.Assert(op == step.Op() || step.Op() == deploy.OpRefresh)
 
 	var keys []resource.PropertyKey
-	if step.Op() == deploy.OpCreateReplacement {
+	var detailedDiff map[string]plugin.PropertyDiff
+	switch step.Op() {
+	case deploy.OpCreateReplacement:
 		keys = step.(*deploy.CreateStep).Keys()
-	} else if step.Op() == deploy.OpReplace {
+		detailedDiff = step.(*deploy.CreateStep).DetailedDiff()
+	case deploy.OpReplace:
 		keys = step.(*deploy.ReplaceStep).Keys()
+		detailedDiff = step.(*deploy.ReplaceStep).DetailedDiff()
+	case deploy.OpUpdate:
+		detailedDiff = step.(*deploy.UpdateStep).DetailedDiff()
 	}
 
 	return StepEventMetadata{
-		Op:       op,
-		URN:      step.URN(),
-		Type:     step.Type(),
-		Keys:     keys,
-		Old:      makeStepEventStateMetadata(step.Old(), debug),
-		New:      makeStepEventStateMetadata(step.New(), debug),
-		Res:      makeStepEventStateMetadata(step.Res(), debug),
-		Logical:  step.Logical(),
-		Provider: step.Provider(),
+		Op:           op,
+		URN:          step.URN(),
+		Type:         step.Type(),
+		Keys:         keys,
+		Old:          makeStepEventStateMetadata(step.Old(), debug),
+		New:          makeStepEventStateMetadata(step.New(), debug),
+		Res:          makeStepEventStateMetadata(step.Res(), debug),
+		Logical:      step.Logical(),
+		Provider:     step.Provider(),
+		DetailedDiff: detailedDiff,
 	}
 }
 
@@ -285,6 +304,10 @@ func filterPropertyMap(propertyMap resource.PropertyMap, debug bool) resource.Pr
 			return resource.Output{
 				Element: filterPropertyValue(t.Element),
 			}
+		case *resource.Secret:
+			return &resource.Secret{
+				Element: filterPropertyValue(t.Element),
+			}
 		}
 
 		// Next, see if it's an array, slice, pointer or struct, and handle each accordingly.
@@ -369,6 +392,15 @@ func (e *eventEmitter) resourcePreEvent(
 	}
 }
 
+func (e *eventEmitter) policyViolationEvent(payload PolicyViolationEventPayload) {
+	contract.Requiref(e != nil, "e", "!= nil")
+
+	e.Chan <- Event{
+		Type:    PolicyViolationEvent,
+		Payload: payload,
+	}
+}
+
 func (e *eventEmitter) preludeEvent(isPreview bool, cfg config.Map) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
@@ -389,31 +421,34 @@ func (e *eventEmitter) preludeEvent(isPreview bool, cfg config.Map) {
 	}
 }
 
-func (e *eventEmitter) previewSummaryEvent(resourceChanges ResourceChanges) {
+func (e *eventEmitter) previewSummaryEvent(resourceChanges ResourceChanges,
+	policyViolations []PolicyViolationEventPayload) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.Chan <- Event{
 		Type: SummaryEvent,
 		Payload: SummaryEventPayload{
-			IsPreview:       true,
-			MaybeCorrupt:    false,
-			Duration:        0,
-			ResourceChanges: resourceChanges,
+			IsPreview:        true,
+			MaybeCorrupt:     false,
+			Duration:         0,
+			ResourceChanges:  resourceChanges,
+			PolicyViolations: policyViolations,
 		},
 	}
 }
 
 func (e *eventEmitter) updateSummaryEvent(maybeCorrupt bool,
-	duration time.Duration, resourceChanges ResourceChanges) {
+	duration time.Duration, resourceChanges ResourceChanges, policyViolations []PolicyViolationEventPayload) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.Chan <- Event{
 		Type: SummaryEvent,
 		Payload: SummaryEventPayload{
-			IsPreview:       false,
-			MaybeCorrupt:    maybeCorrupt,
-			Duration:        duration,
-			ResourceChanges: resourceChanges,
+			IsPreview:        false,
+			MaybeCorrupt:     maybeCorrupt,
+			Duration:         duration,
+			ResourceChanges:  resourceChanges,
+			PolicyViolations: policyViolations,
 		},
 	}
 }
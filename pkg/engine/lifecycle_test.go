@@ -22,6 +22,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/mitchellh/copystructure"
@@ -43,6 +44,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/util/rpcutil/rpcerror"
 	"github.com/pulumi/pulumi/pkg/workspace"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
 )
 
 type JournalEntryKind int
@@ -111,6 +113,7 @@ func (j *Journal) RecordPlugin(plugin workspace.PluginInfo) error {
 func (j *Journal) Snap(base *deploy.Snapshot) *deploy.Snapshot {
 	// Build up a list of current resources by replaying the journal.
 	resources, dones := []*resource.State{}, make(map[*resource.State]bool)
+	aliases := make(map[resource.URN]resource.URN)
 	ops, doneOps := []resource.Operation{}, make(map[*resource.State]bool)
 	for _, e := range j.Entries {
 		logging.V(7).Infof("%v %v (%v)", e.Step.Op(), e.Step.URN(), e.Kind)
@@ -125,6 +128,8 @@ func (j *Journal) Snap(base *deploy.Snapshot) *deploy.Snapshot {
 				ops = append(ops, resource.NewOperation(e.Step.Old(), resource.OperationTypeDeleting))
 			case deploy.OpRead, deploy.OpReadReplacement:
 				ops = append(ops, resource.NewOperation(e.Step.New(), resource.OperationTypeReading))
+			case deploy.OpImport:
+				ops = append(ops, resource.NewOperation(e.Step.New(), resource.OperationTypeImporting))
 			case deploy.OpUpdate:
 				ops = append(ops, resource.NewOperation(e.Step.New(), resource.OperationTypeUpdating))
 			}
@@ -134,7 +139,8 @@ func (j *Journal) Snap(base *deploy.Snapshot) *deploy.Snapshot {
 
 		if e.Kind != JournalEntryOutputs {
 			switch e.Step.Op() {
-			case deploy.OpCreate, deploy.OpCreateReplacement, deploy.OpRead, deploy.OpReadReplacement, deploy.OpUpdate:
+			case deploy.OpCreate, deploy.OpCreateReplacement, deploy.OpRead, deploy.OpReadReplacement, deploy.OpUpdate,
+				deploy.OpImport:
 				doneOps[e.Step.New()] = true
 			case deploy.OpDelete, deploy.OpDeleteReplaced:
 				doneOps[e.Step.Old()] = true
@@ -149,13 +155,19 @@ func (j *Journal) Snap(base *deploy.Snapshot) *deploy.Snapshot {
 		case deploy.OpSame, deploy.OpUpdate:
 			resources = append(resources, e.Step.New())
 			dones[e.Step.Old()] = true
+			if e.Step.Old().URN != e.Step.New().URN {
+				aliases[e.Step.Old().URN] = e.Step.New().URN
+			}
 		case deploy.OpCreate, deploy.OpCreateReplacement:
 			resources = append(resources, e.Step.New())
+			if old := e.Step.Old(); old != nil && old.URN != e.Step.New().URN {
+				aliases[old.URN] = e.Step.New().URN
+			}
 		case deploy.OpDelete, deploy.OpDeleteReplaced:
 			dones[e.Step.Old()] = true
 		case deploy.OpReplace:
 			// do nothing.
-		case deploy.OpRead, deploy.OpReadReplacement:
+		case deploy.OpRead, deploy.OpReadReplacement, deploy.OpImport:
 			resources = append(resources, e.Step.New())
 			if e.Step.Old() != nil {
 				dones[e.Step.Old()] = true
@@ -183,7 +195,7 @@ func (j *Journal) Snap(base *deploy.Snapshot) *deploy.Snapshot {
 
 	manifest := deploy.Manifest{}
 	manifest.Magic = manifest.NewMagic()
-	return deploy.NewSnapshot(manifest, resources, operations)
+	return deploy.NewSnapshot(manifest, deploy.NormalizeURNReferences(resources, aliases), operations)
 }
 
 func newJournal() *Journal {
@@ -227,13 +239,14 @@ type TestOp func(UpdateInfo, *Context, UpdateOptions, bool) (ResourceChanges, er
 type ValidateFunc func(project workspace.Project, target deploy.Target, j *Journal, events []Event, err error) error
 
 func (op TestOp) Run(project workspace.Project, target deploy.Target, opts UpdateOptions,
-	dryRun bool, validate ValidateFunc) (*deploy.Snapshot, error) {
+	dryRun bool, backendClient providers.BackendClient, validate ValidateFunc) (*deploy.Snapshot, error) {
 
-	return op.RunWithContext(context.Background(), project, target, opts, dryRun, validate)
+	return op.RunWithContext(context.Background(), project, target, opts, dryRun, backendClient, validate)
 }
 
 func (op TestOp) RunWithContext(callerCtx context.Context, project workspace.Project, target deploy.Target,
-	opts UpdateOptions, dryRun bool, validate ValidateFunc) (*deploy.Snapshot, error) {
+	opts UpdateOptions, dryRun bool, backendClient providers.BackendClient,
+	validate ValidateFunc) (*deploy.Snapshot, error) {
 
 	// Create an appropriate update info and context.
 	info := &updateInfo{project: project, target: target}
@@ -256,6 +269,7 @@ func (op TestOp) RunWithContext(callerCtx context.Context, project workspace.Pro
 		Cancel:          cancelCtx,
 		Events:          events,
 		SnapshotManager: journal,
+		BackendClient:   backendClient,
 	}
 
 	// Begin draining events.
@@ -293,13 +307,14 @@ type TestStep struct {
 }
 
 type TestPlan struct {
-	Project   string
-	Stack     string
-	Runtime   string
-	Config    config.Map
-	Decrypter config.Decrypter
-	Options   UpdateOptions
-	Steps     []TestStep
+	Project       string
+	Stack         string
+	Runtime       string
+	Config        config.Map
+	Decrypter     config.Decrypter
+	BackendClient providers.BackendClient
+	Options       UpdateOptions
+	Steps         []TestStep
 }
 
 func (p *TestPlan) getNames() (stack tokens.QName, project tokens.PackageName, runtime string) {
@@ -366,7 +381,7 @@ func (p *TestPlan) Run(t *testing.T, snapshot *deploy.Snapshot) *deploy.Snapshot
 		if !step.SkipPreview {
 			previewSnap := CloneSnapshot(t, snap)
 			previewTarget := p.GetTarget(previewSnap)
-			_, err := step.Op.Run(project, previewTarget, p.Options, true, step.Validate)
+			_, err := step.Op.Run(project, previewTarget, p.Options, true, p.BackendClient, step.Validate)
 			if step.ExpectFailure {
 				assert.Error(t, err)
 				continue
@@ -377,7 +392,7 @@ func (p *TestPlan) Run(t *testing.T, snapshot *deploy.Snapshot) *deploy.Snapshot
 
 		var err error
 		target := p.GetTarget(snap)
-		snap, err = step.Op.Run(project, target, p.Options, false, step.Validate)
+		snap, err = step.Op.Run(project, target, p.Options, false, p.BackendClient, step.Validate)
 		if step.ExpectFailure {
 			assert.Error(t, err)
 			continue
@@ -1738,7 +1753,7 @@ func TestCanceledRefresh(t *testing.T) {
 		return err
 	}
 
-	snap, err := op.RunWithContext(ctx, project, target, options, false, validate)
+	snap, err := op.RunWithContext(ctx, project, target, options, false, nil, validate)
 	assert.Error(t, err)
 
 	t.Logf("%v/%v resources refreshed", len(refreshed), len(oldResources))
@@ -1932,8 +1947,8 @@ func TestProviderCancellation(t *testing.T) {
 	loaders := []*deploytest.ProviderLoader{
 		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
 			return &deploytest.Provider{
-				CreateF: func(urn resource.URN,
-					inputs resource.PropertyMap) (resource.ID, resource.PropertyMap, resource.Status, error) {
+				CreateF: func(urn resource.URN, inputs resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
 
 					// Inform the waiter that we've entered a provider op and wait for cancellation.
 					ops.Done()
@@ -1977,7 +1992,7 @@ func TestProviderCancellation(t *testing.T) {
 	}
 	project, target := p.GetProject(), p.GetTarget(nil)
 
-	_, err := op.RunWithContext(ctx, project, target, options, false, nil)
+	_, err := op.RunWithContext(ctx, project, target, options, false, nil, nil)
 	assert.Error(t, err)
 
 	// Wait for the program to finish.
@@ -2032,11 +2047,11 @@ func TestPreviewWithPendingOperations(t *testing.T) {
 	project, target := p.GetProject(), p.GetTarget(old)
 
 	// A preview should succeed despite the pending operations.
-	_, err := op.Run(project, target, options, true, nil)
+	_, err := op.Run(project, target, options, true, nil, nil)
 	assert.NoError(t, err)
 
 	// But an update should fail.
-	_, err = op.Run(project, target, options, false, nil)
+	_, err = op.Run(project, target, options, false, nil, nil)
 	assert.EqualError(t, err, deploy.PlanPendingOperationsError{}.Error())
 }
 
@@ -2051,8 +2066,8 @@ func TestUpdatePartialFailure(t *testing.T) {
 					}, nil
 				},
 
-				UpdateF: func(urn resource.URN, id resource.ID, olds,
-					news resource.PropertyMap) (resource.PropertyMap, resource.Status, error) {
+				UpdateF: func(urn resource.URN, id resource.ID, olds, news resource.PropertyMap,
+					timeout float64) (resource.PropertyMap, resource.Status, error) {
 					outputs := resource.NewPropertyMapFromMap(map[string]interface{}{
 						"output_prop": 42,
 					})
@@ -2127,3 +2142,1220 @@ func TestUpdatePartialFailure(t *testing.T) {
 
 	p.Run(t, old)
 }
+
+func TestTargetedUpdateAndDestroy(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	inputs := resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "bar"})
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		urnA, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "", inputs)
+		assert.NoError(t, err)
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true, "", false, []resource.URN{urnA}, "",
+			inputs)
+		assert.NoError(t, err)
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resC", true, "", false, nil, "", inputs)
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+	}
+	provURN := p.NewProviderURN("pkgA", "default", "")
+	resA := p.NewURN("pkgA:m:typA", "resA", "")
+	resB := p.NewURN("pkgA:m:typA", "resB", "")
+	resC := p.NewURN("pkgA:m:typA", "resC", "")
+
+	// Create the initial resources.
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+	assert.Len(t, snap.Resources, 4)
+
+	// Change the inputs of every resource, but only target resA. Only resA should be updated.
+	validateOps := func(expected map[resource.URN]deploy.StepOp) ValidateFunc {
+		return func(project workspace.Project, target deploy.Target, j *Journal, _ []Event, err error) error {
+			for _, entry := range j.Entries {
+				if entry.Kind != JournalEntrySuccess {
+					continue
+				}
+				op, ok := expected[entry.Step.URN()]
+				assert.True(t, ok, "unexpected step for %v", entry.Step.URN())
+				assert.Equal(t, op, entry.Step.Op(), "unexpected op for %v", entry.Step.URN())
+			}
+			return err
+		}
+	}
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "baz"})
+	p.Options.Targets = []resource.URN{resA}
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: validateOps(map[resource.URN]deploy.StepOp{
+			provURN: deploy.OpSame,
+			resA:    deploy.OpUpdate,
+			resB:    deploy.OpSame,
+			resC:    deploy.OpSame,
+		}),
+	}}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 4)
+	for _, res := range snap.Resources {
+		switch res.URN {
+		case resA:
+			assert.Equal(t, "baz", res.Inputs["foo"].StringValue())
+		case resB, resC:
+			assert.Equal(t, "bar", res.Inputs["foo"].StringValue())
+		}
+	}
+
+	// Now include dependents: resB should be updated along with resA.
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "qux"})
+	p.Options.TargetDependents = true
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: validateOps(map[resource.URN]deploy.StepOp{
+			provURN: deploy.OpSame,
+			resA:    deploy.OpUpdate,
+			resB:    deploy.OpUpdate,
+			resC:    deploy.OpSame,
+		}),
+	}}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 4)
+
+	// Destroying resA alone must fail, as resB depends upon it.
+	p.Options.TargetDependents = false
+	p.Steps = []TestStep{{Op: Destroy, ExpectFailure: true}}
+	p.Run(t, snap)
+
+	// Destroying resC alone should leave everything else in place.
+	p.Options.Targets = []resource.URN{resC}
+	p.Steps = []TestStep{{
+		Op:       Destroy,
+		Validate: validateOps(map[resource.URN]deploy.StepOp{resC: deploy.OpDelete}),
+	}}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 3)
+	for _, res := range snap.Resources {
+		assert.NotEqual(t, resC, res.URN)
+	}
+}
+
+func TestImport(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap) (plugin.DiffResult, error) {
+
+					if olds["foo"].DeepEquals(news["foo"]) {
+						return plugin.DiffResult{Changes: plugin.DiffNone}, nil
+					}
+					return plugin.DiffResult{Changes: plugin.DiffSome}, nil
+				},
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					assert.Fail(t, "imported resources must not be created")
+					return "", nil, resource.StatusOK, errors.New("unexpected create")
+				},
+				ReadF: func(urn resource.URN, id resource.ID,
+					props resource.PropertyMap) (resource.PropertyMap, resource.Status, error) {
+
+					if id != "imported-id" {
+						return nil, resource.StatusOK, nil
+					}
+					return resource.PropertyMap{
+						"foo": resource.NewStringProperty("bar"),
+						"baz": resource.NewNumberProperty(42),
+					}, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	importID := resource.ID("imported-id")
+	inputs := resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "bar"})
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "", inputs,
+			deploytest.ResourceOptions{Import: importID})
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+	}
+	resURN := p.NewURN("pkgA:m:typA", "resA", "")
+
+	// Importing a resource whose inputs match its current state should succeed without creating anything.
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, _ []Event, err error) error {
+			for _, entry := range j.Entries {
+				if entry.Step.URN() == resURN {
+					assert.Equal(t, deploy.OpImport, entry.Step.Op())
+				}
+			}
+			return err
+		},
+	}}
+	snap := p.Run(t, nil)
+	assert.Len(t, snap.Resources, 2)
+	assert.Equal(t, resURN, snap.Resources[1].URN)
+	assert.Equal(t, importID, snap.Resources[1].ID)
+	assert.Equal(t, inputs, snap.Resources[1].Inputs)
+	assert.Equal(t, resource.NewNumberProperty(42), snap.Resources[1].Outputs["baz"])
+
+	// Once imported, the resource is managed like any other.
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, _ []Event, err error) error {
+			for _, entry := range j.Entries {
+				assert.Equal(t, deploy.OpSame, entry.Step.Op())
+			}
+			return err
+		},
+	}}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 2)
+
+	// Importing the resource with a different ID is an error.
+	importID = "other-id"
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true}}
+	p.Run(t, snap)
+
+	// Importing a resource that does not exist is an error.
+	p.Run(t, nil)
+
+	// Importing a resource whose inputs do not match its current state is an error.
+	importID, inputs = "imported-id", resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "qux"})
+	p.Run(t, nil)
+}
+
+func TestImportWithoutProgram(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CheckF: func(urn resource.URN,
+					olds, news resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+					// "arn" is an output of the resource, not an input.
+					inputs := news.Copy()
+					delete(inputs, "arn")
+					return inputs, nil, nil
+				},
+				ReadF: func(urn resource.URN, id resource.ID,
+					props resource.PropertyMap) (resource.PropertyMap, resource.Status, error) {
+
+					return resource.PropertyMap{
+						"foo": resource.NewStringProperty("bar"),
+						"arn": resource.NewStringProperty("arn:" + string(id)),
+					}, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "", nil)
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+		Steps:   []TestStep{{Op: Update}},
+	}
+	resA := p.NewURN("pkgA:m:typA", "resA", "")
+	resB := p.NewURN("pkgA:m:typA", "resB", "")
+
+	// Create a resource using the program.
+	snap := p.Run(t, nil)
+	assert.Len(t, snap.Resources, 2)
+
+	// Now import a second resource without running the program. The existing resource must be left alone, and the
+	// imported resource should take its inputs from the provider's check of its current state.
+	p.Options.Imports = []deploy.Import{{Type: "pkgA:m:typA", Name: "resB", ID: "imported-id"}}
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, _ []Event, err error) error {
+			for _, entry := range j.Entries {
+				assert.NotEqual(t, resA, entry.Step.URN())
+				if entry.Step.URN() == resB {
+					assert.Equal(t, deploy.OpImport, entry.Step.Op())
+				}
+			}
+			return err
+		},
+	}}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 3)
+	for _, res := range snap.Resources {
+		if res.URN == resB {
+			assert.Equal(t, resource.ID("imported-id"), res.ID)
+			assert.Equal(t, "bar", res.Inputs["foo"].StringValue())
+			assert.NotContains(t, res.Inputs, resource.PropertyKey("arn"))
+			assert.Equal(t, "arn:imported-id", res.Outputs["arn"].StringValue())
+		}
+	}
+
+	// Importing a resource that is already managed by the stack is an error.
+	p.Options.Imports = []deploy.Import{{Type: "pkgA:m:typA", Name: "resA", ID: "imported-id"}}
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true}}
+	p.Run(t, snap)
+}
+
+func TestIgnoreChanges(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	var inputs resource.PropertyMap
+	var ignoreChanges []string
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "", inputs,
+			deploytest.ResourceOptions{IgnoreChanges: ignoreChanges})
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+	}
+	resA := p.NewURN("pkgA:m:typA", "resA", "")
+
+	// updateWithOp runs an update and asserts that resA is operated upon with the expected op.
+	updateWithOp := func(snap *deploy.Snapshot, expected deploy.StepOp) *deploy.Snapshot {
+		p.Steps = []TestStep{{
+			Op: Update,
+			Validate: func(project workspace.Project, target deploy.Target, j *Journal, _ []Event, err error) error {
+				for _, entry := range j.Entries {
+					if entry.Step.URN() == resA {
+						assert.Equal(t, expected, entry.Step.Op())
+					}
+				}
+				return err
+			},
+		}}
+		return p.Run(t, snap)
+	}
+
+	// Create the resource.
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{
+		"foo": "bar",
+		"tags": map[string]interface{}{
+			"Name":  "a",
+			"Owner": "b",
+		},
+	})
+	snap := updateWithOp(nil, deploy.OpCreate)
+
+	// Change a top-level and a nested property, both of which are ignored. The resource should not change, and the
+	// old values should be retained.
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{
+		"foo": "baz",
+		"tags": map[string]interface{}{
+			"Name":  "c",
+			"Owner": "b",
+		},
+	})
+	ignoreChanges = []string{"foo", "tags.Name"}
+	snap = updateWithOp(snap, deploy.OpSame)
+	for _, res := range snap.Resources {
+		if res.URN == resA {
+			assert.Equal(t, "bar", res.Inputs["foo"].StringValue())
+			assert.Equal(t, "a", res.Inputs["tags"].ObjectValue()["Name"].StringValue())
+		}
+	}
+
+	// Now change a property that is not ignored. The resource should be updated, but the ignored property should
+	// still retain its old value.
+	inputs["tags"].ObjectValue()["Owner"] = resource.NewStringProperty("d")
+	snap = updateWithOp(snap, deploy.OpUpdate)
+	for _, res := range snap.Resources {
+		if res.URN == resA {
+			assert.Equal(t, "bar", res.Inputs["foo"].StringValue())
+			assert.Equal(t, "d", res.Inputs["tags"].ObjectValue()["Owner"].StringValue())
+		}
+	}
+
+	// Ignoring changes to a property that cannot be restored because its parent is missing from the new inputs is an
+	// error.
+	delete(inputs, "tags")
+	ignoreChanges = []string{"tags.Name"}
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true}}
+	p.Run(t, snap)
+}
+
+func TestCustomTimeouts(t *testing.T) {
+	var createTimeout, updateTimeout, deleteTimeout float64
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					createTimeout = timeout
+					return "created-id", news, resource.StatusOK, nil
+				},
+				UpdateF: func(urn resource.URN, id resource.ID, olds, news resource.PropertyMap,
+					timeout float64) (resource.PropertyMap, resource.Status, error) {
+					updateTimeout = timeout
+					return news, resource.StatusOK, nil
+				},
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+					deleteTimeout = timeout
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	register := true
+	inputs := resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "bar"})
+	timeouts := &pulumirpc.RegisterResourceRequest_CustomTimeouts{Create: "5m", Update: "90s", Delete: "1h"}
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		if !register {
+			return nil
+		}
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "", inputs,
+			deploytest.ResourceOptions{CustomTimeouts: timeouts})
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+		Steps:   []TestStep{{Op: Update}},
+	}
+	resA := p.NewURN("pkgA:m:typA", "resA", "")
+
+	// Create the resource. The create timeout should be passed to the provider and all timeouts should be recorded
+	// in the resource's state.
+	snap := p.Run(t, nil)
+	assert.Equal(t, float64(300), createTimeout)
+	for _, res := range snap.Resources {
+		if res.URN == resA {
+			assert.Equal(t, resource.CustomTimeouts{Create: 300, Update: 90, Delete: 3600}, res.CustomTimeouts)
+		}
+	}
+
+	// Update the resource. The update timeout should be passed to the provider.
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "baz"})
+	snap = p.Run(t, snap)
+	assert.Equal(t, float64(90), updateTimeout)
+
+	// Delete the resource. The delete timeout recorded in the resource's state should be passed to the provider.
+	register = false
+	snap = p.Run(t, snap)
+	assert.Equal(t, float64(3600), deleteTimeout)
+
+	// Invalid timeouts should fail the update.
+	register = true
+	timeouts = &pulumirpc.RegisterResourceRequest_CustomTimeouts{Create: "soon"}
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true}}
+	p.Run(t, snap)
+}
+
+func TestCustomTimeoutExceeded(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					<-release
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	// The provider runs in the engine's process and ignores the timeout, so the engine must enforce it.
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "",
+			resource.PropertyMap{}, deploytest.ResourceOptions{
+				CustomTimeouts: &pulumirpc.RegisterResourceRequest_CustomTimeouts{Create: "100ms"},
+			})
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+		Steps: []TestStep{{
+			Op:            Update,
+			ExpectFailure: true,
+			SkipPreview:   true,
+			Validate: func(project workspace.Project, target deploy.Target, j *Journal,
+				events []Event, res error) error {
+				timedOut := false
+				for _, evt := range events {
+					if evt.Type == DiagEvent {
+						e := evt.Payload.(DiagEventPayload)
+						if e.Severity == diag.Error && strings.Contains(e.Message, "custom timeout of 100ms") {
+							timedOut = true
+						}
+					}
+				}
+				assert.True(t, timedOut)
+				return res
+			},
+		}},
+	}
+	p.Run(t, nil)
+}
+
+func TestRetryTransientFailures(t *testing.T) {
+	var creates, failures int
+	var err error
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					creates++
+					if creates <= failures {
+						return "", nil, resource.StatusOK, err
+					}
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "",
+			resource.PropertyMap{})
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{
+			host:  host,
+			Retry: deploy.RetryPolicy{MaxAttempts: 3, Delay: time.Millisecond},
+		},
+	}
+
+	// countRetries runs an update and returns the number of retry warnings it reported.
+	countRetries := func(expectFailure bool) int {
+		retries := 0
+		p.Steps = []TestStep{{
+			Op:            Update,
+			ExpectFailure: expectFailure,
+			SkipPreview:   true,
+			Validate: func(project workspace.Project, target deploy.Target, j *Journal,
+				events []Event, res error) error {
+				for _, evt := range events {
+					if evt.Type == DiagEvent {
+						e := evt.Payload.(DiagEventPayload)
+						if e.Severity == diag.Warning && strings.Contains(e.Message, "transient error") {
+							retries++
+						}
+					}
+				}
+				return res
+			},
+		}}
+		p.Run(t, nil)
+		return retries
+	}
+
+	// Two transient failures should be retried, after which the create should succeed.
+	creates, failures, err = 0, 2, rpcerror.NewRetryable("rate exceeded")
+	assert.Equal(t, 2, countRetries(false))
+	assert.Equal(t, 3, creates)
+
+	// If the failures outlast the policy's maximum number of attempts, the update should fail.
+	creates, failures = 0, 3
+	assert.Equal(t, 2, countRetries(true))
+	assert.Equal(t, 3, creates)
+
+	// Errors that are not retryable should fail the update immediately.
+	creates, failures, err = 0, 1, rpcerror.New(codes.InvalidArgument, "bad input")
+	assert.Equal(t, 0, countRetries(true))
+	assert.Equal(t, 1, creates)
+}
+
+func TestReplaceTargets(t *testing.T) {
+	deleteBeforeReplace := false
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap) (plugin.DiffResult, error) {
+					return plugin.DiffResult{Changes: plugin.DiffNone, DeleteBeforeReplace: deleteBeforeReplace}, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "",
+			resource.PropertyMap{})
+		assert.NoError(t, err)
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true, "", false, nil, "",
+			resource.PropertyMap{})
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+	}
+	resA := p.NewURN("pkgA:m:typA", "resA", "")
+	resB := p.NewURN("pkgA:m:typA", "resB", "")
+
+	// Create the resources.
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+
+	// replaceWithOps runs an update that replaces resA and asserts that it is operated upon with the expected ops,
+	// in order. resB must be left untouched.
+	replaceWithOps := func(expected ...deploy.StepOp) {
+		p.Options.ReplaceTargets = []resource.URN{resA}
+		p.Steps = []TestStep{{
+			Op: Update,
+			Validate: func(project workspace.Project, target deploy.Target, j *Journal, _ []Event, err error) error {
+				var ops []deploy.StepOp
+				for _, entry := range j.Entries {
+					if entry.Kind != JournalEntrySuccess {
+						continue
+					}
+					switch entry.Step.URN() {
+					case resA:
+						ops = append(ops, entry.Step.Op())
+					case resB:
+						assert.Equal(t, deploy.OpSame, entry.Step.Op())
+					}
+				}
+				assert.Equal(t, expected, ops)
+				return err
+			},
+		}}
+		snap = p.Run(t, snap)
+	}
+
+	// Although the provider reports no changes, resA should be replaced.
+	replaceWithOps(deploy.OpCreateReplacement, deploy.OpReplace, deploy.OpDeleteReplaced)
+
+	// If the provider requests delete-before-replace, the old resource should be deleted first.
+	deleteBeforeReplace = true
+	replaceWithOps(deploy.OpDeleteReplaced, deploy.OpReplace, deploy.OpCreateReplacement)
+}
+
+func TestDeleteBeforeReplaceOption(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap) (plugin.DiffResult, error) {
+					if !olds["foo"].DeepEquals(news["foo"]) {
+						return plugin.DiffResult{
+							Changes:     plugin.DiffSome,
+							ReplaceKeys: []resource.PropertyKey{"foo"},
+						}, nil
+					}
+					return plugin.DiffResult{}, nil
+				},
+			}, nil
+		}),
+	}
+
+	inputs := resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "bar"})
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "", inputs,
+			deploytest.ResourceOptions{DeleteBeforeReplace: true})
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+		Steps:   []TestStep{{Op: Update}},
+	}
+	resA := p.NewURN("pkgA:m:typA", "resA", "")
+
+	// Create the resource. Its state should record that it must be deleted before it is replaced.
+	snap := p.Run(t, nil)
+	for _, res := range snap.Resources {
+		if res.URN == resA {
+			assert.True(t, res.DeleteBeforeReplace)
+		}
+	}
+
+	// Change the resource's inputs. Although the provider did not ask for it, the old resource should be deleted
+	// before its replacement is created.
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "baz"})
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, _ []Event, err error) error {
+			var ops []deploy.StepOp
+			for _, entry := range j.Entries {
+				if entry.Kind == JournalEntrySuccess && entry.Step.URN() == resA {
+					ops = append(ops, entry.Step.Op())
+				}
+			}
+			assert.Equal(t, []deploy.StepOp{deploy.OpDeleteReplaced, deploy.OpReplace, deploy.OpCreateReplacement}, ops)
+			return err
+		},
+	}}
+	p.Run(t, snap)
+}
+
+func TestRetainOnDelete(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap) (plugin.DiffResult, error) {
+					if !olds["foo"].DeepEquals(news["foo"]) {
+						return plugin.DiffResult{
+							Changes:     plugin.DiffSome,
+							ReplaceKeys: []resource.PropertyKey{"foo"},
+						}, nil
+					}
+					return plugin.DiffResult{}, nil
+				},
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+					assert.Fail(t, "Delete was called for a resource that should be retained")
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	register := true
+	inputs := resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "bar"})
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		if !register {
+			return nil
+		}
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "", inputs,
+			deploytest.ResourceOptions{RetainOnDelete: true})
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+		Steps:   []TestStep{{Op: Update}},
+	}
+	resA := p.NewURN("pkgA:m:typA", "resA", "")
+
+	// Create the resource, then replace it. The old resource should be dropped from the state without being deleted.
+	snap := p.Run(t, nil)
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "baz"})
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 2)
+
+	// Remove the resource from the program. It should be dropped from the state without being deleted.
+	register = false
+	snap = p.Run(t, snap)
+	for _, res := range snap.Resources {
+		assert.NotEqual(t, resA, res.URN)
+	}
+}
+
+func TestAliases(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	p := &TestPlan{}
+	comp := p.NewURN("my:comp", "comp", "")
+
+	// The program registers a component and two custom resources, the second of which depends on the first. The name
+	// and parent of the first resource are configurable, as are its aliases.
+	nameA, parentA := "resA", resource.URN("")
+	var aliasesA []resource.URN
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("my:comp", "comp", false, "", false, nil, "", resource.PropertyMap{})
+		if err != nil {
+			return err
+		}
+		urnA, _, _, err := monitor.RegisterResource("pkgA:m:typA", nameA, true, parentA, false, nil, "",
+			resource.PropertyMap{}, deploytest.ResourceOptions{Aliases: aliasesA})
+		if err != nil {
+			return err
+		}
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true, "", false, []resource.URN{urnA}, "",
+			resource.PropertyMap{})
+		return err
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	// updateWithoutReplacement runs an update and asserts that no resources are created or deleted.
+	updateWithoutReplacement := func(snap *deploy.Snapshot) *deploy.Snapshot {
+		p.Steps = []TestStep{{
+			Op: Update,
+			Validate: func(project workspace.Project, target deploy.Target, j *Journal, _ []Event, err error) error {
+				for _, entry := range j.Entries {
+					assert.Equal(t, deploy.OpSame, entry.Step.Op(), "%v", entry.Step.URN())
+				}
+				return err
+			},
+		}}
+		return p.Run(t, snap)
+	}
+
+	// assertRenamed asserts that resA is known only by the given URN, and that resB's dependency refers to that URN.
+	assertRenamed := func(snap *deploy.Snapshot, urnA resource.URN) {
+		assert.NoError(t, snap.VerifyIntegrity())
+		found := false
+		for _, res := range snap.Resources {
+			assert.NotEqual(t, p.NewURN("pkgA:m:typA", "resA", ""), res.URN)
+			if res.URN == urnA {
+				found = true
+			}
+			if res.URN == p.NewURN("pkgA:m:typA", "resB", "") {
+				assert.Equal(t, []resource.URN{urnA}, res.Dependencies)
+			}
+		}
+		assert.True(t, found)
+	}
+
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+	urnA := p.NewURN("pkgA:m:typA", "resA", "")
+
+	// Rename the first resource. It should be renamed in place rather than replaced.
+	nameA, aliasesA = "resA2", []resource.URN{urnA}
+	snap = updateWithoutReplacement(snap)
+	urnA2 := p.NewURN("pkgA:m:typA", "resA2", "")
+	assertRenamed(snap, urnA2)
+
+	// Re-parent the renamed resource under the component, this time in a plan that targets only the renamed resource.
+	// The untargeted dependent must be left alone, but its dependency must refer to the new URN.
+	parentA, aliasesA = comp, []resource.URN{urnA2}
+	urnA3 := p.NewURN("pkgA:m:typA", "resA2", comp)
+	p.Options.Targets = []resource.URN{urnA3}
+	snap = updateWithoutReplacement(snap)
+	p.Options.Targets = nil
+	assertRenamed(snap, urnA3)
+
+	// Two resources may not claim the same existing resource via their aliases.
+	program = deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		for _, name := range []string{"resC", "resD"} {
+			_, _, _, err := monitor.RegisterResource("pkgA:m:typA", name, true, "", false, nil, "",
+				resource.PropertyMap{}, deploytest.ResourceOptions{Aliases: []resource.URN{urnA3}})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true}}
+	p.Run(t, snap)
+}
+
+func TestStackReference(t *testing.T) {
+	foo := "bar"
+	backendClient := &deploytest.BackendClient{
+		GetStackOutputsF: func(ctx context.Context, name string) (resource.PropertyMap, error) {
+			switch name {
+			case "other":
+				return resource.NewPropertyMapFromMap(map[string]interface{}{
+					"foo": foo,
+				}), nil
+			default:
+				return nil, errors.Errorf("unknown stack \"%s\"", name)
+			}
+		},
+	}
+
+	// Create a resource that references a stack. Its outputs are read from the backend.
+	program := deploytest.NewLanguageRuntime(func(info plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, state, err := monitor.RegisterResource(providers.StackReferenceType, "other", true, "", false, nil,
+			"", resource.PropertyMap{"name": resource.NewStringProperty("other")})
+		assert.NoError(t, err)
+		if !info.DryRun {
+			assert.Equal(t, foo, state["outputs"].ObjectValue()["foo"].StringValue())
+		}
+		return nil
+	})
+	p := &TestPlan{
+		BackendClient: backendClient,
+		Options:       UpdateOptions{host: deploytest.NewPluginHost(nil, nil, program)},
+		Steps: []TestStep{{
+			Op: Update,
+			Validate: func(project workspace.Project, target deploy.Target, j *Journal, _ []Event,
+				err error) error {
+
+				for _, entry := range j.Entries {
+					assert.Equal(t, deploy.OpCreate, entry.Step.Op())
+				}
+				return err
+			},
+		}},
+	}
+	snap := p.Run(t, nil)
+	assert.Len(t, snap.Resources, 2)
+	assert.Equal(t, providers.StackReferenceType, snap.Resources[1].Type)
+
+	// Refreshing the stack reference re-reads the outputs.
+	p.Steps = []TestStep{{Op: Refresh, SkipPreview: true}}
+	snap = p.Run(t, snap)
+	assert.Equal(t, "bar", snap.Resources[1].Outputs["outputs"].ObjectValue()["foo"].StringValue())
+
+	// An update re-reads the outputs, and leaves the stack reference alone if they have not changed.
+	validateOp := func(op deploy.StepOp) ValidateFunc {
+		return func(project workspace.Project, target deploy.Target, j *Journal, _ []Event, err error) error {
+			for _, entry := range j.Entries {
+				if entry.Step.URN() == snap.Resources[1].URN {
+					assert.Equal(t, op, entry.Step.Op())
+				}
+			}
+			return err
+		}
+	}
+	p.Steps = []TestStep{{Op: Update, SkipPreview: true, Validate: validateOp(deploy.OpSame)}}
+	snap = p.Run(t, snap)
+
+	// If the outputs have changed, the update records their new values.
+	foo = "baz"
+	p.Steps = []TestStep{{Op: Update, Validate: validateOp(deploy.OpUpdate)}}
+	snap = p.Run(t, snap)
+	assert.Equal(t, "baz", snap.Resources[1].Outputs["outputs"].ObjectValue()["foo"].StringValue())
+
+	// Referencing an unknown stack fails.
+	program = deploytest.NewLanguageRuntime(func(info plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource(providers.StackReferenceType, "other", true, "", false, nil,
+			"", resource.PropertyMap{"name": resource.NewStringProperty("rehto")})
+		assert.Error(t, err)
+		return err
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program)
+	p.Steps = []TestStep{{Op: Update, SkipPreview: true, ExpectFailure: true}}
+	p.Run(t, snap)
+}
+
+func TestSavedPlan(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					return "created-id", resource.PropertyMap{
+						"out": resource.NewStringProperty("known"),
+					}, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	// Create a program that registers two resources, the second of which consumes an output of the first. As with the
+	// language SDKs, the output is unknown during a preview.
+	foo := "bar"
+	program := deploytest.NewLanguageRuntime(func(info plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, state, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "",
+			resource.PropertyMap{"foo": resource.NewStringProperty(foo)})
+		if err != nil {
+			return err
+		}
+		in := state["out"]
+		if info.DryRun {
+			in = resource.MakeComputed(resource.NewStringProperty(""))
+		}
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true, "", false, nil, "",
+			resource.PropertyMap{"in": in})
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{}
+	project, target := p.GetProject(), p.GetTarget(nil)
+
+	// Preview the update, recording its plan.
+	plan := &deploy.UpdatePlan{}
+	_, err := TestOp(Update).Run(project, target, UpdateOptions{host: host, RecordPlan: plan}, true, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, plan.Steps, 3)
+	for _, step := range plan.Steps {
+		assert.Equal(t, deploy.OpCreate, step.Op)
+	}
+	assert.True(t, plan.Steps[2].NewInputs["in"].IsComputed())
+
+	// An update whose inputs diverge from the plan fails before it creates anything.
+	foo = "baz"
+	snap, err := TestOp(Update).Run(project, p.GetTarget(nil), UpdateOptions{host: host, Plan: plan}, false, nil, nil)
+	assert.Error(t, err)
+	if snap != nil {
+		assert.Len(t, snap.Resources, 1)
+	}
+
+	// An update that matches the plan succeeds, even though the previously unknown output is now known.
+	foo = "bar"
+	snap, err = TestOp(Update).Run(project, p.GetTarget(nil), UpdateOptions{host: host, Plan: plan}, false, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, snap.Resources, 3)
+
+	// Once the resources exist, the plan no longer applies: its creates would now be sames.
+	_, err = TestOp(Update).Run(project, p.GetTarget(snap), UpdateOptions{host: host, Plan: plan}, false, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestRefreshPreviewReportsDrift(t *testing.T) {
+	drifted := false
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					return "created-id", news, resource.StatusOK, nil
+				},
+				ReadF: func(urn resource.URN, id resource.ID,
+					props resource.PropertyMap) (resource.PropertyMap, resource.Status, error) {
+
+					if drifted {
+						changed := resource.PropertyMap{"foo": resource.NewStringProperty("changed")}
+						return changed, resource.StatusOK, nil
+					}
+					return props, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "",
+			resource.PropertyMap{"foo": resource.NewStringProperty("bar")})
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{Options: UpdateOptions{host: host}}
+	project := p.GetProject()
+	snap, err := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, nil, nil)
+	assert.NoError(t, err)
+
+	// previewRefresh previews a refresh and returns the changes that it reports.
+	previewRefresh := func() ResourceChanges {
+		cancelCtx, _ := cancel.NewContext(context.Background())
+		events := make(chan Event)
+		go func() {
+			for range events {
+			}
+		}()
+		defer close(events)
+
+		info := &updateInfo{project: project, target: p.GetTarget(CloneSnapshot(t, snap))}
+		ctx := &Context{Cancel: cancelCtx, Events: events, SnapshotManager: newJournal()}
+		changes, err := Refresh(info, ctx, p.Options, true)
+		assert.NoError(t, err)
+		return changes
+	}
+
+	// A resource whose state matches the provider's is not reported.
+	assert.False(t, previewRefresh().HasChanges())
+
+	// A resource whose state differs from the provider's is reported as an update, but the stack's state is left
+	// unchanged.
+	drifted = true
+	changes := previewRefresh()
+	assert.True(t, changes.HasChanges())
+	assert.Equal(t, 1, changes[deploy.OpUpdate])
+	assert.Equal(t, "bar", snap.Resources[1].Outputs["foo"].StringValue())
+}
+
+func TestAnalyzerEnforcementLevels(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		resA, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "",
+			resource.PropertyMap{"foo": resource.NewStringProperty("bar")})
+		if err != nil {
+			return err
+		}
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true, "", false, []resource.URN{resA}, "",
+			resource.PropertyMap{})
+		return err
+	})
+
+	// The analyzer reports an advisory failure for each resource with a "foo" property, and reports a stack-wide
+	// failure at the given level if resB does not depend on resA.
+	var stackLevel plugin.EnforcementLevel
+	var analyzed []plugin.AnalyzerResource
+	analyzer := &deploytest.Analyzer{
+		AnalyzerName: "policy",
+		AnalyzeF: func(t tokens.Type, props resource.PropertyMap) ([]plugin.AnalyzeFailure, error) {
+			if _, has := props["foo"]; has {
+				return []plugin.AnalyzeFailure{{
+					Property:         "foo",
+					Reason:           "foo is discouraged",
+					EnforcementLevel: plugin.Advisory,
+				}}, nil
+			}
+			return nil, nil
+		},
+		AnalyzeStackF: func(resources []plugin.AnalyzerResource) ([]plugin.AnalyzeFailure, error) {
+			analyzed = resources
+			for _, res := range resources {
+				if res.URN.Name() == "resB" && len(res.Dependencies) == 1 {
+					return []plugin.AnalyzeFailure{{
+						URN:              res.URN,
+						Reason:           "resB must not depend on resA",
+						EnforcementLevel: stackLevel,
+					}}, nil
+				}
+			}
+			return nil, nil
+		},
+	}
+	host := deploytest.NewPluginHostWithAnalyzers(nil, nil, program, []plugin.Analyzer{analyzer}, loaders...)
+
+	p := &TestPlan{}
+	project := p.GetProject()
+	opts := UpdateOptions{host: host, Analyzers: []string{"policy"}}
+
+	// A mandatory stack-wide failure fails the preview.
+	stackLevel = plugin.Mandatory
+	_, err := TestOp(Update).Run(project, p.GetTarget(nil), opts, true, nil, nil)
+	assert.Error(t, err)
+
+	// The stack analyzer sees every resource, including the default provider, along with its dependencies.
+	if assert.Len(t, analyzed, 3) {
+		assert.True(t, providers.IsProviderType(analyzed[0].Type))
+		assert.Equal(t, "resA", string(analyzed[1].URN.Name()))
+		assert.Equal(t, []resource.URN{analyzed[1].URN}, analyzed[2].Dependencies)
+	}
+
+	// Advisory failures are reported as policy violations, but do not fail the update.
+	stackLevel = plugin.Advisory
+	_, err = TestOp(Update).Run(project, p.GetTarget(nil), opts, false, nil,
+		func(_ workspace.Project, _ deploy.Target, _ *Journal, events []Event, err error) error {
+			var violations []PolicyViolationEventPayload
+			var summary *SummaryEventPayload
+			for _, e := range events {
+				switch e.Type {
+				case PolicyViolationEvent:
+					violations = append(violations, e.Payload.(PolicyViolationEventPayload))
+				case SummaryEvent:
+					payload := e.Payload.(SummaryEventPayload)
+					summary = &payload
+				}
+			}
+
+			if assert.Len(t, violations, 2) {
+				assert.Equal(t, "resA", string(violations[0].URN.Name()))
+				assert.Equal(t, resource.PropertyKey("foo"), violations[0].Property)
+				assert.Equal(t, plugin.Advisory, violations[0].EnforcementLevel)
+				assert.Equal(t, "resB", string(violations[1].URN.Name()))
+				assert.Equal(t, tokens.QName("policy"), violations[1].Analyzer)
+			}
+			if assert.NotNil(t, summary) {
+				assert.Equal(t, violations, summary.PolicyViolations)
+			}
+			return err
+		})
+	assert.NoError(t, err)
+}
+
+func TestDetailedDiff(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap) (plugin.DiffResult, error) {
+					detailedDiff := make(map[string]plugin.PropertyDiff)
+					if !olds["foo"].DeepEquals(news["foo"]) {
+						detailedDiff["foo"] = plugin.PropertyDiff{Kind: plugin.DiffUpdateReplace}
+					}
+					if !olds["bar"].DeepEquals(news["bar"]) {
+						detailedDiff["bar.baz"] = plugin.PropertyDiff{Kind: plugin.DiffUpdate}
+					}
+					if len(detailedDiff) == 0 {
+						return plugin.DiffResult{Changes: plugin.DiffNone}, nil
+					}
+					return plugin.DiffResult{Changes: plugin.DiffSome, DetailedDiff: detailedDiff}, nil
+				},
+				CreateF: func(urn resource.URN, inputs resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					return "created-id", inputs, resource.StatusOK, nil
+				},
+				UpdateF: func(urn resource.URN, id resource.ID, olds, news resource.PropertyMap,
+					timeout float64) (resource.PropertyMap, resource.Status, error) {
+					return news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	inputs := resource.PropertyMap{}
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, "", false, nil, "", inputs)
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+	}
+	resA := p.NewURN("pkgA:m:typA", "resA", "")
+
+	// updateWithDiff runs an update and asserts that resA is operated upon with the expected op and that the
+	// provider's detailed diff is attached to the step's events.
+	updateWithDiff := func(snap *deploy.Snapshot, expectedOp deploy.StepOp,
+		expectedDiff map[string]plugin.PropertyDiff) *deploy.Snapshot {
+
+		p.Steps = []TestStep{{
+			Op: Update,
+			Validate: func(project workspace.Project, target deploy.Target, j *Journal, events []Event,
+				err error) error {
+
+				found := false
+				for _, e := range events {
+					if e.Type != ResourcePreEvent {
+						continue
+					}
+					md := e.Payload.(ResourcePreEventPayload).Metadata
+					if md.URN == resA && md.Op == expectedOp {
+						found = true
+						assert.Equal(t, expectedDiff, md.DetailedDiff)
+					}
+				}
+				assert.True(t, found)
+				return err
+			},
+		}}
+		return p.Run(t, snap)
+	}
+
+	inputs["foo"] = resource.NewStringProperty("foo")
+	inputs["bar"] = resource.NewObjectProperty(resource.PropertyMap{"baz": resource.NewStringProperty("baz")})
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+
+	// A detailed diff without replacements results in an update.
+	inputs["bar"] = resource.NewObjectProperty(resource.PropertyMap{"baz": resource.NewStringProperty("qux")})
+	snap = updateWithDiff(snap, deploy.OpUpdate, map[string]plugin.PropertyDiff{
+		"bar.baz": {Kind: plugin.DiffUpdate},
+	})
+
+	// A detailed diff with a replacement results in a replacement, even though the provider returned no replace keys.
+	inputs["foo"] = resource.NewStringProperty("bar")
+	updateWithDiff(snap, deploy.OpReplace, map[string]plugin.PropertyDiff{
+		"foo": {Kind: plugin.DiffUpdateReplace},
+	})
+}
@@ -155,7 +155,7 @@ func plan(ctx *Context, info *planContext, opts planOptions, dryRun bool) (*plan
 	}
 
 	// Generate a plan; this API handles all interesting cases (create, update, delete).
-	plan, err := deploy.NewPlan(plugctx, target, target.Snapshot, source, analyzers, dryRun)
+	plan, err := deploy.NewPlan(plugctx, target, target.Snapshot, source, analyzers, dryRun, ctx.BackendClient)
 	if err != nil {
 		return nil, err
 	}
@@ -210,6 +210,13 @@ func (res *planResult) Walk(cancelCtx *Context, events deploy.Events, preview bo
 			Refresh:           res.Options.Refresh,
 			RefreshOnly:       res.Options.isRefresh,
 			TrustDependencies: res.Options.trustDependencies,
+			Targets:           res.Options.Targets,
+			TargetDependents:  res.Options.TargetDependents,
+			ReplaceTargets:    res.Options.ReplaceTargets,
+			Imports:           res.Options.Imports,
+			SavedPlan:         res.Options.Plan,
+			RecordPlan:        res.Options.RecordPlan,
+			Retry:             res.Options.Retry,
 		}
 		err = res.Plan.Execute(ctx, opts, preview)
 		close(done)
@@ -251,15 +258,16 @@ func printPlan(ctx *Context, result *planResult, dryRun bool) (ResourceChanges,
 
 	// Emit an event with a summary of operation counts.
 	changes := ResourceChanges(actions.Ops)
-	result.Options.Events.previewSummaryEvent(changes)
+	result.Options.Events.previewSummaryEvent(changes, actions.PolicyViolations)
 	return changes, nil
 }
 
 type planActions struct {
-	Ops     map[deploy.StepOp]int
-	Opts    planOptions
-	Seen    map[resource.URN]deploy.Step
-	MapLock sync.Mutex
+	Ops              map[deploy.StepOp]int
+	Opts             planOptions
+	Seen             map[resource.URN]deploy.Step
+	PolicyViolations []PolicyViolationEventPayload
+	MapLock          sync.Mutex
 }
 
 func newPlanActions(opts planOptions) *planActions {
@@ -338,12 +346,31 @@ func (acts *planActions) OnResourceOutputs(step deploy.Step) error {
 	return nil
 }
 
+func (acts *planActions) OnPolicyViolation(analyzer tokens.QName, failure plugin.AnalyzeFailure) {
+	payload := newPolicyViolationEventPayload(analyzer, failure)
+
+	acts.MapLock.Lock()
+	acts.PolicyViolations = append(acts.PolicyViolations, payload)
+	acts.MapLock.Unlock()
+
+	acts.Opts.Events.policyViolationEvent(payload)
+}
+
+func newPolicyViolationEventPayload(analyzer tokens.QName, failure plugin.AnalyzeFailure) PolicyViolationEventPayload {
+	return PolicyViolationEventPayload{
+		URN:              failure.URN,
+		Analyzer:         analyzer,
+		Property:         failure.Property,
+		Message:          failure.Reason,
+		EnforcementLevel: failure.EnforcementLevel,
+	}
+}
+
 func assertSeen(seen map[resource.URN]deploy.Step, step deploy.Step) {
 	_, has := seen[step.URN()]
 	contract.Assertf(has, "URN '%v' had not been marked as seen", step.URN())
 }
 
 func isDefaultProviderStep(step deploy.Step) bool {
-	urn := step.URN()
-	return providers.IsProviderType(urn.Type()) && urn.Name() == "default"
+	return providers.IsDefaultProvider(step.URN())
 }
@@ -39,12 +39,34 @@ type UpdateOptions struct {
 	// the degree of parallelism for resource operations (<=1 for serial).
 	Parallel int
 
+	// the policy for retrying resource operations that fail with a transient error.
+	Retry deploy.RetryPolicy
+
 	// true if debugging output it enabled
 	Debug bool
 
 	// true if the plan should refresh before executing.
 	Refresh bool
 
+	// an optional set of resource URNs to which the update should be restricted.
+	Targets []resource.URN
+
+	// true if resources that depend upon a targeted resource should be targeted as well.
+	TargetDependents bool
+
+	// an optional set of resource URNs that should be replaced even if their providers report no changes.
+	ReplaceTargets []resource.URN
+
+	// an optional list of existing resources to import in lieu of evaluating the program.
+	Imports []deploy.Import
+
+	// an optional plan saved by a previous preview. If present, the update fails if any of its steps diverge from
+	// those of the saved plan.
+	Plan *deploy.UpdatePlan
+
+	// an optional plan into which the steps generated by the update are recorded.
+	RecordPlan *deploy.UpdatePlan
+
 	// true if we should report events for steps that involve default providers.
 	reportDefaultProviderSteps bool
 
@@ -122,12 +144,18 @@ func newUpdateSource(
 
 	// If that succeeded, create a new source that will perform interpretation of the compiled program.
 	// TODO[pulumi/pulumi#88]: we are passing `nil` as the arguments map; we need to allow a way to pass these.
-	return deploy.NewEvalSource(plugctx, &deploy.EvalRunInfo{
+	runinfo := &deploy.EvalRunInfo{
 		Proj:    proj,
 		Pwd:     pwd,
 		Program: main,
 		Target:  target,
-	}, defaultProviderVersions, dryRun), nil
+	}
+
+	// If we have been asked to import resources, register those rather than evaluating the program.
+	if len(opts.Imports) != 0 {
+		return deploy.NewImportSource(plugctx, runinfo, defaultProviderVersions, opts.Imports, dryRun), nil
+	}
+	return deploy.NewEvalSource(plugctx, runinfo, defaultProviderVersions, dryRun), nil
 }
 
 func update(ctx *Context, info *planContext, opts planOptions, dryRun bool) (ResourceChanges, error) {
@@ -163,7 +191,8 @@ func update(ctx *Context, info *planContext, opts planOptions, dryRun bool) (Res
 
 			if len(resourceChanges) != 0 {
 				// Print out the total number of steps performed (and their kinds), the duration, and any summary info.
-				opts.Events.updateSummaryEvent(actions.MaybeCorrupt, time.Since(start), resourceChanges)
+				opts.Events.updateSummaryEvent(
+					actions.MaybeCorrupt, time.Since(start), resourceChanges, actions.PolicyViolations)
 			}
 		}
 	}
@@ -182,14 +211,15 @@ func (p *pluginActions) OnPluginLoad(loadedPlug workspace.PluginInfo) error {
 
 // updateActions pretty-prints the plan application process as it goes.
 type updateActions struct {
-	Context      *Context
-	Steps        int
-	Ops          map[deploy.StepOp]int
-	Seen         map[resource.URN]deploy.Step
-	MapLock      sync.Mutex
-	MaybeCorrupt bool
-	Update       UpdateInfo
-	Opts         planOptions
+	Context          *Context
+	Steps            int
+	Ops              map[deploy.StepOp]int
+	Seen             map[resource.URN]deploy.Step
+	PolicyViolations []PolicyViolationEventPayload
+	MapLock          sync.Mutex
+	MaybeCorrupt     bool
+	Update           UpdateInfo
+	Opts             planOptions
 }
 
 func newUpdateActions(context *Context, u UpdateInfo, opts planOptions) *updateActions {
@@ -315,3 +345,13 @@ func (acts *updateActions) OnResourceOutputs(step deploy.Step) error {
 	// We need to perform another snapshot write to ensure they get written out.
 	return acts.Context.SnapshotManager.RegisterResourceOutputs(step)
 }
+
+func (acts *updateActions) OnPolicyViolation(analyzer tokens.QName, failure plugin.AnalyzeFailure) {
+	payload := newPolicyViolationEventPayload(analyzer, failure)
+
+	acts.MapLock.Lock()
+	acts.PolicyViolations = append(acts.PolicyViolations, payload)
+	acts.MapLock.Unlock()
+
+	acts.Opts.Events.policyViolationEvent(payload)
+}
@@ -16,6 +16,7 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/pkg/errors"
 )
@@ -36,10 +37,11 @@ func (m Map) Decrypt(decrypter Decrypter) (map[Key]string, error) {
 	return r, nil
 }
 
-// HasSecureValue returns true if the config map contains a secure (encrypted) value.
+// HasSecureValue returns true if the config map contains a secure (encrypted) value, either directly or within an
+// object.
 func (m Map) HasSecureValue() bool {
 	for _, v := range m {
-		if v.Secure() {
+		if v.ContainsSecrets() {
 			return true
 		}
 	}
@@ -104,3 +106,229 @@ func (m *Map) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	*m = newMap
 	return nil
 }
+
+// GetPath returns the part of the value for the given key that is found by following the given path, which is a list
+// of map keys (strings) and array indices (ints). An empty path returns the value itself. The returned bool is false
+// if there is no such value.
+func (m Map) GetPath(k Key, path []interface{}) (Value, bool, error) {
+	v, ok := m[k]
+	if !ok || len(path) == 0 {
+		return v, ok, nil
+	}
+	if !v.Object() {
+		return Value{}, false, errors.Errorf("config value %s is not an object", k)
+	}
+
+	obj, err := v.ToObject()
+	if err != nil {
+		return Value{}, false, err
+	}
+	for _, elem := range path {
+		switch elem := elem.(type) {
+		case string:
+			o, ok := obj.(map[string]interface{})
+			if !ok {
+				return Value{}, false, errors.Errorf("cannot index a non-object with key %q", elem)
+			}
+			if _, secure := secureLeaf(o); secure {
+				return Value{}, false, errors.New("cannot index a secure value")
+			}
+			if obj, ok = o[elem]; !ok {
+				return Value{}, false, nil
+			}
+		case int:
+			a, ok := obj.([]interface{})
+			if !ok {
+				return Value{}, false, errors.Errorf("cannot index a non-array with index %d", elem)
+			}
+			if elem < 0 || elem >= len(a) {
+				return Value{}, false, nil
+			}
+			obj = a[elem]
+		default:
+			return Value{}, false, errors.Errorf("invalid path element %v", elem)
+		}
+	}
+
+	switch obj := obj.(type) {
+	case nil:
+		return Value{}, false, nil
+	case string, map[string]interface{}, []interface{}:
+		result, err := newObjectValueFromObject(obj)
+		return result, err == nil, err
+	default:
+		return NewValue(fmt.Sprintf("%v", obj)), true, nil
+	}
+}
+
+// SetPath sets the part of the value for the given key that is found by following the given path to v, creating any
+// missing objects and arrays along the way. An array may be extended by setting the element one past its end.
+func (m Map) SetPath(k Key, path []interface{}, v Value) error {
+	if len(path) == 0 {
+		m[k] = v
+		return nil
+	}
+
+	var root interface{}
+	if existing, ok := m[k]; ok {
+		if !existing.Object() {
+			return errors.Errorf("config value %s is not an object", k)
+		}
+		obj, err := existing.ToObject()
+		if err != nil {
+			return err
+		}
+		root = obj
+	}
+
+	var leaf interface{}
+	switch {
+	case v.Secure():
+		leaf = map[string]interface{}{"secure": v.value}
+	case v.Object():
+		obj, err := v.ToObject()
+		if err != nil {
+			return err
+		}
+		leaf = obj
+	default:
+		leaf = v.value
+	}
+
+	root, err := setPath(root, path, leaf)
+	if err != nil {
+		return err
+	}
+	result, err := newObjectValueFromObject(root)
+	if err != nil {
+		return err
+	}
+	m[k] = result
+	return nil
+}
+
+// setPath returns a copy of obj in which the value at the given path has been set to leaf.
+func setPath(obj interface{}, path []interface{}, leaf interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return leaf, nil
+	}
+
+	switch elem := path[0].(type) {
+	case string:
+		if obj == nil {
+			obj = map[string]interface{}{}
+		}
+		o, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("cannot index a non-object with key %q", elem)
+		}
+		if _, secure := secureLeaf(o); secure {
+			return nil, errors.New("cannot index a secure value")
+		}
+		v, err := setPath(o[elem], path[1:], leaf)
+		if err != nil {
+			return nil, err
+		}
+		o[elem] = v
+		return o, nil
+	case int:
+		if obj == nil {
+			obj = []interface{}{}
+		}
+		a, ok := obj.([]interface{})
+		if !ok {
+			return nil, errors.Errorf("cannot index a non-array with index %d", elem)
+		}
+		if elem < 0 || elem > len(a) {
+			return nil, errors.Errorf("array index %d out of range", elem)
+		}
+		if elem == len(a) {
+			a = append(a, nil)
+		}
+		v, err := setPath(a[elem], path[1:], leaf)
+		if err != nil {
+			return nil, err
+		}
+		a[elem] = v
+		return a, nil
+	default:
+		return nil, errors.Errorf("invalid path element %v", elem)
+	}
+}
+
+// RemovePath removes the part of the value for the given key that is found by following the given path. Removing an
+// array element shifts the elements that follow it. It is not an error if there is no such value.
+func (m Map) RemovePath(k Key, path []interface{}) error {
+	if len(path) == 0 {
+		delete(m, k)
+		return nil
+	}
+
+	existing, ok := m[k]
+	if !ok {
+		return nil
+	}
+	if !existing.Object() {
+		return errors.Errorf("config value %s is not an object", k)
+	}
+	root, err := existing.ToObject()
+	if err != nil {
+		return err
+	}
+	if root, err = removePath(root, path); err != nil {
+		return err
+	}
+	result, err := newObjectValueFromObject(root)
+	if err != nil {
+		return err
+	}
+	m[k] = result
+	return nil
+}
+
+// removePath returns a copy of obj from which the value at the given non-empty path has been removed.
+func removePath(obj interface{}, path []interface{}) (interface{}, error) {
+	switch elem := path[0].(type) {
+	case string:
+		o, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("cannot index a non-object with key %q", elem)
+		}
+		if _, secure := secureLeaf(o); secure {
+			return nil, errors.New("cannot index a secure value")
+		}
+		v, ok := o[elem]
+		if !ok {
+			return o, nil
+		}
+		if len(path) == 1 {
+			delete(o, elem)
+			return o, nil
+		}
+		v, err := removePath(v, path[1:])
+		if err != nil {
+			return nil, err
+		}
+		o[elem] = v
+		return o, nil
+	case int:
+		a, ok := obj.([]interface{})
+		if !ok {
+			return nil, errors.Errorf("cannot index a non-array with index %d", elem)
+		}
+		if elem < 0 || elem >= len(a) {
+			return a, nil
+		}
+		if len(path) == 1 {
+			return append(a[:elem], a[elem+1:]...), nil
+		}
+		v, err := removePath(a[elem], path[1:])
+		if err != nil {
+			return nil, err
+		}
+		a[elem] = v
+		return a, nil
+	default:
+		return nil, errors.Errorf("invalid path element %v", elem)
+	}
+}
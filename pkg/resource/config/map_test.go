@@ -59,6 +59,56 @@ func TestMarshalMapYAML(t *testing.T) {
 	assert.Equal(t, m, newM)
 }
 
+func TestMapPaths(t *testing.T) {
+	k := Key{namespace: "my", name: "db"}
+	m := Map{}
+
+	// Setting a path creates the objects and arrays along it.
+	assert.NoError(t, m.SetPath(k, []interface{}{"replicas", 0, "host"}, NewValue("a")))
+	assert.NoError(t, m.SetPath(k, []interface{}{"replicas", 1, "host"}, NewValue("b")))
+	assert.NoError(t, m.SetPath(k, []interface{}{"password"}, NewSecureValue("ciphertext")))
+	assert.Equal(t, NewObjectValue(`{"password":{"secure":"ciphertext"},"replicas":[{"host":"a"},{"host":"b"}]}`), m[k])
+	assert.True(t, m.HasSecureValue())
+
+	// Arrays may only be extended by one element at a time.
+	assert.Error(t, m.SetPath(k, []interface{}{"replicas", 3}, NewValue("c")))
+	// Strings cannot be indexed.
+	assert.Error(t, m.SetPath(k, []interface{}{"replicas", 0, "host", "x"}, NewValue("c")))
+
+	v, ok, err := m.GetPath(k, []interface{}{"replicas", 1, "host"})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, NewValue("b"), v)
+	v, ok, err = m.GetPath(k, []interface{}{"replicas", 0})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, NewObjectValue(`{"host":"a"}`), v)
+	v, ok, err = m.GetPath(k, []interface{}{"password"})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, NewSecureValue("ciphertext"), v)
+	_, ok, err = m.GetPath(k, []interface{}{"replicas", 2})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Removing an array element shifts those that follow it.
+	assert.NoError(t, m.RemovePath(k, []interface{}{"replicas", 0}))
+	assert.NoError(t, m.RemovePath(k, []interface{}{"password"}))
+	assert.NoError(t, m.RemovePath(k, []interface{}{"missing"}))
+	assert.Equal(t, NewObjectValue(`{"replicas":[{"host":"b"}]}`), m[k])
+	assert.False(t, m.HasSecureValue())
+
+	// Plain values cannot be indexed.
+	plain := Key{namespace: "my", name: "plain"}
+	assert.NoError(t, m.SetPath(plain, nil, NewValue("value")))
+	_, _, err = m.GetPath(plain, []interface{}{"x"})
+	assert.Error(t, err)
+
+	newM, err := roundtripMapYAML(m)
+	assert.NoError(t, err)
+	assert.Equal(t, m, newM)
+}
+
 func roundtripMapYAML(m Map) (Map, error) {
 	return roundtripMap(m, yaml.Marshal, yaml.Unmarshal)
 }
@@ -0,0 +1,310 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	cryptorand "crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+const (
+	// PassphraseSecretsProvider derives the data key for a stack from a passphrase. This is the default.
+	PassphraseSecretsProvider = "passphrase"
+	// KeyFileSecretsProviderPrefix prefixes the path of a file that holds a stack's raw data key, e.g.
+	// `keyfile:/path/to/key`.
+	KeyFileSecretsProviderPrefix = "keyfile:"
+	// CommandSecretsProviderPrefix prefixes a command that wraps and unwraps a stack's data key, e.g.
+	// `command:vault-wrap --path pulumi/dev`. The command is split into words as a POSIX shell would split it, so
+	// arguments that contain spaces or backslashes must be quoted.
+	CommandSecretsProviderPrefix = "command:"
+)
+
+// SecretsProviderState is the per-stack state that a secrets provider persists alongside the stack's configuration.
+type SecretsProviderState struct {
+	EncryptionSalt string // the salt and verification message used by the passphrase provider.
+	EncryptedKey   string // the wrapped data key used by the command provider.
+}
+
+// SecretsProvider produces the crypter used to protect a stack's secret values.
+type SecretsProvider interface {
+	// Crypter returns a crypter for a stack with the given state. If the provider needed to create new state (e.g. a
+	// fresh salt or data key), it records it in state and returns true so that the caller can persist it.
+	Crypter(state *SecretsProviderState) (Crypter, bool, error)
+}
+
+// PassphraseReader prompts the user for a passphrase.
+type PassphraseReader func(prompt string) (string, error)
+
+// NewSecretsProvider returns the secrets provider described by the given string. An empty string selects the
+// passphrase provider, which uses readPassphrase to obtain the stack's passphrase.
+func NewSecretsProvider(provider string, readPassphrase PassphraseReader) (SecretsProvider, error) {
+	switch {
+	case provider == "" || provider == PassphraseSecretsProvider:
+		return &passphraseSecretsProvider{readPassphrase: readPassphrase}, nil
+	case strings.HasPrefix(provider, KeyFileSecretsProviderPrefix):
+		path := strings.TrimPrefix(provider, KeyFileSecretsProviderPrefix)
+		if path == "" {
+			return nil, errors.New("the keyfile secrets provider requires a path, e.g. keyfile:/path/to/key")
+		}
+		return &keyFileSecretsProvider{path: path}, nil
+	case strings.HasPrefix(provider, CommandSecretsProviderPrefix):
+		args, err := splitCommand(strings.TrimPrefix(provider, CommandSecretsProviderPrefix))
+		if err != nil {
+			return nil, err
+		}
+		if len(args) == 0 {
+			return nil, errors.New("the command secrets provider requires a command, e.g. command:my-wrapper")
+		}
+		return &commandSecretsProvider{args: args}, nil
+	default:
+		return nil, errors.Errorf("unknown secrets provider '%s'; expected %s, %s<path>, or %s<command>",
+			provider, PassphraseSecretsProvider, KeyFileSecretsProviderPrefix, CommandSecretsProviderPrefix)
+	}
+}
+
+// passphraseSecretsProvider derives a stack's key from a passphrase using PBKDF2. The salt is stored in the stack's
+// state along with a message encrypted using the derived key so that an incorrect passphrase can be detected.
+type passphraseSecretsProvider struct {
+	readPassphrase PassphraseReader
+}
+
+func (p *passphraseSecretsProvider) Crypter(state *SecretsProviderState) (Crypter, bool, error) {
+	// If we have a salt, we can just use it.
+	if state.EncryptionSalt != "" {
+		phrase, err := p.readPassphrase("Enter your passphrase to unlock config/secrets\n" +
+			"    (set PULUMI_CONFIG_PASSPHRASE to remember)")
+		if err != nil {
+			return nil, false, err
+		}
+
+		crypter, err := symmetricCrypterFromPhraseAndState(phrase, state.EncryptionSalt)
+		if err != nil {
+			return nil, false, err
+		}
+		return crypter, false, nil
+	}
+
+	// Here, the stack does not have an EncryptionSalt, so we will get a passphrase and create one
+	phrase, err := p.readPassphrase("Enter your passphrase to protect config/secrets")
+	if err != nil {
+		return nil, false, err
+	}
+	confirm, err := p.readPassphrase("Re-enter your passphrase to confirm")
+	if err != nil {
+		return nil, false, err
+	}
+	if phrase != confirm {
+		return nil, false, errors.New("passphrases do not match")
+	}
+
+	// Produce a new salt.
+	salt := make([]byte, 8)
+	_, err = cryptorand.Read(salt)
+	contract.Assertf(err == nil, "could not read from system random")
+
+	// Encrypt a message and store it with the salt so we can test if the password is correct later.
+	crypter := NewSymmetricCrypterFromPassphrase(phrase, salt)
+	msg, err := crypter.EncryptValue("pulumi")
+	contract.AssertNoError(err)
+
+	state.EncryptionSalt = fmt.Sprintf("v1:%s:%s", base64.StdEncoding.EncodeToString(salt), msg)
+	return crypter, true, nil
+}
+
+// given a passphrase and an encryption state, construct a Crypter from it. Our encryption
+// state value is a version tag followed by version specific state information. Presently, we only have one version
+// we support (`v1`) which is AES-256-GCM using a key derived from a passphrase using 1,000,000 iterations of PDKDF2
+// using SHA256.
+func symmetricCrypterFromPhraseAndState(phrase string, state string) (Crypter, error) {
+	splits := strings.SplitN(state, ":", 3)
+	if len(splits) != 3 {
+		return nil, errors.New("malformed state value")
+	}
+
+	if splits[0] != "v1" {
+		return nil, errors.New("unknown state version")
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(splits[1])
+	if err != nil {
+		return nil, err
+	}
+
+	decrypter := NewSymmetricCrypterFromPassphrase(phrase, salt)
+	decrypted, err := decrypter.DecryptValue(state[indexN(state, ":", 2)+1:])
+	if err != nil || decrypted != "pulumi" {
+		return nil, errors.New("incorrect passphrase")
+	}
+
+	return decrypter, nil
+}
+
+func indexN(s string, substr string, n int) int {
+	contract.Require(n > 0, "n")
+	scratch := s
+
+	for i := n; i > 0; i-- {
+		idx := strings.Index(scratch, substr)
+		if i == -1 {
+			return -1
+		}
+
+		scratch = scratch[idx+1:]
+	}
+
+	return len(s) - (len(scratch) + len(substr))
+}
+
+// keyFileSecretsProvider reads a stack's key directly from a file that holds exactly SymmetricCrypterKeyBytes of raw
+// key material. It keeps no state of its own.
+type keyFileSecretsProvider struct {
+	path string
+}
+
+func (p *keyFileSecretsProvider) Crypter(state *SecretsProviderState) (Crypter, bool, error) {
+	key, err := ioutil.ReadFile(p.path)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "reading key file")
+	}
+	if len(key) != SymmetricCrypterKeyBytes {
+		return nil, false, errors.Errorf("key file '%s' must contain exactly %d bytes; found %d",
+			p.path, SymmetricCrypterKeyBytes, len(key))
+	}
+	return NewSymmetricCrypter(key), false, nil
+}
+
+// commandSecretsProvider protects a stack's randomly generated data key by handing it to an external command, which
+// allows tools such as Vault or a cloud KMS CLI to manage the key that actually guards the stack's secrets.
+//
+// The command is invoked as `<command> wrap` with the base64-encoded data key on standard input, and must print the
+// wrapped key on standard output; the wrapped key is stored in the stack's state. It is later invoked as
+// `<command> unwrap` with the wrapped key on standard input, and must print the base64-encoded data key.
+type commandSecretsProvider struct {
+	args []string
+}
+
+func (p *commandSecretsProvider) Crypter(state *SecretsProviderState) (Crypter, bool, error) {
+	// If we already have a wrapped key, just unwrap it.
+	if state.EncryptedKey != "" {
+		unwrapped, err := p.run("unwrap", state.EncryptedKey)
+		if err != nil {
+			return nil, false, err
+		}
+		key, err := base64.StdEncoding.DecodeString(unwrapped)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "decoding unwrapped key")
+		}
+		if len(key) != SymmetricCrypterKeyBytes {
+			return nil, false, errors.Errorf("unwrapped key must be %d bytes; found %d",
+				SymmetricCrypterKeyBytes, len(key))
+		}
+		return NewSymmetricCrypter(key), false, nil
+	}
+
+	// Otherwise, produce a new data key and ask the command to wrap it.
+	key := make([]byte, SymmetricCrypterKeyBytes)
+	_, err := cryptorand.Read(key)
+	contract.Assertf(err == nil, "could not read from system random")
+
+	wrapped, err := p.run("wrap", base64.StdEncoding.EncodeToString(key))
+	if err != nil {
+		return nil, false, err
+	}
+	if wrapped == "" {
+		return nil, false, errors.Errorf("'%s wrap' produced an empty key", p.args[0])
+	}
+
+	state.EncryptedKey = wrapped
+	return NewSymmetricCrypter(key), true, nil
+}
+
+// run invokes the provider's command with the given verb, writing input to its standard input and returning its
+// trimmed standard output.
+func (p *commandSecretsProvider) run(verb string, input string) (string, error) {
+	args := append(append([]string{}, p.args[1:]...), verb)
+	cmd := exec.Command(p.args[0], args...) // nolint: gas, intentionally running a user-specified command
+	cmd.Stdin = strings.NewReader(input)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, "'%s %s' failed: %s", p.args[0], verb, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// splitCommand splits a command line into words the way a POSIX shell does: words are separated by unquoted
+// whitespace, single quotes preserve everything they enclose, and a backslash escapes the next character (within
+// double quotes, only if that character is one of $, `, ", \ or a newline). No other shell syntax is supported.
+func splitCommand(command string) ([]string, error) {
+	var words []string
+	var word bytes.Buffer
+	inWord, escaped := false, false
+	var quote rune // the quote that encloses the current character, if any.
+	for _, c := range command {
+		switch {
+		case escaped:
+			if quote == '"' && !strings.ContainsRune("$`\"\\\n", c) {
+				word.WriteRune('\\')
+			}
+			word.WriteRune(c)
+			escaped = false
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(c)
+			}
+		case c == '\\':
+			escaped, inWord = true, true
+		case quote == '"':
+			if c == '"' {
+				quote = 0
+			} else {
+				word.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			quote, inWord = c, true
+		case unicode.IsSpace(c):
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(c)
+			inWord = true
+		}
+	}
+
+	switch {
+	case quote != 0:
+		return nil, errors.Errorf("unterminated %c quote in command '%s'", quote, command)
+	case escaped:
+		return nil, errors.Errorf("command '%s' ends with an unescaped backslash", command)
+	case inWord:
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+func TestPassphraseSecretsProvider(t *testing.T) {
+	phrase := "correct horse battery staple"
+	provider, err := NewSecretsProvider("", func(string) (string, error) { return phrase, nil })
+	assert.NoError(t, err)
+
+	// The first use creates a salt.
+	var state SecretsProviderState
+	crypter, changed, err := provider.Crypter(&state)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.NotEmpty(t, state.EncryptionSalt)
+
+	ciphertext, err := crypter.EncryptValue("hunter2")
+	assert.NoError(t, err)
+
+	// Later uses recover the same key from the salt.
+	crypter, changed, err = provider.Crypter(&state)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	plaintext, err := crypter.DecryptValue(ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "hunter2", plaintext)
+
+	// An incorrect passphrase is rejected.
+	phrase = "wrong"
+	_, _, err = provider.Crypter(&state)
+	assert.Error(t, err)
+}
+
+func TestKeyFileSecretsProvider(t *testing.T) {
+	f, err := ioutil.TempFile("", "pulumi-key")
+	assert.NoError(t, err)
+	defer func() { contract.IgnoreError(os.Remove(f.Name())) }()
+	_, err = f.Write(make([]byte, SymmetricCrypterKeyBytes))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	provider, err := NewSecretsProvider(KeyFileSecretsProviderPrefix+f.Name(), nil)
+	assert.NoError(t, err)
+
+	var state SecretsProviderState
+	crypter, changed, err := provider.Crypter(&state)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	ciphertext, err := crypter.EncryptValue("hunter2")
+	assert.NoError(t, err)
+	plaintext, err := NewSymmetricCrypter(make([]byte, SymmetricCrypterKeyBytes)).DecryptValue(ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "hunter2", plaintext)
+}
+
+func TestUnknownSecretsProvider(t *testing.T) {
+	_, err := NewSecretsProvider("vault", nil)
+	assert.Error(t, err)
+	_, err = NewSecretsProvider(KeyFileSecretsProviderPrefix, nil)
+	assert.Error(t, err)
+	_, err = NewSecretsProvider(CommandSecretsProviderPrefix, nil)
+	assert.Error(t, err)
+	_, err = NewSecretsProvider(CommandSecretsProviderPrefix+`"wrap`, nil)
+	assert.Error(t, err)
+}
+
+func TestSplitCommand(t *testing.T) {
+	cases := []struct {
+		command  string
+		expected []string
+	}{
+		{"", nil},
+		{"  wrap  ", []string{"wrap"}},
+		{"vault-wrap --path pulumi/dev", []string{"vault-wrap", "--path", "pulumi/dev"}},
+		{`"/opt/my tools/wrap" --name 'dev stack'`, []string{"/opt/my tools/wrap", "--name", "dev stack"}},
+		{`wrap --empty ''`, []string{"wrap", "--empty", ""}},
+		{`wrap my\ key`, []string{"wrap", "my key"}},
+		{`wrap "a \"quoted\" \word"`, []string{"wrap", `a "quoted" \word`}},
+		{`wrap 'C:\keys'`, []string{"wrap", `C:\keys`}},
+		{`wrap --opt="a b"c`, []string{"wrap", "--opt=a bc"}},
+	}
+	for _, c := range cases {
+		words, err := splitCommand(c.command)
+		assert.NoError(t, err, c.command)
+		assert.Equal(t, c.expected, words, c.command)
+	}
+
+	for _, command := range []string{`wrap "dev`, `wrap 'dev`, `wrap dev\`} {
+		_, err := splitCommand(command)
+		assert.Error(t, err, command)
+	}
+}
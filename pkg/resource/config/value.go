@@ -15,14 +15,20 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
-	"errors"
+	"fmt"
+
+	"github.com/pkg/errors"
 )
 
-// Value is a single config value.
+// Value is a single config value. A value is either a string, which may be secure (encrypted), or an object: a
+// structure of maps and arrays, stored as JSON, whose string leaves may themselves be secure. A secure leaf is
+// represented within the structure as an object with a single "secure" property that holds its ciphertext.
 type Value struct {
 	value  string
 	secure bool
+	object bool
 }
 
 func NewSecureValue(v string) Value {
@@ -33,24 +39,285 @@ func NewValue(v string) Value {
 	return Value{value: v, secure: false}
 }
 
+// NewObjectValue creates a structured value from its JSON representation.
+func NewObjectValue(v string) Value {
+	return Value{value: v, object: true}
+}
+
 // Value fetches the value of this configuration entry, using decrypter to decrypt if necessary.  If the value
 // is a secret and decrypter is nil, or if decryption fails for any reason, a non-nil error is returned.
+//
+// The value of an object is its JSON representation with any secure leaves decrypted. If decrypter is the
+// NopDecrypter, secure leaves are left in their encrypted form.
 func (c Value) Value(decrypter Decrypter) (string, error) {
-	if !c.secure {
+	if !c.secure && !c.object {
 		return c.value, nil
 	}
 	if decrypter == nil {
+		if c.object && !c.ContainsSecrets() {
+			return c.value, nil
+		}
 		return "", errors.New("non-nil decrypter required for secret")
 	}
 
+	if c.object {
+		if decrypter == NopDecrypter {
+			return c.value, nil
+		}
+		obj, err := c.ToObject()
+		if err != nil {
+			return "", err
+		}
+		decrypted, err := mapSecureLeaves(obj, func(ciphertext string) (interface{}, error) {
+			return decrypter.DecryptValue(ciphertext)
+		})
+		if err != nil {
+			return "", err
+		}
+		b, err := json.Marshal(decrypted)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+
 	return decrypter.DecryptValue(c.value)
 }
 
+// SecureValues returns the decrypted values of the secure parts of this configuration entry: either the entry
+// itself, if it is secure, or any secure leaves of an object.
+func (c Value) SecureValues(decrypter Decrypter) ([]string, error) {
+	if c.secure {
+		v, err := c.Value(decrypter)
+		if err != nil {
+			return nil, err
+		}
+		return []string{v}, nil
+	}
+	if !c.object {
+		return nil, nil
+	}
+
+	obj, err := c.ToObject()
+	if err != nil {
+		return nil, err
+	}
+	var values []string
+	_, err = mapSecureLeaves(obj, func(ciphertext string) (interface{}, error) {
+		v, err := decrypter.DecryptValue(ciphertext)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+		return v, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// Copy returns a copy of this value in which every secure part has been decrypted using decrypter and re-encrypted
+// using encrypter.
+func (c Value) Copy(decrypter Decrypter, encrypter Encrypter) (Value, error) {
+	switch {
+	case c.secure:
+		plaintext, err := c.Value(decrypter)
+		if err != nil {
+			return Value{}, err
+		}
+		ciphertext, err := encrypter.EncryptValue(plaintext)
+		if err != nil {
+			return Value{}, err
+		}
+		return NewSecureValue(ciphertext), nil
+	case c.object:
+		obj, err := c.ToObject()
+		if err != nil {
+			return Value{}, err
+		}
+		copied, err := mapSecureLeaves(obj, func(ciphertext string) (interface{}, error) {
+			plaintext, err := decrypter.DecryptValue(ciphertext)
+			if err != nil {
+				return nil, err
+			}
+			ciphertext, err = encrypter.EncryptValue(plaintext)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"secure": ciphertext}, nil
+		})
+		if err != nil {
+			return Value{}, err
+		}
+		return newObjectValueFromObject(copied)
+	default:
+		return c, nil
+	}
+}
+
+// Secure returns true if this value is a secure string. Objects are never secure themselves, though they may have
+// secure leaves; see ContainsSecrets.
 func (c Value) Secure() bool {
 	return c.secure
 }
 
+// Object returns true if this value is a structured object or array rather than a string.
+func (c Value) Object() bool {
+	return c.object
+}
+
+// ContainsSecrets returns true if this value is secure or is an object with secure leaves.
+func (c Value) ContainsSecrets() bool {
+	if c.secure {
+		return true
+	}
+	if !c.object {
+		return false
+	}
+
+	obj, err := c.ToObject()
+	if err != nil {
+		return false
+	}
+	found := false
+	_, err = mapSecureLeaves(obj, func(ciphertext string) (interface{}, error) {
+		found = true
+		return ciphertext, nil
+	})
+	return err == nil && found
+}
+
+// ToObject returns the structure of an object value, or the string for any other value. Numbers within an object are
+// returned as json.Numbers, and secure leaves as maps with a single "secure" key.
+func (c Value) ToObject() (interface{}, error) {
+	if !c.object {
+		return c.value, nil
+	}
+
+	var obj interface{}
+	dec := json.NewDecoder(bytes.NewReader([]byte(c.value)))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, errors.Wrap(err, "malformed object value")
+	}
+	return obj, nil
+}
+
+// newObjectValueFromObject creates an object value from the given structure. If the structure is a plain string, the
+// result is a string value instead.
+func newObjectValueFromObject(obj interface{}) (Value, error) {
+	switch obj := obj.(type) {
+	case string:
+		return NewValue(obj), nil
+	case map[string]interface{}:
+		if ciphertext, ok := secureLeaf(obj); ok {
+			return NewSecureValue(ciphertext), nil
+		}
+	}
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return Value{}, err
+	}
+	return NewObjectValue(string(b)), nil
+}
+
+// secureLeaf returns the ciphertext of the given object if it represents a secure leaf.
+func secureLeaf(obj map[string]interface{}) (string, bool) {
+	if len(obj) != 1 {
+		return "", false
+	}
+	ciphertext, ok := obj["secure"].(string)
+	return ciphertext, ok
+}
+
+// mapSecureLeaves returns a copy of the given structure in which each secure leaf has been replaced with the result of
+// calling f on its ciphertext.
+func mapSecureLeaves(obj interface{}, f func(ciphertext string) (interface{}, error)) (interface{}, error) {
+	switch obj := obj.(type) {
+	case map[string]interface{}:
+		if ciphertext, ok := secureLeaf(obj); ok {
+			return f(ciphertext)
+		}
+		result := make(map[string]interface{}, len(obj))
+		for k, v := range obj {
+			mapped, err := mapSecureLeaves(v, f)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = mapped
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(obj))
+		for i, v := range obj {
+			mapped, err := mapSecureLeaves(v, f)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = mapped
+		}
+		return result, nil
+	default:
+		return obj, nil
+	}
+}
+
+// yamlToJSON converts a structure decoded from YAML, whose maps have interface{} keys, into one that may be encoded
+// as JSON.
+func yamlToJSON(obj interface{}) interface{} {
+	switch obj := obj.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(obj))
+		for k, v := range obj {
+			result[fmt.Sprintf("%v", k)] = yamlToJSON(v)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(obj))
+		for i, v := range obj {
+			result[i] = yamlToJSON(v)
+		}
+		return result
+	default:
+		return obj
+	}
+}
+
+// jsonToYAML converts a structure decoded from JSON into one that may be encoded as YAML, turning json.Numbers into
+// numbers so that they are not quoted.
+func jsonToYAML(obj interface{}) interface{} {
+	switch obj := obj.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(obj))
+		for k, v := range obj {
+			result[k] = jsonToYAML(v)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(obj))
+		for i, v := range obj {
+			result[i] = jsonToYAML(v)
+		}
+		return result
+	case json.Number:
+		if i, err := obj.Int64(); err == nil {
+			return i
+		}
+		if f, err := obj.Float64(); err == nil {
+			return f
+		}
+		return obj.String()
+	default:
+		return obj
+	}
+}
+
 func (c Value) MarshalJSON() ([]byte, error) {
+	if c.object {
+		return []byte(c.value), nil
+	}
 	if !c.secure {
 		return json.Marshal(c.value)
 	}
@@ -62,27 +329,40 @@ func (c Value) MarshalJSON() ([]byte, error) {
 }
 
 func (c *Value) UnmarshalJSON(b []byte) error {
-	var m map[string]string
-	err := json.Unmarshal(b, &m)
-	if err == nil {
-		if len(m) != 1 {
-			return errors.New("malformed secure data")
-		}
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*c = NewValue(s)
+		return nil
+	}
 
-		val, has := m["secure"]
-		if !has {
-			return errors.New("malformed secure data")
+	var obj interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return err
+	}
+	if _, ok := obj.(map[string]interface{}); !ok {
+		if _, ok = obj.([]interface{}); !ok {
+			return errors.New("malformed config value")
 		}
-
-		c.value = val
-		c.secure = true
-		return nil
 	}
 
-	return json.Unmarshal(b, &c.value)
+	v, err := newObjectValueFromObject(obj)
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
 }
 
 func (c Value) MarshalYAML() (interface{}, error) {
+	if c.object {
+		obj, err := c.ToObject()
+		if err != nil {
+			return nil, err
+		}
+		return jsonToYAML(obj), nil
+	}
 	if !c.secure {
 		return c.value, nil
 	}
@@ -94,23 +374,27 @@ func (c Value) MarshalYAML() (interface{}, error) {
 }
 
 func (c *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
-	var m map[string]string
-	err := unmarshal(&m)
-	if err == nil {
-		if len(m) != 1 {
-			return errors.New("malformed secure data")
-		}
+	var s string
+	if err := unmarshal(&s); err == nil {
+		*c = NewValue(s)
+		return nil
+	}
 
-		val, has := m["secure"]
-		if !has {
-			return errors.New("malformed secure data")
+	var obj interface{}
+	if err := unmarshal(&obj); err != nil {
+		return err
+	}
+	obj = yamlToJSON(obj)
+	if _, ok := obj.(map[string]interface{}); !ok {
+		if _, ok = obj.([]interface{}); !ok {
+			return errors.New("malformed config value")
 		}
-
-		c.value = val
-		c.secure = true
-		return nil
 	}
 
-	c.secure = false
-	return unmarshal(&c.value)
+	v, err := newObjectValueFromObject(obj)
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
 }
@@ -16,6 +16,7 @@ package config
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -70,6 +71,64 @@ func TestMarshallSecureValueJSON(t *testing.T) {
 	assert.Equal(t, v, newV)
 }
 
+func TestMarshallObjectValueYAML(t *testing.T) {
+	v := NewObjectValue(`{"hosts":["a","b"],"password":{"secure":"value"},"port":5432}`)
+
+	b, err := yaml.Marshal(v)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hosts:\n- a\n- b\npassword:\n  secure: value\nport: 5432\n"), b)
+
+	newV, err := roundtripValueYAML(v)
+	assert.NoError(t, err)
+	assert.Equal(t, v, newV)
+}
+
+func TestMarshallObjectValueJSON(t *testing.T) {
+	v := NewObjectValue(`[{"name":"a","password":{"secure":"value"}},1.5]`)
+
+	b, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`[{"name":"a","password":{"secure":"value"}},1.5]`), b)
+
+	newV, err := roundtripValueJSON(v)
+	assert.NoError(t, err)
+	assert.Equal(t, v, newV)
+}
+
+func TestObjectValueSecrets(t *testing.T) {
+	v := NewObjectValue(`{"a":{"secure":"one"},"b":[{"secure":"two"},"three"]}`)
+	assert.False(t, v.Secure())
+	assert.True(t, v.Object())
+	assert.True(t, v.ContainsSecrets())
+	assert.False(t, NewObjectValue(`{"a":"one"}`).ContainsSecrets())
+
+	// The NopDecrypter leaves secure leaves encrypted, while others decrypt them in place.
+	raw, err := v.Value(NopDecrypter)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":{"secure":"one"},"b":[{"secure":"two"},"three"]}`, raw)
+	blinded, err := v.Value(NewBlindingDecrypter())
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":"[secret]","b":["[secret]","three"]}`, blinded)
+	_, err = v.Value(nil)
+	assert.Error(t, err)
+
+	secrets, err := v.SecureValues(NewBlindingDecrypter())
+	assert.NoError(t, err)
+	assert.Len(t, secrets, 2)
+
+	// Copying re-encrypts each secure leaf.
+	copied, err := v.Copy(NopDecrypter, upperEncrypter{})
+	assert.NoError(t, err)
+	assert.Equal(t, NewObjectValue(`{"a":{"secure":"ONE"},"b":[{"secure":"TWO"},"three"]}`), copied)
+}
+
+// upperEncrypter is a test encrypter that upper-cases its plaintext.
+type upperEncrypter struct{}
+
+func (upperEncrypter) EncryptValue(plaintext string) (string, error) {
+	return strings.ToUpper(plaintext), nil
+}
+
 func roundtripValueYAML(v Value) (Value, error) {
 	return roundtripValue(v, yaml.Marshal, yaml.Unmarshal)
 }
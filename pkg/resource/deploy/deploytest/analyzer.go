@@ -0,0 +1,58 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploytest
+
+import (
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+type Analyzer struct {
+	AnalyzerName tokens.QName
+
+	AnalyzeF      func(t tokens.Type, props resource.PropertyMap) ([]plugin.AnalyzeFailure, error)
+	AnalyzeStackF func(resources []plugin.AnalyzerResource) ([]plugin.AnalyzeFailure, error)
+}
+
+func (a *Analyzer) Close() error {
+	return nil
+}
+
+func (a *Analyzer) Name() tokens.QName {
+	return a.AnalyzerName
+}
+
+func (a *Analyzer) Analyze(t tokens.Type, props resource.PropertyMap) ([]plugin.AnalyzeFailure, error) {
+	if a.AnalyzeF == nil {
+		return nil, nil
+	}
+	return a.AnalyzeF(t, props)
+}
+
+func (a *Analyzer) AnalyzeStack(resources []plugin.AnalyzerResource) ([]plugin.AnalyzeFailure, error) {
+	if a.AnalyzeStackF == nil {
+		return nil, nil
+	}
+	return a.AnalyzeStackF(resources)
+}
+
+func (a *Analyzer) GetPluginInfo() (workspace.PluginInfo, error) {
+	return workspace.PluginInfo{
+		Name: string(a.AnalyzerName),
+		Kind: workspace.AnalyzerPlugin,
+	}, nil
+}
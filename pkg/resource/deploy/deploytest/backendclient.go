@@ -0,0 +1,39 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploytest
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+)
+
+// BackendClient provides a simple implementation of providers.BackendClient that defers to a function value.
+type BackendClient struct {
+	GetStackOutputsF func(ctx context.Context, name string) (resource.PropertyMap, error)
+}
+
+var _ providers.BackendClient = (*BackendClient)(nil)
+
+// GetStackOutputs returns the outputs (if any) for the named stack or an error if the stack cannot be found.
+func (b *BackendClient) GetStackOutputs(ctx context.Context, name string) (resource.PropertyMap, error) {
+	if b.GetStackOutputsF == nil {
+		return nil, errors.Errorf("unknown stack %q", name)
+	}
+	return b.GetStackOutputsF(ctx, name)
+}
@@ -46,6 +46,7 @@ func NewProviderLoader(pkg tokens.Package, version semver.Version, load LoadProv
 type pluginHost struct {
 	providerLoaders []*ProviderLoader
 	languageRuntime plugin.LanguageRuntime
+	analyzers       []plugin.Analyzer
 	sink            diag.Sink
 	statusSink      diag.Sink
 
@@ -65,6 +66,16 @@ func NewPluginHost(sink, statusSink diag.Sink, languageRuntime plugin.LanguageRu
 	}
 }
 
+// NewPluginHostWithAnalyzers creates a plugin host that serves the given analyzers in addition to the providers
+// produced by the given loaders.
+func NewPluginHostWithAnalyzers(sink, statusSink diag.Sink, languageRuntime plugin.LanguageRuntime,
+	analyzers []plugin.Analyzer, providerLoaders ...*ProviderLoader) plugin.Host {
+
+	host := NewPluginHost(sink, statusSink, languageRuntime, providerLoaders...).(*pluginHost)
+	host.analyzers = analyzers
+	return host
+}
+
 func (host *pluginHost) Provider(pkg tokens.Package, version *semver.Version) (plugin.Provider, error) {
 	var best *ProviderLoader
 	for _, l := range host.providerLoaders {
@@ -124,6 +135,11 @@ func (host *pluginHost) LogStatus(sev diag.Severity, urn resource.URN, msg strin
 	host.statusSink.Logf(sev, diag.StreamMessage(urn, msg, streamID))
 }
 func (host *pluginHost) Analyzer(nm tokens.QName) (plugin.Analyzer, error) {
+	for _, a := range host.analyzers {
+		if a.Name() == nm {
+			return a, nil
+		}
+	}
 	return nil, errors.New("unsupported")
 }
 func (host *pluginHost) CloseProvider(provider plugin.Provider) error {
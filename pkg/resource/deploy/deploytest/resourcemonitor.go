@@ -27,9 +27,30 @@ type ResourceMonitor struct {
 	resmon pulumirpc.ResourceMonitorClient
 }
 
+// ResourceOptions contains the optional settings for a resource registration.
+type ResourceOptions struct {
+	Import        resource.ID    // the ID of an existing resource to import, if any.
+	IgnoreChanges []string       // a list of property paths whose changes should be ignored.
+	Aliases       []resource.URN // additional URNs that this resource may have been known by.
+
+	// CustomTimeouts optionally overrides the timeouts for the resource's create, update, and delete operations.
+	CustomTimeouts *pulumirpc.RegisterResourceRequest_CustomTimeouts
+	// DeleteBeforeReplace is true if the resource must be deleted before its replacement is created.
+	DeleteBeforeReplace bool
+	// RetainOnDelete is true if the resource should be dropped from the state rather than deleted.
+	RetainOnDelete bool
+	// Version is the version of the default provider to use for the resource, if any.
+	Version string
+}
+
 func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom bool, parent resource.URN, protect bool,
-	dependencies []resource.URN, provider string,
-	inputs resource.PropertyMap) (resource.URN, resource.ID, resource.PropertyMap, error) {
+	dependencies []resource.URN, provider string, inputs resource.PropertyMap,
+	options ...ResourceOptions) (resource.URN, resource.ID, resource.PropertyMap, error) {
+
+	var opts ResourceOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
 
 	// marshal inputs
 	ins, err := plugin.MarshalProperties(inputs, plugin.MarshalOptions{KeepUnknowns: true})
@@ -43,16 +64,29 @@ func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom b
 		deps = append(deps, string(d))
 	}
 
+	// marshal aliases
+	var aliases []string
+	for _, a := range opts.Aliases {
+		aliases = append(aliases, string(a))
+	}
+
 	// submit request
 	resp, err := rm.resmon.RegisterResource(context.Background(), &pulumirpc.RegisterResourceRequest{
-		Type:         string(t),
-		Name:         name,
-		Custom:       custom,
-		Parent:       string(parent),
-		Protect:      protect,
-		Dependencies: deps,
-		Provider:     provider,
-		Object:       ins,
+		Type:                string(t),
+		Name:                name,
+		Custom:              custom,
+		Parent:              string(parent),
+		Protect:             protect,
+		Dependencies:        deps,
+		Provider:            provider,
+		Object:              ins,
+		ImportId:            string(opts.Import),
+		IgnoreChanges:       opts.IgnoreChanges,
+		Aliases:             aliases,
+		CustomTimeouts:      opts.CustomTimeouts,
+		DeleteBeforeReplace: opts.DeleteBeforeReplace,
+		RetainOnDelete:      opts.RetainOnDelete,
+		Version:             opts.Version,
 	})
 	if err != nil {
 		return "", "", nil, err
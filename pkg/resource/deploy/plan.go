@@ -16,6 +16,7 @@ package deploy
 
 import (
 	"context"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
@@ -28,6 +29,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/retry"
 )
 
 // Options controls the planning and deployment process.
@@ -37,6 +39,49 @@ type Options struct {
 	Refresh           bool   // whether or not to refresh before executing the plan.
 	RefreshOnly       bool   // whether or not to exit after refreshing.
 	TrustDependencies bool   // whether or not to trust the resource dependency graph.
+
+	// An optional set of URNs to which this plan's operations should be restricted. Resources outside of this set
+	// retain their existing state.
+	Targets []resource.URN
+	// True if resources that depend upon a targeted resource should be targeted as well.
+	TargetDependents bool
+	// An optional set of URNs of existing resources that should be replaced even if their providers do not report
+	// any changes.
+	ReplaceTargets []resource.URN
+	// An optional list of existing resources to import. If this list is non-empty, the plan's source registers these
+	// resources rather than evaluating a program, and the plan's operations are restricted to them.
+	Imports []Import
+	// An optional plan saved by a previous preview. If present, each step generated by this plan must match the
+	// corresponding step of the saved plan, and every step of the saved plan must be generated.
+	SavedPlan *UpdatePlan
+	// An optional plan into which the steps generated by this plan are recorded.
+	RecordPlan *UpdatePlan
+	// The policy for retrying resource operations that fail with a transient error.
+	Retry RetryPolicy
+}
+
+// RetryPolicy controls how resource operations that fail with an error the provider has signalled as retryable (see
+// rpcerror.IsRetryable) are retried. The zero value disables retries.
+type RetryPolicy struct {
+	MaxAttempts int           // the maximum number of attempts for each operation (<=1 for no retries).
+	Delay       time.Duration // the delay before the first retry (0 for the default).
+	Backoff     float64       // the multiplier by which the delay grows after each retry (0 for the default).
+	MaxDelay    time.Duration // the maximum delay between retries (0 for the default).
+}
+
+// acceptor returns a retry acceptor that uses this policy's delays and the given acceptance function.
+func (p RetryPolicy) acceptor(accept retry.Acceptance) retry.Acceptor {
+	acceptor := retry.Acceptor{Accept: accept}
+	if p.Delay > 0 {
+		acceptor.Delay = &p.Delay
+	}
+	if p.Backoff > 0 {
+		acceptor.Backoff = &p.Backoff
+	}
+	if p.MaxDelay > 0 {
+		acceptor.MaxDelay = &p.MaxDelay
+	}
+	return acceptor
 }
 
 // DegreeOfParallelism returns the degree of parallelism that should be used during the
@@ -53,6 +98,7 @@ type Events interface {
 	OnResourceStepPre(step Step) (interface{}, error)
 	OnResourceStepPost(ctx interface{}, step Step, status resource.Status, err error) error
 	OnResourceOutputs(step Step) error
+	OnPolicyViolation(analyzer tokens.QName, failure plugin.AnalyzeFailure)
 }
 
 // PlanPendingOperationsError is an error returned from `NewPlan` if there exist pending operations in the
@@ -161,8 +207,10 @@ func addDefaultProviders(target *Target, source Source, prev *Snapshot) error {
 //
 // Note that a plan uses internal concurrency and parallelism in various ways, so it must be closed if for some reason
 // a plan isn't carried out to its final conclusion.  This will result in cancelation and reclamation of OS resources.
+//
+// The given backend client, if any, is used by builtin resources such as stack references to read other stacks.
 func NewPlan(ctx *plugin.Context, target *Target, prev *Snapshot, source Source, analyzers []tokens.QName,
-	preview bool) (*Plan, error) {
+	preview bool, backendClient providers.BackendClient) (*Plan, error) {
 
 	contract.Assert(ctx != nil)
 	contract.Assert(target != nil)
@@ -207,7 +255,8 @@ func NewPlan(ctx *plugin.Context, target *Target, prev *Snapshot, source Source,
 	// Create a new provider registry. Although we really only need to pass in any providers that were present in the
 	// old resource list, the registry itself will filter out other sorts of resources when processing the prior state,
 	// so we just pass all of the old resources.
-	reg, err := providers.NewRegistry(ctx.Host, oldResources, preview)
+	builtins := providers.NewBuiltinProvider(backendClient)
+	reg, err := providers.NewRegistry(ctx.Host, oldResources, preview, builtins)
 	if err != nil {
 		return nil, err
 	}
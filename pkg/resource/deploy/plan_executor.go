@@ -35,6 +35,9 @@ type planExecutor struct {
 
 	stepGen  *stepGenerator // step generator owned by this plan
 	stepExec *stepExecutor  // step executor owned by this plan
+
+	checker    *planChecker // checks generated steps against a saved plan, if any
+	recordPlan *UpdatePlan  // the plan into which generated steps are recorded, if any
 }
 
 // execError creates an error appropriate for returning from planExecutor.Execute.
@@ -91,6 +94,12 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 	// Set up a step generator for this plan.
 	pe.stepGen = newStepGenerator(pe.plan, opts)
 
+	// If this plan is constrained by a saved plan, check each step that we generate against it.
+	if opts.SavedPlan != nil {
+		pe.checker = newPlanChecker(opts.SavedPlan)
+	}
+	pe.recordPlan = opts.RecordPlan
+
 	// Retire any pending deletes that are currently present in this plan.
 	if err = pe.retirePendingDeletes(callerCtx, opts, preview); err != nil {
 		return err
@@ -147,7 +156,30 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 				}
 
 				if event.Event == nil {
-					deleteSteps := pe.stepGen.GenerateDeletes()
+					// Now that the source has finished, give any analyzers a chance to inspect the stack as a whole
+					// before we begin processing deletes.
+					var deleteSteps []Step
+					res := pe.stepGen.AnalyzeResources()
+					if res == nil {
+						deleteSteps, res = pe.stepGen.GenerateDeletes()
+					}
+					if res == nil {
+						res = pe.checkAndRecordSteps(deleteSteps)
+					}
+					if res == nil && pe.checker != nil {
+						if err := pe.checker.finish(); err != nil {
+							res = result.FromError(err)
+						}
+					}
+					if res != nil {
+						if resErr := res.Error(); resErr != nil {
+							logging.V(4).Infof("planExecutor.Execute(...): error finishing source: %v", resErr)
+							pe.reportError("", resErr)
+						}
+						cancel()
+						return false, result.TODO()
+					}
+
 					deletes := pe.stepGen.ScheduleDeletes(deleteSteps)
 
 					// ScheduleDeletes gives us a list of lists of steps. Each list of steps can safely be executed in
@@ -221,11 +253,28 @@ func (pe *planExecutor) handleSingleEvent(event SourceEvent) *result.Result {
 	if res != nil {
 		return res
 	}
+	if res = pe.checkAndRecordSteps(steps); res != nil {
+		return res
+	}
 
 	pe.stepExec.ExecuteSerial(steps)
 	return nil
 }
 
+// checkAndRecordSteps checks the given steps against the saved plan, if any, and then records them into the plan
+// that is being recorded, if any.
+func (pe *planExecutor) checkAndRecordSteps(steps []Step) *result.Result {
+	if pe.checker != nil {
+		if err := pe.checker.check(steps); err != nil {
+			return result.FromError(err)
+		}
+	}
+	if pe.recordPlan != nil {
+		pe.recordPlan.record(steps)
+	}
+	return nil
+}
+
 // retirePendingDeletes deletes all resources that are pending deletion. Run before the start of a plan, this pass
 // ensures that the engine never sees any resources that are pending deletion from a previous plan.
 //
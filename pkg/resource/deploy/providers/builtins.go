@@ -0,0 +1,211 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// BackendClient provides the builtin provider with access to information about other stacks.
+type BackendClient interface {
+	// GetStackOutputs returns the outputs of the named stack, or an error if the stack cannot be found.
+	GetStackOutputs(ctx context.Context, name string) (resource.PropertyMap, error)
+}
+
+// BuiltinPackage is the package whose resources are implemented by the engine itself rather than by a plugin.
+const BuiltinPackage tokens.Package = "pulumi"
+
+// StackReferenceType is the type of the builtin resource that exposes the outputs of another stack. Its only input is
+// the "name" of the referenced stack, and its "outputs" property holds that stack's root outputs.
+const StackReferenceType tokens.Type = "pulumi:pulumi:StackReference"
+
+// builtinProvider implements the resources of the builtin package.
+type builtinProvider struct {
+	context       context.Context
+	cancel        context.CancelFunc
+	backendClient BackendClient
+}
+
+// NewBuiltinProvider creates the provider for the builtin package. Stack references are resolved using the given
+// backend client; if it is nil, any attempt to read a stack reference fails.
+func NewBuiltinProvider(backendClient BackendClient) plugin.Provider {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &builtinProvider{
+		context:       ctx,
+		cancel:        cancel,
+		backendClient: backendClient,
+	}
+}
+
+func (p *builtinProvider) Close() error {
+	return nil
+}
+
+func (p *builtinProvider) Pkg() tokens.Package {
+	return BuiltinPackage
+}
+
+// CheckConfig validates the configuration for this resource provider.
+func (p *builtinProvider) CheckConfig(olds,
+	news resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+	return news, nil, nil
+}
+
+// DiffConfig checks what impacts a hypothetical change to this provider's configuration will have on the provider.
+func (p *builtinProvider) DiffConfig(olds, news resource.PropertyMap) (plugin.DiffResult, error) {
+	return plugin.DiffResult{Changes: plugin.DiffNone}, nil
+}
+
+func (p *builtinProvider) Configure(props resource.PropertyMap) error {
+	return nil
+}
+
+// Check validates the inputs of a builtin resource.
+func (p *builtinProvider) Check(urn resource.URN, olds, news resource.PropertyMap,
+	allowUnknowns bool) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+	typ := urn.Type()
+	if typ != StackReferenceType {
+		return nil, nil, errors.Errorf("unrecognized resource type '%v'", typ)
+	}
+
+	// Only the name of the referenced stack is an input; any other properties, such as the "outputs" that SDKs
+	// register in order to receive their values, are dropped.
+	name := news["name"]
+	if !name.IsComputed() && !name.IsString() {
+		return nil, []plugin.CheckFailure{{Property: "name", Reason: "property \"name\" must be a string"}}, nil
+	}
+	return resource.PropertyMap{"name": name}, nil, nil
+}
+
+// Diff replaces a stack reference whenever the name of the referenced stack changes. Otherwise, it re-reads the
+// outputs of the referenced stack, and reports an update if they differ from the outputs that were last read.
+func (p *builtinProvider) Diff(urn resource.URN, id resource.ID, olds, news resource.PropertyMap,
+	allowUnknowns bool) (plugin.DiffResult, error) {
+
+	contract.Assert(urn.Type() == StackReferenceType)
+
+	if !olds["name"].DeepEquals(news["name"]) {
+		return plugin.DiffResult{
+			Changes:     plugin.DiffSome,
+			ReplaceKeys: []resource.PropertyKey{"name"},
+		}, nil
+	}
+
+	state, err := p.readStackReference(news)
+	if err != nil {
+		return plugin.DiffResult{}, err
+	}
+	if !olds["outputs"].DeepEquals(state["outputs"]) {
+		return plugin.DiffResult{Changes: plugin.DiffSome}, nil
+	}
+	return plugin.DiffResult{Changes: plugin.DiffNone}, nil
+}
+
+// Create reads the outputs of the referenced stack.
+func (p *builtinProvider) Create(urn resource.URN, inputs resource.PropertyMap,
+	timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+	contract.Assert(urn.Type() == StackReferenceType)
+
+	state, err := p.readStackReference(inputs)
+	if err != nil {
+		return "", nil, resource.StatusUnknown, err
+	}
+
+	id := resource.ID(uuid.NewV4().String())
+	return id, state, resource.StatusOK, nil
+}
+
+// Read re-reads the outputs of the referenced stack, which may have changed since they were last read.
+func (p *builtinProvider) Read(urn resource.URN, id resource.ID,
+	props resource.PropertyMap) (resource.PropertyMap, resource.Status, error) {
+
+	contract.Require(id != "", "id")
+	contract.Assert(urn.Type() == StackReferenceType)
+
+	outputs, err := p.readStackReference(props)
+	if err != nil {
+		return nil, resource.StatusUnknown, err
+	}
+	return outputs, resource.StatusOK, nil
+}
+
+// Update re-reads the outputs of the referenced stack. It is called when Diff has found that they changed.
+func (p *builtinProvider) Update(urn resource.URN, id resource.ID, olds, news resource.PropertyMap,
+	timeout float64) (resource.PropertyMap, resource.Status, error) {
+
+	contract.Assert(urn.Type() == StackReferenceType)
+
+	state, err := p.readStackReference(news)
+	if err != nil {
+		return nil, resource.StatusUnknown, err
+	}
+	return state, resource.StatusOK, nil
+}
+
+// Delete does nothing: a stack reference does not own the stack that it references.
+func (p *builtinProvider) Delete(urn resource.URN, id resource.ID, props resource.PropertyMap,
+	timeout float64) (resource.Status, error) {
+
+	contract.Assert(urn.Type() == StackReferenceType)
+
+	return resource.StatusOK, nil
+}
+
+func (p *builtinProvider) Invoke(tok tokens.ModuleMember,
+	args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+	return nil, nil, errors.Errorf("unrecognized function name: '%v'", tok)
+}
+
+func (p *builtinProvider) GetPluginInfo() (workspace.PluginInfo, error) {
+	// return an error: this should not be called for the builtin provider
+	return workspace.PluginInfo{}, errors.New("the builtin provider does not report plugin info")
+}
+
+func (p *builtinProvider) SignalCancellation() error {
+	p.cancel()
+	return nil
+}
+
+// readStackReference returns the state of a stack reference: its inputs, plus the outputs of the referenced stack.
+func (p *builtinProvider) readStackReference(inputs resource.PropertyMap) (resource.PropertyMap, error) {
+	name, ok := inputs["name"]
+	contract.Assert(ok)
+	contract.Assert(name.IsString())
+
+	if p.backendClient == nil {
+		return nil, errors.New("no backend client is available")
+	}
+
+	outputs, err := p.backendClient.GetStackOutputs(p.context, name.StringValue())
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading outputs of stack '%s'", name.StringValue())
+	}
+
+	return resource.PropertyMap{
+		"name":    name,
+		"outputs": resource.NewObjectProperty(outputs),
+	}, nil
+}
@@ -17,6 +17,7 @@ package providers
 import (
 	"strings"
 
+	"github.com/blang/semver"
 	"github.com/pkg/errors"
 
 	"github.com/pulumi/pulumi/pkg/resource"
@@ -46,6 +47,35 @@ func MakeProviderType(pkg tokens.Package) tokens.Type {
 	return tokens.Type("pulumi:providers:" + pkg)
 }
 
+// DefaultProviderVersionDelimiter separates the version of a versioned default provider from the rest of its name.
+const DefaultProviderVersionDelimiter = "@"
+
+// IsReservedProviderName returns true if the given name is reserved for versioned default providers, i.e. if it begins
+// with "default@". Explicit providers may not use such names, so that they cannot be confused with default providers.
+func IsReservedProviderName(name tokens.QName) bool {
+	return strings.HasPrefix(string(name), "default"+DefaultProviderVersionDelimiter)
+}
+
+// DefaultProviderName returns the name of the default provider for the given requested version of a package. The
+// default provider for the unversioned request is simply named "default"; default providers for specific versions are
+// named "default@<version>".
+func DefaultProviderName(version *semver.Version) tokens.QName {
+	if version == nil {
+		return "default"
+	}
+	return tokens.QName("default" + DefaultProviderVersionDelimiter + version.String())
+}
+
+// IsDefaultProvider returns true if the supplied URN refers to a default provider, i.e. a provider that the engine
+// registers on behalf of resources that do not explicitly reference one.
+func IsDefaultProvider(urn resource.URN) bool {
+	if !IsProviderType(urn.Type()) {
+		return false
+	}
+	name := urn.Name()
+	return name == "default" || IsReservedProviderName(name)
+}
+
 func getProviderPackage(typ tokens.Type) tokens.Package {
 	contract.Require(IsProviderType(typ), "typ")
 	return tokens.Package(typ.Name())
@@ -74,3 +74,18 @@ func TestRoundTripReference(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, str, ref.String())
 }
+
+func TestIsDefaultProvider(t *testing.T) {
+	assert.True(t, IsDefaultProvider(resource.NewURN("test", "test", "", "pulumi:providers:type", "default")))
+	assert.True(t, IsDefaultProvider(resource.NewURN("test", "test", "", "pulumi:providers:type", "default@1.2.3")))
+	assert.False(t, IsDefaultProvider(resource.NewURN("test", "test", "", "pulumi:providers:type", "default_1_2_3")))
+	assert.False(t, IsDefaultProvider(resource.NewURN("test", "test", "", "pulumi:providers:type", "us-west@2")))
+	assert.False(t, IsDefaultProvider(resource.NewURN("test", "test", "", "pulumi:providers:type", "test")))
+	assert.False(t, IsDefaultProvider(resource.NewURN("test", "test", "", "test:index:type", "default")))
+}
+
+func TestIsReservedProviderName(t *testing.T) {
+	assert.True(t, IsReservedProviderName("default@1.2.3"))
+	assert.False(t, IsReservedProviderName("default"))
+	assert.False(t, IsReservedProviderName("us-west@2"))
+}
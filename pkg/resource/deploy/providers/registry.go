@@ -60,9 +60,13 @@ func getProviderVersion(inputs resource.PropertyMap) (*semver.Version, error) {
 //
 // In order to fit neatly in to the existing infrastructure for managing resources using Pulumi, a provider regidstry
 // itself implements the plugin.Provider interface.
+//
+// Providers for the builtin package are not loaded from plugins: each is served by the builtin provider with which the
+// registry was created.
 type Registry struct {
 	host      plugin.Host
 	isPreview bool
+	builtins  plugin.Provider
 	providers map[Reference]plugin.Provider
 	m         sync.RWMutex
 }
@@ -71,11 +75,15 @@ var _ plugin.Provider = (*Registry)(nil)
 
 // NewRegistry creates a new provider registry using the given host and old resources. Each provider present in the old
 // resources will be loaded, configured, and added to the returned registry under its reference. If any provider is not
-// loadable/configurable or has an invalid ID, this function returns an error.
-func NewRegistry(host plugin.Host, prev []*resource.State, isPreview bool) (*Registry, error) {
+// loadable/configurable or has an invalid ID, this function returns an error. Providers for the builtin package are
+// served by the given builtin provider, if any.
+func NewRegistry(host plugin.Host, prev []*resource.State, isPreview bool,
+	builtins plugin.Provider) (*Registry, error) {
+
 	r := &Registry{
 		host:      host,
 		isPreview: isPreview,
+		builtins:  builtins,
 		providers: make(map[Reference]plugin.Provider),
 	}
 
@@ -102,7 +110,7 @@ func NewRegistry(host plugin.Host, prev []*resource.State, isPreview bool) (*Reg
 		if err != nil {
 			return nil, errors.Errorf("could not parse version for provider '%v': %v", urn, err)
 		}
-		provider, err := host.Provider(getProviderPackage(urn.Type()), version)
+		provider, err := r.loadProvider(getProviderPackage(urn.Type()), version)
 		if provider == nil {
 			return nil, errors.Errorf("could not find plugin for provider '%v'", urn)
 		}
@@ -122,6 +130,15 @@ func NewRegistry(host plugin.Host, prev []*resource.State, isPreview bool) (*Reg
 	return r, nil
 }
 
+// loadProvider loads the provider plugin for the given package and version. The builtin package is always served by the
+// registry's builtin provider.
+func (r *Registry) loadProvider(pkg tokens.Package, version *semver.Version) (plugin.Provider, error) {
+	if r.builtins != nil && pkg == r.builtins.Pkg() {
+		return r.builtins, nil
+	}
+	return r.host.Provider(pkg, version)
+}
+
 // GetProvider returns the provider plugin that is currently registered under the given reference, if any.
 func (r *Registry) GetProvider(ref Reference) (plugin.Provider, bool) {
 	r.m.RLock()
@@ -206,7 +223,7 @@ func (r *Registry) Check(urn resource.URN, olds, news resource.PropertyMap,
 	if err != nil {
 		return nil, []plugin.CheckFailure{{Property: "version", Reason: err.Error()}}, nil
 	}
-	provider, err := r.host.Provider(getProviderPackage(urn.Type()), version)
+	provider, err := r.loadProvider(getProviderPackage(urn.Type()), version)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -276,8 +293,8 @@ func (r *Registry) Diff(urn resource.URN, id resource.ID, olds, news resource.Pr
 // registers it under the assigned (URN, ID).
 //
 // The provider must have been loaded by a prior call to Check.
-func (r *Registry) Create(urn resource.URN,
-	news resource.PropertyMap) (resource.ID, resource.PropertyMap, resource.Status, error) {
+func (r *Registry) Create(urn resource.URN, news resource.PropertyMap,
+	timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
 
 	contract.Assert(!r.isPreview)
 
@@ -303,8 +320,8 @@ func (r *Registry) Create(urn resource.URN,
 // reference indicated by the (URN, ID) pair.
 //
 // THe provider must have been loaded by a prior call to Check.
-func (r *Registry) Update(urn resource.URN, id resource.ID, olds,
-	news resource.PropertyMap) (resource.PropertyMap, resource.Status, error) {
+func (r *Registry) Update(urn resource.URN, id resource.ID, olds, news resource.PropertyMap,
+	timeout float64) (resource.PropertyMap, resource.Status, error) {
 
 	contract.Assert(!r.isPreview)
 
@@ -326,7 +343,8 @@ func (r *Registry) Update(urn resource.URN, id resource.ID, olds,
 
 // Delete unregisters and unloads the provider with the given URN and ID. The provider must have been loaded when the
 // registry was created (i.e. it must have been present in the state handed to NewRegistry).
-func (r *Registry) Delete(urn resource.URN, id resource.ID, props resource.PropertyMap) (resource.Status, error) {
+func (r *Registry) Delete(urn resource.URN, id resource.ID, props resource.PropertyMap,
+	timeout float64) (resource.Status, error) {
 	contract.Assert(!r.isPreview)
 
 	ref := mustNewReference(urn, id)
@@ -109,7 +109,7 @@ func (prov *testProvider) Check(urn resource.URN,
 	olds, news resource.PropertyMap, _ bool) (resource.PropertyMap, []plugin.CheckFailure, error) {
 	return nil, nil, errors.New("unsupported")
 }
-func (prov *testProvider) Create(urn resource.URN, props resource.PropertyMap) (resource.ID,
+func (prov *testProvider) Create(urn resource.URN, props resource.PropertyMap, timeout float64) (resource.ID,
 	resource.PropertyMap, resource.Status, error) {
 	return "", nil, resource.StatusOK, errors.New("unsupported")
 }
@@ -121,12 +121,12 @@ func (prov *testProvider) Diff(urn resource.URN, id resource.ID,
 	olds resource.PropertyMap, news resource.PropertyMap, _ bool) (plugin.DiffResult, error) {
 	return plugin.DiffResult{}, errors.New("unsupported")
 }
-func (prov *testProvider) Update(urn resource.URN, id resource.ID,
-	olds resource.PropertyMap, news resource.PropertyMap) (resource.PropertyMap, resource.Status, error) {
+func (prov *testProvider) Update(urn resource.URN, id resource.ID, olds resource.PropertyMap, news resource.PropertyMap,
+	timeout float64) (resource.PropertyMap, resource.Status, error) {
 	return nil, resource.StatusOK, errors.New("unsupported")
 }
 func (prov *testProvider) Delete(urn resource.URN,
-	id resource.ID, props resource.PropertyMap) (resource.Status, error) {
+	id resource.ID, props resource.PropertyMap, timeout float64) (resource.Status, error) {
 	return resource.StatusOK, errors.New("unsupported")
 }
 func (prov *testProvider) Invoke(tok tokens.ModuleMember,
@@ -230,11 +230,11 @@ func newProviderState(pkg, name, id string, delete bool, inputs resource.Propert
 }
 
 func TestNewRegistryNoOldState(t *testing.T) {
-	r, err := NewRegistry(&testPluginHost{}, nil, false)
+	r, err := NewRegistry(&testPluginHost{}, nil, false, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
-	r, err = NewRegistry(&testPluginHost{}, nil, true)
+	r, err = NewRegistry(&testPluginHost{}, nil, true, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 }
@@ -263,7 +263,7 @@ func TestNewRegistryOldState(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, olds, false)
+	r, err := NewRegistry(host, olds, false, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
@@ -297,7 +297,7 @@ func TestNewRegistryOldStateNoProviders(t *testing.T) {
 	}
 	host := newPluginHost(t, []*providerLoader{})
 
-	r, err := NewRegistry(host, olds, false)
+	r, err := NewRegistry(host, olds, false, nil)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 }
@@ -311,7 +311,7 @@ func TestNewRegistryOldStateWrongPackage(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, olds, false)
+	r, err := NewRegistry(host, olds, false, nil)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 }
@@ -327,7 +327,7 @@ func TestNewRegistryOldStateWrongVersion(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, olds, false)
+	r, err := NewRegistry(host, olds, false, nil)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 }
@@ -341,7 +341,7 @@ func TestNewRegistryOldStateNoID(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, olds, false)
+	r, err := NewRegistry(host, olds, false, nil)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 }
@@ -355,7 +355,7 @@ func TestNewRegistryOldStateUnknownID(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, olds, false)
+	r, err := NewRegistry(host, olds, false, nil)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 }
@@ -370,7 +370,7 @@ func TestNewRegistryOldStateDuplicates(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, olds, false)
+	r, err := NewRegistry(host, olds, false, nil)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 }
@@ -388,7 +388,7 @@ func TestCRUD(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, olds, false)
+	r, err := NewRegistry(host, olds, false, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
@@ -423,7 +423,7 @@ func TestCRUD(t *testing.T) {
 		assert.False(t, p.(*testProvider).configured)
 
 		// Create
-		id, outs, status, err := r.Create(urn, inputs)
+		id, outs, status, err := r.Create(urn, inputs, 0)
 		assert.NoError(t, err)
 		assert.NotEqual(t, "", id)
 		assert.NotEqual(t, UnknownID, id)
@@ -468,7 +468,7 @@ func TestCRUD(t *testing.T) {
 		assert.Equal(t, old, p2)
 
 		// Update
-		outs, status, err := r.Update(urn, id, olds, inputs)
+		outs, status, err := r.Update(urn, id, olds, inputs, 0)
 		assert.NoError(t, err)
 		assert.Equal(t, resource.PropertyMap{}, outs)
 		assert.Equal(t, resource.StatusOK, status)
@@ -488,7 +488,7 @@ func TestCRUD(t *testing.T) {
 		assert.True(t, ok)
 
 		// Delete
-		status, err := r.Delete(urn, id, resource.PropertyMap{})
+		status, err := r.Delete(urn, id, resource.PropertyMap{}, 0)
 		assert.NoError(t, err)
 		assert.Equal(t, resource.StatusOK, status)
 
@@ -527,7 +527,7 @@ func TestCRUDPreview(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, olds, true)
+	r, err := NewRegistry(host, olds, true, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
@@ -632,7 +632,7 @@ func TestCRUDPreview(t *testing.T) {
 func TestCRUDNoProviders(t *testing.T) {
 	host := newPluginHost(t, []*providerLoader{})
 
-	r, err := NewRegistry(host, []*resource.State{}, false)
+	r, err := NewRegistry(host, []*resource.State{}, false, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
@@ -653,7 +653,7 @@ func TestCRUDWrongPackage(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, []*resource.State{}, false)
+	r, err := NewRegistry(host, []*resource.State{}, false, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
@@ -674,7 +674,7 @@ func TestCRUDWrongVersion(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, []*resource.State{}, false)
+	r, err := NewRegistry(host, []*resource.State{}, false, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
@@ -695,7 +695,7 @@ func TestCRUDBadVersionNotString(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, []*resource.State{}, false)
+	r, err := NewRegistry(host, []*resource.State{}, false, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
@@ -717,7 +717,7 @@ func TestCRUDBadVersion(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, []*resource.State{}, false)
+	r, err := NewRegistry(host, []*resource.State{}, false, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
@@ -62,6 +62,59 @@ func NewSnapshot(manifest Manifest, resources []*resource.State, ops []resource.
 	}
 }
 
+// NormalizeURNReferences returns a list of resources in which each reference to a resource that has been renamed via an
+// alias refers to the resource's new URN instead. The aliases map from old URNs to new URNs. Resources that refer to
+// none of the old URNs are returned as-is; all others are copied rather than being modified in place, as the original
+// states may still be in use by the engine.
+func NormalizeURNReferences(resources []*resource.State, aliases map[resource.URN]resource.URN) []*resource.State {
+	if len(aliases) == 0 {
+		return resources
+	}
+
+	normalizeURN := func(urn resource.URN) (resource.URN, bool) {
+		if aliased, has := aliases[urn]; has {
+			return aliased, true
+		}
+		return urn, false
+	}
+
+	result := make([]*resource.State, len(resources))
+	for i, state := range resources {
+		parent, changed := normalizeURN(state.Parent)
+
+		var deps []resource.URN
+		if state.Dependencies != nil {
+			deps = make([]resource.URN, len(state.Dependencies))
+			for j, dep := range state.Dependencies {
+				var depChanged bool
+				deps[j], depChanged = normalizeURN(dep)
+				changed = changed || depChanged
+			}
+		}
+
+		provider := state.Provider
+		if provider != "" {
+			if ref, err := providers.ParseReference(provider); err == nil {
+				if urn, provChanged := normalizeURN(ref.URN()); provChanged {
+					if newRef, err := providers.NewReference(urn, ref.ID()); err == nil {
+						provider, changed = newRef.String(), true
+					}
+				}
+			}
+		}
+
+		if !changed {
+			result[i] = state
+			continue
+		}
+
+		normalized := *state
+		normalized.Parent, normalized.Dependencies, normalized.Provider = parent, deps, provider
+		result[i] = &normalized
+	}
+	return result
+}
+
 // VerifyIntegrity checks a snapshot to ensure it is well-formed.  Because of the cost of this operation,
 // integrity verification is only performed on demand, and not automatically during snapshot construction.
 //
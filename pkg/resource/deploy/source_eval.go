@@ -17,6 +17,7 @@ package deploy
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/blang/semver"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
@@ -59,10 +60,32 @@ func NewEvalSource(plugctx *plugin.Context, runinfo *EvalRunInfo,
 	}
 }
 
+// Import describes an existing resource that is to be adopted into a stack without evaluating its program.
+type Import struct {
+	Type tokens.Type  // the type token for the resource.
+	Name tokens.QName // the name of the resource.
+	ID   resource.ID  // the ID of the existing resource.
+}
+
+// NewImportSource returns a planning source that registers the given resources for import rather than evaluating a
+// package. Each imported resource is a top-level resource managed by the default provider for its package.
+func NewImportSource(plugctx *plugin.Context, runinfo *EvalRunInfo,
+	defaultProviderVersions map[tokens.Package]*semver.Version, imports []Import, dryRun bool) Source {
+
+	return &evalSource{
+		plugctx:                 plugctx,
+		runinfo:                 runinfo,
+		defaultProviderVersions: defaultProviderVersions,
+		imports:                 imports,
+		dryRun:                  dryRun,
+	}
+}
+
 type evalSource struct {
 	plugctx                 *plugin.Context                    // the plugin context.
 	runinfo                 *EvalRunInfo                       // the directives to use when running the program.
 	defaultProviderVersions map[tokens.Package]*semver.Version // the default provider versions for this source.
+	imports                 []Import                           // the resources to import in lieu of a program.
 	dryRun                  bool                               // true if this is a dry-run operation only.
 }
 
@@ -169,6 +192,11 @@ func (iter *evalSourceIterator) forkRun(opts Options) {
 	go func() {
 		// Next, launch the language plugin.
 		run := func() error {
+			// If we are importing resources rather than evaluating a program, just register them directly.
+			if iter.src.imports != nil {
+				return iter.registerImports()
+			}
+
 			rt := iter.src.runinfo.Proj.RuntimeInfo.Name()
 			langhost, err := iter.src.plugctx.Host.LanguageRuntime(rt)
 			if err != nil {
@@ -210,12 +238,30 @@ func (iter *evalSourceIterator) forkRun(opts Options) {
 	}()
 }
 
+// registerImports registers each of the source's imports with the resource monitor as though a program had done so.
+func (iter *evalSourceIterator) registerImports() error {
+	for _, imp := range iter.src.imports {
+		_, err := iter.mon.RegisterResource(context.Background(), &pulumirpc.RegisterResourceRequest{
+			Type:     string(imp.Type),
+			Name:     string(imp.Name),
+			Custom:   true,
+			ImportId: string(imp.ID),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // defaultProviders manages the registration of default providers. The default provider for a package is the provider
 // resource that will be used to manage resources that do not explicitly reference a provider. Default providers will
-// only be registered for packages that are used by resources registered by the user's Pulumi program.
+// only be registered for packages that are used by resources registered by the user's Pulumi program. A program may
+// request a specific version of a package's default provider, in which case a separate default provider is registered
+// for each requested version.
 type defaultProviders struct {
 	versions  map[tokens.Package]*semver.Version
-	providers map[tokens.Package]providers.Reference
+	providers map[defaultProviderKey]providers.Reference
 	config    plugin.ConfigSource
 
 	requests chan defaultProviderRequest
@@ -230,13 +276,42 @@ type defaultProviderResponse struct {
 
 type defaultProviderRequest struct {
 	pkg      tokens.Package
+	version  *semver.Version
 	response chan<- defaultProviderResponse
 }
 
+// defaultProviderKey identifies a default provider by its package and requested version, if any.
+type defaultProviderKey struct {
+	pkg     tokens.Package
+	version string
+}
+
+func newDefaultProviderKey(pkg tokens.Package, version *semver.Version) defaultProviderKey {
+	key := defaultProviderKey{pkg: pkg}
+	if version != nil {
+		key.version = version.String()
+	}
+	return key
+}
+
+// parseProviderVersion parses the provider version requested by a resource, read, or invoke. An empty version
+// requests the default version of the provider.
+func parseProviderVersion(version string) (*semver.Version, error) {
+	if version == "" {
+		return nil, nil
+	}
+	sv, err := semver.ParseTolerant(version)
+	if err != nil {
+		return nil, rpcerror.New(codes.InvalidArgument, fmt.Sprintf("could not parse provider version: %v", err))
+	}
+	return &sv, nil
+}
+
 // newRegisterDefaultProviderEvent creates a RegisterResourceEvent and completion channel that can be sent to the
-// engine to register a default provider resource for the indicated package.
+// engine to register a default provider resource for the indicated package and version. If no version is given, the
+// default version for the package, if any, is used.
 func (d *defaultProviders) newRegisterDefaultProviderEvent(
-	pkg tokens.Package) (*registerResourceEvent, <-chan *RegisterResult, error) {
+	pkg tokens.Package, version *semver.Version) (*registerResourceEvent, <-chan *RegisterResult, error) {
 
 	// Attempt to get the config for the package.
 	cfg, err := d.config.GetPackageConfig(pkg)
@@ -249,14 +324,19 @@ func (d *defaultProviders) newRegisterDefaultProviderEvent(
 	for k, v := range cfg {
 		inputs[resource.PropertyKey(k.Name())] = resource.NewStringProperty(v)
 	}
-	if version := d.versions[pkg]; version != nil {
+	name := providers.DefaultProviderName(version)
+	if version == nil {
+		version = d.versions[pkg]
+	}
+	if version != nil {
 		inputs["version"] = resource.NewStringProperty(version.String())
 	}
 
 	// Create the result channel and the event.
 	done := make(chan *RegisterResult)
 	event := &registerResourceEvent{
-		goal: resource.NewGoal(providers.MakeProviderType(pkg), "default", true, inputs, "", false, nil, "", nil),
+		goal: resource.NewGoal(providers.MakeProviderType(pkg), name, true, inputs, "", false, nil, "", nil, "", nil,
+			nil, resource.CustomTimeouts{}, false, false),
 		done: done,
 	}
 	return event, done, nil
@@ -269,15 +349,16 @@ func (d *defaultProviders) newRegisterDefaultProviderEvent(
 //
 // Note that this function must not be called from two goroutines concurrently; it is the responsibility of d.serve()
 // to ensure this.
-func (d *defaultProviders) handleRequest(pkg tokens.Package) (providers.Reference, error) {
-	logging.V(5).Infof("handling default provider request for package %s", pkg)
+func (d *defaultProviders) handleRequest(pkg tokens.Package, version *semver.Version) (providers.Reference, error) {
+	logging.V(5).Infof("handling default provider request for package %s (version %v)", pkg, version)
 
-	ref, ok := d.providers[pkg]
+	key := newDefaultProviderKey(pkg, version)
+	ref, ok := d.providers[key]
 	if ok {
 		return ref, nil
 	}
 
-	event, done, err := d.newRegisterDefaultProviderEvent(pkg)
+	event, done, err := d.newRegisterDefaultProviderEvent(pkg, version)
 	if err != nil {
 		return providers.Reference{}, err
 	}
@@ -306,7 +387,7 @@ func (d *defaultProviders) handleRequest(pkg tokens.Package) (providers.Referenc
 
 	ref, err = providers.NewReference(result.State.URN, id)
 	contract.Assert(err == nil)
-	d.providers[pkg] = ref
+	d.providers[key] = ref
 
 	return ref, nil
 }
@@ -318,7 +399,7 @@ func (d *defaultProviders) serve() {
 		case req := <-d.requests:
 			// Note that we do not need to handle cancellation when sending the response: every message we receive is
 			// guaranteed to have something waiting on the other end of the response channel.
-			ref, err := d.handleRequest(req.pkg)
+			ref, err := d.handleRequest(req.pkg, req.version)
 			req.response <- defaultProviderResponse{ref: ref, err: err}
 		case <-d.cancel:
 			return
@@ -326,13 +407,14 @@ func (d *defaultProviders) serve() {
 	}
 }
 
-// getDefaultProviderRef fetches the provider reference for the default provider for a particular package.
-func (d *defaultProviders) getDefaultProviderRef(pkg tokens.Package) (providers.Reference, error) {
-	contract.Assert(pkg != "pulumi")
+// getDefaultProviderRef fetches the provider reference for the default provider for a particular package and
+// version. A nil version requests the package's default version.
+func (d *defaultProviders) getDefaultProviderRef(
+	pkg tokens.Package, version *semver.Version) (providers.Reference, error) {
 
 	response := make(chan defaultProviderResponse)
 	select {
-	case d.requests <- defaultProviderRequest{pkg: pkg, response: response}:
+	case d.requests <- defaultProviderRequest{pkg: pkg, version: version, response: response}:
 	case <-d.cancel:
 		return providers.Reference{}, context.Canceled
 	}
@@ -364,7 +446,7 @@ func newResourceMonitor(src *evalSource, provs ProviderSource, regChan chan *reg
 	// Create a new default provider manager.
 	d := &defaultProviders{
 		versions:  src.defaultProviderVersions,
-		providers: make(map[tokens.Package]providers.Reference),
+		providers: make(map[defaultProviderKey]providers.Reference),
 		config:    src.runinfo.Target,
 		requests:  make(chan defaultProviderRequest),
 		regChan:   regChan,
@@ -414,8 +496,10 @@ func (rm *resmon) Cancel() error {
 
 // getProviderReference fetches the provider reference for a resource, read, or invoke from the given package with the
 // given unparsed provider reference. If the unparsed provider reference is empty, this function returns a reference
-// to the default provider for the indicated package.
-func (rm *resmon) getProviderReference(pkg tokens.Package, rawProviderRef string) (providers.Reference, error) {
+// to the default provider for the indicated package and version.
+func (rm *resmon) getProviderReference(pkg tokens.Package, rawProviderRef string,
+	version *semver.Version) (providers.Reference, error) {
+
 	if pkg == "pulumi" {
 		return providers.Reference{}, errors.Errorf("cannot reference internal providers")
 	}
@@ -428,7 +512,7 @@ func (rm *resmon) getProviderReference(pkg tokens.Package, rawProviderRef string
 		return ref, nil
 	}
 
-	ref, err := rm.defaultProviders.getDefaultProviderRef(pkg)
+	ref, err := rm.defaultProviders.getDefaultProviderRef(pkg, version)
 	if err != nil {
 		return providers.Reference{}, err
 	}
@@ -437,9 +521,11 @@ func (rm *resmon) getProviderReference(pkg tokens.Package, rawProviderRef string
 
 // getProvider fetches the provider plugin for a resource, read, or invoke from the given package with the given
 // unparsed provider reference. If the unparsed provider reference is empty, this function returns the plugin for the
-// indicated package's default provider.
-func (rm *resmon) getProvider(pkg tokens.Package, rawProviderRef string) (plugin.Provider, error) {
-	providerRef, err := rm.getProviderReference(pkg, rawProviderRef)
+// indicated package's default provider of the given version.
+func (rm *resmon) getProvider(pkg tokens.Package, rawProviderRef string,
+	version *semver.Version) (plugin.Provider, error) {
+
+	providerRef, err := rm.getProviderReference(pkg, rawProviderRef, version)
 	if err != nil {
 		return nil, err
 	}
@@ -455,7 +541,12 @@ func (rm *resmon) Invoke(ctx context.Context, req *pulumirpc.InvokeRequest) (*pu
 	// Fetch the token and load up the resource provider if necessary.
 	tok := tokens.ModuleMember(req.GetTok())
 
-	prov, err := rm.getProvider(tok.Package(), req.GetProvider())
+	version, err := parseProviderVersion(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	prov, err := rm.getProvider(tok.Package(), req.GetProvider(), version)
 	if err != nil {
 		return nil, err
 	}
@@ -500,9 +591,14 @@ func (rm *resmon) ReadResource(ctx context.Context,
 	name := tokens.QName(req.GetName())
 	parent := resource.URN(req.GetParent())
 
+	version, err := parseProviderVersion(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
 	provider := req.GetProvider()
 	if !providers.IsProviderType(t) && provider == "" {
-		ref, provErr := rm.defaultProviders.getDefaultProviderRef(t.Package())
+		ref, provErr := rm.defaultProviders.getDefaultProviderRef(t.Package(), version)
 		if provErr != nil {
 			return nil, provErr
 		}
@@ -519,6 +615,7 @@ func (rm *resmon) ReadResource(ctx context.Context,
 	props, err := plugin.UnmarshalProperties(req.GetProperties(), plugin.MarshalOptions{
 		Label:        label,
 		KeepUnknowns: true,
+		KeepSecrets:  true,
 	})
 	if err != nil {
 		return nil, err
@@ -575,6 +672,10 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 	custom := req.GetCustom()
 	parent := resource.URN(req.GetParent())
 	protect := req.GetProtect()
+	id := resource.ID(req.GetImportId())
+	ignoreChanges := req.GetIgnoreChanges()
+	deleteBeforeReplace := req.GetDeleteBeforeReplace()
+	retainOnDelete := req.GetRetainOnDelete()
 	var t tokens.Type
 
 	// Custom resources must have a three-part type so that we can 1) identify if they are providers and 2) retrieve the
@@ -590,10 +691,36 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 		t = tokens.Type(req.GetType())
 	}
 
+	// Explicit providers may not be named like versioned default providers. Other names, including those that
+	// contain the version delimiter elsewhere, remain valid.
+	if providers.IsProviderType(t) && providers.IsReservedProviderName(name) {
+		return nil, rpcerror.New(codes.InvalidArgument, fmt.Sprintf(
+			"provider name '%s' is reserved: names beginning with 'default%s' are used by default providers",
+			name, providers.DefaultProviderVersionDelimiter))
+	}
+
+	// Only custom resources that are managed by a provider may be imported.
+	if id != "" && (!custom || providers.IsProviderType(t)) {
+		return nil, rpcerror.New(codes.InvalidArgument,
+			fmt.Sprintf("resource '%s' of type '%s' cannot be imported", name, t))
+	}
+
+	// Ensure that any properties whose changes are to be ignored are named by valid property paths.
+	for _, path := range ignoreChanges {
+		if _, err := resource.ParsePropertyPath(path); err != nil {
+			return nil, rpcerror.New(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	version, err := parseProviderVersion(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
 	label := fmt.Sprintf("ResourceMonitor.RegisterResource(%s,%s)", t, name)
 	provider := req.GetProvider()
 	if custom && !providers.IsProviderType(t) && provider == "" {
-		ref, err := rm.defaultProviders.getDefaultProviderRef(t.Package())
+		ref, err := rm.defaultProviders.getDefaultProviderRef(t.Package(), version)
 		if err != nil {
 			return nil, err
 		}
@@ -605,20 +732,46 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 		dependencies = append(dependencies, resource.URN(dependingURN))
 	}
 
-	props, err := plugin.UnmarshalProperties(
-		req.GetObject(), plugin.MarshalOptions{Label: label, KeepUnknowns: true, ComputeAssetHashes: true})
+	var aliases []resource.URN
+	for _, aliasURN := range req.GetAliases() {
+		alias := resource.URN(aliasURN)
+		if !alias.IsValid() {
+			return nil, rpcerror.New(codes.InvalidArgument,
+				fmt.Sprintf("alias '%s' of resource '%s' is not a valid URN", aliasURN, name))
+		}
+		aliases = append(aliases, alias)
+	}
+
+	var timeouts resource.CustomTimeouts
+	if ct := req.GetCustomTimeouts(); ct != nil {
+		var err error
+		if timeouts, err = parseCustomTimeouts(ct); err != nil {
+			return nil, rpcerror.New(codes.InvalidArgument,
+				fmt.Sprintf("invalid custom timeouts for resource '%s': %v", name, err))
+		}
+	}
+
+	props, err := plugin.UnmarshalProperties(req.GetObject(), plugin.MarshalOptions{
+		Label:              label,
+		KeepUnknowns:       true,
+		ComputeAssetHashes: true,
+		KeepSecrets:        true,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	logging.V(5).Infof(
 		"ResourceMonitor.RegisterResource received: t=%v, name=%v, custom=%v, #props=%v, parent=%v, protect=%v, "+
-			"provider=%v, deps=%v",
-		t, name, custom, len(props), parent, protect, provider, dependencies)
+			"provider=%v, deps=%v, import=%v, ignoreChanges=%v, aliases=%v, timeouts=%v, deleteBeforeReplace=%v, "+
+			"retainOnDelete=%v",
+		t, name, custom, len(props), parent, protect, provider, dependencies, id, ignoreChanges, aliases, timeouts,
+		deleteBeforeReplace, retainOnDelete)
 
 	// Send the goal state to the engine.
 	step := &registerResourceEvent{
-		goal: resource.NewGoal(t, name, custom, props, parent, protect, dependencies, provider, nil),
+		goal: resource.NewGoal(t, name, custom, props, parent, protect, dependencies, provider, nil, id,
+			ignoreChanges, aliases, timeouts, deleteBeforeReplace, retainOnDelete),
 		done: make(chan *RegisterResult),
 	}
 
@@ -664,6 +817,37 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 	}, nil
 }
 
+// parseCustomTimeouts converts the duration strings in a resource's custom timeouts into seconds. Empty strings are
+// left as zero, which uses the provider's default timeout.
+func parseCustomTimeouts(ct *pulumirpc.RegisterResourceRequest_CustomTimeouts) (resource.CustomTimeouts, error) {
+	parse := func(op, value string) (float64, error) {
+		if value == "" {
+			return 0, nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, errors.Wrapf(err, "%s timeout", op)
+		}
+		if d < 0 {
+			return 0, errors.Errorf("%s timeout %q must not be negative", op, value)
+		}
+		return d.Seconds(), nil
+	}
+
+	var timeouts resource.CustomTimeouts
+	var err error
+	if timeouts.Create, err = parse("create", ct.GetCreate()); err != nil {
+		return resource.CustomTimeouts{}, err
+	}
+	if timeouts.Update, err = parse("update", ct.GetUpdate()); err != nil {
+		return resource.CustomTimeouts{}, err
+	}
+	if timeouts.Delete, err = parse("delete", ct.GetDelete()); err != nil {
+		return resource.CustomTimeouts{}, err
+	}
+	return timeouts, nil
+}
+
 // RegisterResourceOutputs records some new output properties for a resource that have arrived after its initial
 // provisioning.  These will make their way into the eventual checkpoint state file for that resource.
 func (rm *resmon) RegisterResourceOutputs(ctx context.Context,
@@ -675,8 +859,12 @@ func (rm *resmon) RegisterResourceOutputs(ctx context.Context,
 		return nil, errors.New("missing required URN")
 	}
 	label := fmt.Sprintf("ResourceMonitor.RegisterResourceOutputs(%s)", urn)
-	outs, err := plugin.UnmarshalProperties(
-		req.GetOutputs(), plugin.MarshalOptions{Label: label, KeepUnknowns: true, ComputeAssetHashes: true})
+	outs, err := plugin.UnmarshalProperties(req.GetOutputs(), plugin.MarshalOptions{
+		Label:              label,
+		KeepUnknowns:       true,
+		ComputeAssetHashes: true,
+		KeepSecrets:        true,
+	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "cannot unmarshal output properties")
 	}
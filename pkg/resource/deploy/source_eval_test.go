@@ -61,7 +61,7 @@ func fixedProgram(steps []RegisterResourceEvent) deploytest.ProgramFunc {
 			}
 			s.Done(&RegisterResult{
 				State: resource.NewState(g.Type, urn, g.Custom, false, id, g.Properties, outs, g.Parent, g.Protect,
-					false, g.Dependencies, nil, g.Provider),
+					false, g.Dependencies, nil, g.Provider, g.CustomTimeouts, false, false),
 			})
 		}
 		return nil
@@ -143,16 +143,16 @@ func TestRegisterNoDefaultProviders(t *testing.T) {
 		// Register a component resource.
 		&testRegEvent{
 			goal: resource.NewGoal(componentURN.Type(), componentURN.Name(), false, resource.PropertyMap{}, "", false,
-				nil, "", []string{}),
+				nil, "", []string{}, "", nil, nil, resource.CustomTimeouts{}, false, false),
 		},
 		// Register a couple resources using provider A.
 		&testRegEvent{
 			goal: resource.NewGoal("pkgA:index:typA", "res1", true, resource.PropertyMap{}, componentURN, false, nil,
-				providerARef.String(), []string{}),
+				providerARef.String(), []string{}, "", nil, nil, resource.CustomTimeouts{}, false, false),
 		},
 		&testRegEvent{
 			goal: resource.NewGoal("pkgA:index:typA", "res2", true, resource.PropertyMap{}, componentURN, false, nil,
-				providerARef.String(), []string{}),
+				providerARef.String(), []string{}, "", nil, nil, resource.CustomTimeouts{}, false, false),
 		},
 		// Register two more providers.
 		newProviderEvent("pkgA", "providerB", nil, ""),
@@ -160,11 +160,11 @@ func TestRegisterNoDefaultProviders(t *testing.T) {
 		// Register a few resources that use the new providers.
 		&testRegEvent{
 			goal: resource.NewGoal("pkgB:index:typB", "res3", true, resource.PropertyMap{}, "", false, nil,
-				providerBRef.String(), []string{}),
+				providerBRef.String(), []string{}, "", nil, nil, resource.CustomTimeouts{}, false, false),
 		},
 		&testRegEvent{
 			goal: resource.NewGoal("pkgB:index:typC", "res4", true, resource.PropertyMap{}, "", false, nil,
-				providerCRef.String(), []string{}),
+				providerCRef.String(), []string{}, "", nil, nil, resource.CustomTimeouts{}, false, false),
 		},
 	}
 
@@ -197,7 +197,8 @@ func TestRegisterNoDefaultProviders(t *testing.T) {
 		}
 		reg.Done(&RegisterResult{
 			State: resource.NewState(goal.Type, urn, goal.Custom, false, id, goal.Properties, resource.PropertyMap{},
-				goal.Parent, goal.Protect, false, goal.Dependencies, nil, goal.Provider),
+				goal.Parent, goal.Protect, false, goal.Dependencies, nil, goal.Provider,
+				goal.CustomTimeouts, false, false),
 		})
 
 		processed++
@@ -226,25 +227,25 @@ func TestRegisterDefaultProviders(t *testing.T) {
 		// Register a component resource.
 		&testRegEvent{
 			goal: resource.NewGoal(componentURN.Type(), componentURN.Name(), false, resource.PropertyMap{}, "", false,
-				nil, "", []string{}),
+				nil, "", []string{}, "", nil, nil, resource.CustomTimeouts{}, false, false),
 		},
 		// Register a couple resources from package A.
 		&testRegEvent{
 			goal: resource.NewGoal("pkgA:m:typA", "res1", true, resource.PropertyMap{},
-				componentURN, false, nil, "", []string{}),
+				componentURN, false, nil, "", []string{}, "", nil, nil, resource.CustomTimeouts{}, false, false),
 		},
 		&testRegEvent{
 			goal: resource.NewGoal("pkgA:m:typA", "res2", true, resource.PropertyMap{},
-				componentURN, false, nil, "", []string{}),
+				componentURN, false, nil, "", []string{}, "", nil, nil, resource.CustomTimeouts{}, false, false),
 		},
 		// Register a few resources from other packages.
 		&testRegEvent{
 			goal: resource.NewGoal("pkgB:m:typB", "res3", true, resource.PropertyMap{}, "", false,
-				nil, "", []string{}),
+				nil, "", []string{}, "", nil, nil, resource.CustomTimeouts{}, false, false),
 		},
 		&testRegEvent{
 			goal: resource.NewGoal("pkgB:m:typC", "res4", true, resource.PropertyMap{}, "", false,
-				nil, "", []string{}),
+				nil, "", []string{}, "", nil, nil, resource.CustomTimeouts{}, false, false),
 		},
 	}
 
@@ -288,7 +289,8 @@ func TestRegisterDefaultProviders(t *testing.T) {
 
 		reg.Done(&RegisterResult{
 			State: resource.NewState(goal.Type, urn, goal.Custom, false, id, goal.Properties, resource.PropertyMap{},
-				goal.Parent, goal.Protect, false, goal.Dependencies, nil, goal.Provider),
+				goal.Parent, goal.Protect, false, goal.Dependencies, nil, goal.Provider,
+				goal.CustomTimeouts, false, false),
 		})
 
 		processed++
@@ -297,6 +299,111 @@ func TestRegisterDefaultProviders(t *testing.T) {
 	assert.Equal(t, len(steps)+len(defaults), processed)
 }
 
+func TestRegisterVersionedDefaultProviders(t *testing.T) {
+	runInfo := &EvalRunInfo{
+		Proj:   &workspace.Project{Name: "test"},
+		Target: &Target{Name: "test"},
+	}
+
+	newURN := func(t tokens.Type, name string, parent resource.URN) resource.URN {
+		var pt tokens.Type
+		if parent != "" {
+			pt = parent.Type()
+		}
+		return resource.NewURN(runInfo.Target.Name, runInfo.Proj.Name, pt, t, tokens.QName(name))
+	}
+
+	// Register resources that request the default version and two specific versions of package A's provider.
+	versions := map[string]string{"res1": "", "res2": "1.0.0", "res3": "1.0.0", "res4": "2.0.0"}
+	program := func(_ plugin.RunInfo, resmon *deploytest.ResourceMonitor) error {
+		for _, name := range []string{"res1", "res2", "res3", "res4"} {
+			_, _, _, err := resmon.RegisterResource("pkgA:m:typA", name, true, "", false, nil, "",
+				resource.PropertyMap{}, deploytest.ResourceOptions{Version: versions[name]})
+			assert.NoError(t, err)
+		}
+		return nil
+	}
+
+	// Create and iterate an eval source.
+	ctx, err := newTestPluginContext(program)
+	assert.NoError(t, err)
+
+	iter, err := NewEvalSource(ctx, runInfo, nil, false).Iterate(context.Background(), Options{}, &testProviderSource{})
+	assert.NoError(t, err)
+
+	defaults := make(map[string]resource.PropertyMap)
+	for {
+		event, err := iter.Next()
+		assert.NoError(t, err)
+
+		if event == nil {
+			break
+		}
+
+		reg := event.(RegisterResourceEvent)
+
+		goal := reg.Goal()
+		urn, id := newURN(goal.Type, string(goal.Name), goal.Parent), resource.ID("id")
+
+		if providers.IsProviderType(goal.Type) {
+			assert.True(t, providers.IsDefaultProvider(urn))
+			ref, err := providers.NewReference(urn, id)
+			assert.NoError(t, err)
+			_, ok := defaults[ref.String()]
+			assert.False(t, ok)
+			defaults[ref.String()] = goal.Properties
+		} else {
+			props, ok := defaults[goal.Provider]
+			if assert.True(t, ok) {
+				ref, err := providers.ParseReference(goal.Provider)
+				assert.NoError(t, err)
+				if version := versions[string(goal.Name)]; version == "" {
+					assert.Equal(t, tokens.QName("default"), ref.URN().Name())
+					assert.NotContains(t, props, resource.PropertyKey("version"))
+				} else {
+					assert.Equal(t, tokens.QName("default@"+version), ref.URN().Name())
+					assert.Equal(t, resource.NewStringProperty(version), props["version"])
+				}
+			}
+		}
+
+		reg.Done(&RegisterResult{
+			State: resource.NewState(goal.Type, urn, goal.Custom, false, id, goal.Properties, resource.PropertyMap{},
+				goal.Parent, goal.Protect, false, goal.Dependencies, nil, goal.Provider,
+				goal.CustomTimeouts, false, false),
+		})
+	}
+
+	assert.Len(t, defaults, 3)
+}
+
+func TestRegisterReservedProviderName(t *testing.T) {
+	runInfo := &EvalRunInfo{
+		Proj:   &workspace.Project{Name: "test"},
+		Target: &Target{Name: "test"},
+	}
+
+	// Explicit providers may not be named like versioned default providers.
+	program := func(_ plugin.RunInfo, resmon *deploytest.ResourceMonitor) error {
+		_, _, _, err := resmon.RegisterResource(providers.MakeProviderType("pkgA"), "default@1.0.0", true, "", false,
+			nil, "", resource.PropertyMap{})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "provider name 'default@1.0.0' is reserved")
+		}
+		return nil
+	}
+
+	ctx, err := newTestPluginContext(program)
+	assert.NoError(t, err)
+
+	iter, err := NewEvalSource(ctx, runInfo, nil, false).Iterate(context.Background(), Options{}, &testProviderSource{})
+	assert.NoError(t, err)
+
+	event, err := iter.Next()
+	assert.NoError(t, err)
+	assert.Nil(t, event)
+}
+
 func TestReadInvokeNoDefaultProviders(t *testing.T) {
 	runInfo := &EvalRunInfo{
 		Proj:   &workspace.Project{Name: "test"},
@@ -377,7 +484,8 @@ func TestReadInvokeNoDefaultProviders(t *testing.T) {
 		urn := newURN(read.Type(), string(read.Name()), read.Parent())
 		read.Done(&ReadResult{
 			State: resource.NewState(read.Type(), urn, true, false, read.ID(), read.Properties(),
-				resource.PropertyMap{}, read.Parent(), false, false, read.Dependencies(), nil, read.Provider()),
+				resource.PropertyMap{}, read.Parent(), false, false, read.Dependencies(), nil, read.Provider(),
+				resource.CustomTimeouts{}, false, false),
 		})
 		reads++
 	}
@@ -461,7 +569,8 @@ func TestReadInvokeDefaultProviders(t *testing.T) {
 
 			e.Done(&RegisterResult{
 				State: resource.NewState(goal.Type, urn, goal.Custom, false, id, goal.Properties, resource.PropertyMap{},
-					goal.Parent, goal.Protect, false, goal.Dependencies, nil, goal.Provider),
+					goal.Parent, goal.Protect, false, goal.Dependencies, nil, goal.Provider,
+					goal.CustomTimeouts, false, false),
 			})
 			registers++
 
@@ -469,7 +578,8 @@ func TestReadInvokeDefaultProviders(t *testing.T) {
 			urn := newURN(e.Type(), string(e.Name()), e.Parent())
 			e.Done(&ReadResult{
 				State: resource.NewState(e.Type(), urn, true, false, e.ID(), e.Properties(),
-					resource.PropertyMap{}, e.Parent(), false, false, e.Dependencies(), nil, e.Provider()),
+					resource.PropertyMap{}, e.Parent(), false, false, e.Dependencies(), nil, e.Provider(),
+					resource.CustomTimeouts{}, false, false),
 			})
 			reads++
 		}
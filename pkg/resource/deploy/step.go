@@ -15,6 +15,9 @@
 package deploy
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/pkg/errors"
 
 	"github.com/pulumi/pulumi/pkg/diag/colors"
@@ -82,15 +85,15 @@ func (s *SameStep) Op() StepOp           { return OpSame }
 func (s *SameStep) Plan() *Plan          { return s.plan }
 func (s *SameStep) Type() tokens.Type    { return s.old.Type }
 func (s *SameStep) Provider() string     { return s.old.Provider }
-func (s *SameStep) URN() resource.URN    { return s.old.URN }
+func (s *SameStep) URN() resource.URN    { return s.new.URN }
 func (s *SameStep) Old() *resource.State { return s.old }
 func (s *SameStep) New() *resource.State { return s.new }
 func (s *SameStep) Res() *resource.State { return s.new }
 func (s *SameStep) Logical() bool        { return true }
 
 func (s *SameStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
-	// Retain the URN, ID, and outputs:
-	s.new.URN = s.old.URN
+	// Retain the ID and outputs. Note that the URN may differ from the old URN if the resource was renamed via an
+	// alias.
 	s.new.ID = s.old.ID
 	s.new.Outputs = s.old.Outputs
 	complete := func() { s.reg.Done(&RegisterResult{State: s.new, Stable: true}) }
@@ -106,6 +109,9 @@ type CreateStep struct {
 	keys          []resource.PropertyKey // the keys causing replacement (only for replacements).
 	replacing     bool                   // true if this is a create due to a replacement.
 	pendingDelete bool                   // true if this replacement should create a pending delete.
+
+	// the provider's detailed diff of the resource's properties, if any (only for replacements).
+	detailedDiff map[string]plugin.PropertyDiff
 }
 
 var _ Step = (*CreateStep)(nil)
@@ -125,8 +131,8 @@ func NewCreateStep(plan *Plan, reg RegisterResourceEvent, new *resource.State) S
 	}
 }
 
-func NewCreateReplacementStep(plan *Plan, reg RegisterResourceEvent,
-	old *resource.State, new *resource.State, keys []resource.PropertyKey, pendingDelete bool) Step {
+func NewCreateReplacementStep(plan *Plan, reg RegisterResourceEvent, old *resource.State, new *resource.State,
+	keys []resource.PropertyKey, detailedDiff map[string]plugin.PropertyDiff, pendingDelete bool) Step {
 	contract.Assert(reg != nil)
 	contract.Assert(old != nil)
 	contract.Assert(old.URN != "")
@@ -147,6 +153,7 @@ func NewCreateReplacementStep(plan *Plan, reg RegisterResourceEvent,
 		keys:          keys,
 		replacing:     true,
 		pendingDelete: pendingDelete,
+		detailedDiff:  detailedDiff,
 	}
 }
 
@@ -166,6 +173,8 @@ func (s *CreateStep) Res() *resource.State         { return s.new }
 func (s *CreateStep) Keys() []resource.PropertyKey { return s.keys }
 func (s *CreateStep) Logical() bool                { return !s.replacing }
 
+func (s *CreateStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
+
 func (s *CreateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	var resourceError error
 	resourceStatus := resource.StatusOK
@@ -176,7 +185,7 @@ func (s *CreateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 			if err != nil {
 				return resource.StatusOK, nil, err
 			}
-			id, outs, rst, err := prov.Create(s.URN(), s.new.Inputs)
+			id, outs, rst, err := prov.Create(s.URN(), s.new.Inputs, s.new.CustomTimeouts.Create)
 			if err != nil {
 				if rst != resource.StatusPartialFailure {
 					return rst, nil, err
@@ -210,7 +219,7 @@ func (s *CreateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 	return resourceStatus, complete, resourceError
 }
 
-// DeleteStep is a mutating step that deletes an existing resource. If `old` is marked "External",
+// DeleteStep is a mutating step that deletes an existing resource. If `old` is marked "External" or "RetainOnDelete",
 // DeleteStep is a no-op.
 type DeleteStep struct {
 	plan      *Plan           // the current plan.
@@ -266,15 +275,16 @@ func (s *DeleteStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 			errors.Errorf("refusing to delete protected resource '%s'", s.old.URN)
 	}
 
-	// Deleting an External resource is a no-op, since Pulumi does not own the lifecycle.
-	if !preview && !s.old.External {
+	// Deleting an External resource is a no-op, since Pulumi does not own the lifecycle. Similarly, a resource that is
+	// to be retained on deletion is simply dropped from the state without invoking its provider.
+	if !preview && !s.old.External && !s.old.RetainOnDelete {
 		if s.old.Custom {
 			// Invoke the Delete RPC function for this provider:
 			prov, err := getProvider(s)
 			if err != nil {
 				return resource.StatusOK, nil, err
 			}
-			if rst, err := prov.Delete(s.URN(), s.old.ID, s.old.All()); err != nil {
+			if rst, err := prov.Delete(s.URN(), s.old.ID, s.old.All(), s.old.CustomTimeouts.Delete); err != nil {
 				return rst, nil, err
 			}
 		}
@@ -290,12 +300,15 @@ type UpdateStep struct {
 	old     *resource.State        // the state of the existing resource.
 	new     *resource.State        // the newly computed state of the resource after updating.
 	stables []resource.PropertyKey // an optional list of properties that won't change during this update.
+
+	// the provider's detailed diff of the resource's properties, if any.
+	detailedDiff map[string]plugin.PropertyDiff
 }
 
 var _ Step = (*UpdateStep)(nil)
 
 func NewUpdateStep(plan *Plan, reg RegisterResourceEvent, old *resource.State,
-	new *resource.State, stables []resource.PropertyKey) Step {
+	new *resource.State, stables []resource.PropertyKey, detailedDiff map[string]plugin.PropertyDiff) Step {
 	contract.Assert(old != nil)
 	contract.Assert(old.URN != "")
 	contract.Assert(old.ID != "" || !old.Custom)
@@ -309,11 +322,12 @@ func NewUpdateStep(plan *Plan, reg RegisterResourceEvent, old *resource.State,
 	contract.Assert(!new.External)
 	contract.Assert(!old.External)
 	return &UpdateStep{
-		plan:    plan,
-		reg:     reg,
-		old:     old,
-		new:     new,
-		stables: stables,
+		plan:         plan,
+		reg:          reg,
+		old:          old,
+		new:          new,
+		stables:      stables,
+		detailedDiff: detailedDiff,
 	}
 }
 
@@ -321,15 +335,16 @@ func (s *UpdateStep) Op() StepOp           { return OpUpdate }
 func (s *UpdateStep) Plan() *Plan          { return s.plan }
 func (s *UpdateStep) Type() tokens.Type    { return s.old.Type }
 func (s *UpdateStep) Provider() string     { return s.old.Provider }
-func (s *UpdateStep) URN() resource.URN    { return s.old.URN }
+func (s *UpdateStep) URN() resource.URN    { return s.new.URN }
 func (s *UpdateStep) Old() *resource.State { return s.old }
 func (s *UpdateStep) New() *resource.State { return s.new }
 func (s *UpdateStep) Res() *resource.State { return s.new }
 func (s *UpdateStep) Logical() bool        { return true }
 
+func (s *UpdateStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
+
 func (s *UpdateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
-	// Always propagate the URN and ID, even in previews and refreshes.
-	s.new.URN = s.old.URN
+	// Always propagate the ID, even in previews and refreshes.
 	s.new.ID = s.old.ID
 
 	var resourceError error
@@ -343,7 +358,8 @@ func (s *UpdateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 			}
 
 			// Update to the combination of the old "all" state (including outputs), but overwritten with new inputs.
-			outs, rst, upderr := prov.Update(s.URN(), s.old.ID, s.old.All(), s.new.Inputs)
+			outs, rst, upderr := prov.Update(s.URN(), s.old.ID, s.old.All(), s.new.Inputs,
+				s.new.CustomTimeouts.Update)
 			if upderr != nil {
 				if rst != resource.StatusPartialFailure {
 					return rst, nil, upderr
@@ -379,12 +395,15 @@ type ReplaceStep struct {
 	new           *resource.State        // the new state snapshot.
 	keys          []resource.PropertyKey // the keys causing replacement.
 	pendingDelete bool                   // true if a pending deletion should happen.
+
+	// the provider's detailed diff of the resource's properties, if any.
+	detailedDiff map[string]plugin.PropertyDiff
 }
 
 var _ Step = (*ReplaceStep)(nil)
 
 func NewReplaceStep(plan *Plan, old *resource.State, new *resource.State,
-	keys []resource.PropertyKey, pendingDelete bool) Step {
+	keys []resource.PropertyKey, detailedDiff map[string]plugin.PropertyDiff, pendingDelete bool) Step {
 	contract.Assert(old != nil)
 	contract.Assert(old.URN != "")
 	contract.Assert(old.ID != "" || !old.Custom)
@@ -399,6 +418,7 @@ func NewReplaceStep(plan *Plan, old *resource.State, new *resource.State,
 		new:           new,
 		keys:          keys,
 		pendingDelete: pendingDelete,
+		detailedDiff:  detailedDiff,
 	}
 }
 
@@ -406,13 +426,15 @@ func (s *ReplaceStep) Op() StepOp                   { return OpReplace }
 func (s *ReplaceStep) Plan() *Plan                  { return s.plan }
 func (s *ReplaceStep) Type() tokens.Type            { return s.old.Type }
 func (s *ReplaceStep) Provider() string             { return s.old.Provider }
-func (s *ReplaceStep) URN() resource.URN            { return s.old.URN }
+func (s *ReplaceStep) URN() resource.URN            { return s.new.URN }
 func (s *ReplaceStep) Old() *resource.State         { return s.old }
 func (s *ReplaceStep) New() *resource.State         { return s.new }
 func (s *ReplaceStep) Res() *resource.State         { return s.new }
 func (s *ReplaceStep) Keys() []resource.PropertyKey { return s.keys }
 func (s *ReplaceStep) Logical() bool                { return true }
 
+func (s *ReplaceStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
+
 func (s *ReplaceStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	// If this is a pending delete, we should have marked the old resource for deletion in the CreateReplacement step.
 	contract.Assert(!s.pendingDelete || s.old.Delete)
@@ -612,7 +634,8 @@ func (s *RefreshStep) Apply(preview bool) (resource.Status, StepCompleteFunc, er
 
 	if refreshed != nil {
 		s.new = resource.NewState(s.old.Type, s.old.URN, s.old.Custom, s.old.Delete, s.old.ID, s.old.Inputs, refreshed,
-			s.old.Parent, s.old.Protect, s.old.External, s.old.Dependencies, initErrors, s.old.Provider)
+			s.old.Parent, s.old.Protect, s.old.External, s.old.Dependencies, initErrors, s.old.Provider,
+			s.old.CustomTimeouts, s.old.DeleteBeforeReplace, s.old.RetainOnDelete)
 	} else {
 		s.new = nil
 	}
@@ -620,6 +643,115 @@ func (s *RefreshStep) Apply(preview bool) (resource.Status, StepCompleteFunc, er
 	return rst, complete, err
 }
 
+// ImportStep is a mutating step that adopts an existing resource into the stack. Unlike a resource that is read,
+// an imported resource is fully managed by Pulumi once the import has completed. If the resource's inputs were
+// supplied by a program, they must match the current state of the existing resource or the import fails.
+type ImportStep struct {
+	plan            *Plan                 // the current plan.
+	reg             RegisterResourceEvent // the registration intent to convey a URN back to.
+	old             *resource.State       // the state of the external resource being adopted, if any.
+	new             *resource.State       // the newly computed state of the resource after importing.
+	inputsFromState bool                  // true if the resource's inputs should be derived from its current state.
+}
+
+var _ Step = (*ImportStep)(nil)
+
+// NewImportStep creates a new Import step. If inputsFromState is true, the resource was not described by a program, and
+// its inputs are derived from the state of the existing resource rather than checked against it.
+func NewImportStep(plan *Plan, reg RegisterResourceEvent, old *resource.State, new *resource.State,
+	inputsFromState bool) Step {
+	contract.Assert(reg != nil)
+	contract.Assertf(old == nil || old.External, "old target of Import step must be External")
+	contract.Assert(new != nil)
+	contract.Assert(new.URN != "")
+	contract.Assert(new.ID != "")
+	contract.Assertf(new.Custom, "target of Import step must be Custom")
+	contract.Assert(new.Provider != "")
+	contract.Assert(!new.Delete)
+	contract.Assert(!new.External)
+	return &ImportStep{
+		plan:            plan,
+		reg:             reg,
+		old:             old,
+		new:             new,
+		inputsFromState: inputsFromState,
+	}
+}
+
+func (s *ImportStep) Op() StepOp           { return OpImport }
+func (s *ImportStep) Plan() *Plan          { return s.plan }
+func (s *ImportStep) Type() tokens.Type    { return s.new.Type }
+func (s *ImportStep) Provider() string     { return s.new.Provider }
+func (s *ImportStep) URN() resource.URN    { return s.new.URN }
+func (s *ImportStep) Old() *resource.State { return s.old }
+func (s *ImportStep) New() *resource.State { return s.new }
+func (s *ImportStep) Res() *resource.State { return s.new }
+func (s *ImportStep) Logical() bool        { return true }
+
+func (s *ImportStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
+	complete := func() { s.reg.Done(&RegisterResult{State: s.new}) }
+
+	// Like reads, imports run during previews. The only time we can't run is if the ID we are given is unknown.
+	if s.new.ID == plugin.UnknownStringValue {
+		s.new.Outputs = resource.PropertyMap{}
+		return resource.StatusOK, complete, nil
+	}
+
+	prov, err := getProvider(s)
+	if err != nil {
+		return resource.StatusOK, nil, err
+	}
+
+	// Read the current state of the resource. If the resource does not exist, there is nothing to import.
+	outputs, rst, err := prov.Read(s.new.URN, s.new.ID, s.new.Inputs)
+	if err != nil {
+		return rst, nil, err
+	}
+	if outputs == nil {
+		return resource.StatusOK, nil,
+			errors.Errorf("resource '%v' with ID '%v' does not exist and cannot be imported", s.new.URN, s.new.ID)
+	}
+	s.new.Outputs = outputs
+
+	if s.inputsFromState {
+		// There is no program that describes this resource, so its desired state is derived from its current state.
+		// The provider decides which of the resource's properties are inputs: checking the current state as a set of
+		// new inputs drops or normalizes any properties that are only outputs.
+		inputs, failures, err := prov.Check(s.new.URN, nil, outputs, preview)
+		if err != nil {
+			return resource.StatusOK, nil, err
+		}
+		if len(failures) > 0 {
+			var reasons []string
+			for _, failure := range failures {
+				if failure.Property != "" {
+					reasons = append(reasons, fmt.Sprintf("%v: %v", failure.Property, failure.Reason))
+				} else {
+					reasons = append(reasons, failure.Reason)
+				}
+			}
+			return resource.StatusOK, nil,
+				errors.Errorf("the state of resource '%v' with ID '%v' is not valid input: %v",
+					s.new.URN, s.new.ID, strings.Join(reasons, "; "))
+		}
+		s.new.Inputs = inputs
+	} else {
+		// Otherwise, ensure that the program describes the resource as it currently exists. Importing a resource
+		// must not change it.
+		diff, err := prov.Diff(s.new.URN, s.new.ID, outputs, s.new.Inputs, preview)
+		if err != nil {
+			return resource.StatusOK, nil, err
+		}
+		if diff.Changes == plugin.DiffSome {
+			return resource.StatusOK, nil,
+				errors.Errorf("inputs to import do not match the existing resource '%v' with ID '%v'",
+					s.new.URN, s.new.ID)
+		}
+	}
+
+	return resource.StatusOK, complete, nil
+}
+
 // StepOp represents the kind of operation performed by a step.  It evaluates to its string label.
 type StepOp string
 
@@ -634,6 +766,7 @@ const (
 	OpRead              StepOp = "read"               // reading an existing resource.
 	OpReadReplacement   StepOp = "read-replacement"   // reading an existing resource for a replacement.
 	OpRefresh           StepOp = "refresh"            // refreshing an existing resource.
+	OpImport            StepOp = "import"             // importing an existing resource.
 )
 
 // StepOps contains the full set of step operation types.
@@ -648,6 +781,7 @@ var StepOps = []StepOp{
 	OpRead,
 	OpReadReplacement,
 	OpRefresh,
+	OpImport,
 }
 
 // Color returns a suggested color for lines of this op type.
@@ -673,6 +807,8 @@ func (op StepOp) Color() string {
 		return colors.SpecReplace
 	case OpRefresh:
 		return colors.SpecUpdate
+	case OpImport:
+		return colors.SpecCreate
 	default:
 		contract.Failf("Unrecognized resource step op: '%v'", op)
 		return ""
@@ -707,6 +843,8 @@ func (op StepOp) RawPrefix() string {
 		return ">~"
 	case OpRefresh:
 		return "~ "
+	case OpImport:
+		return "= "
 	default:
 		contract.Failf("Unrecognized resource step op: %v", op)
 		return ""
@@ -721,6 +859,8 @@ func (op StepOp) PastTense() string {
 		return "refreshed"
 	case OpRead:
 		return "read"
+	case OpImport:
+		return "imported"
 	default:
 		contract.Failf("Unexpected resource step op: %v", op)
 		return ""
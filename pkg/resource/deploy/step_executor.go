@@ -19,11 +19,15 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/util/retry"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil/rpcerror"
 )
 
 const (
@@ -266,7 +270,7 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	}
 
 	se.log(workerID, "applying step %v on %v (preview %v)", step.Op(), step.URN(), se.preview)
-	status, stepComplete, err := step.Apply(se.preview)
+	status, stepComplete, err := se.applyStep(workerID, step)
 
 	if err == nil {
 		// If we have a state object, and this is a create or update, remember it, as we may need to update it later.
@@ -302,6 +306,106 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	return nil
 }
 
+// applyStep applies a single step. If the step performs a resource operation that fails with an error that the provider
+// has signalled as retryable, the step is re-applied according to the plan's retry policy. Each retry is reported as a
+// warning so that users can see why the operation is taking longer than expected.
+func (se *stepExecutor) applyStep(workerID int, step Step) (resource.Status, StepCompleteFunc, error) {
+	policy := se.opts.Retry
+	if se.preview || policy.MaxAttempts <= 1 || !isRetryableOp(step.Op()) {
+		return se.applyStepWithTimeout(step)
+	}
+
+	var status resource.Status
+	var stepComplete StepCompleteFunc
+	var err error
+	accept := func(try int, nextRetryTime time.Duration) (bool, interface{}, error) {
+		status, stepComplete, err = se.applyStepWithTimeout(step)
+
+		// Only errors that leave the resource in a known state may be retried: anything else may indicate that the
+		// operation was at least partially performed.
+		attempt := try + 1
+		if err == nil || status != resource.StatusOK || !rpcerror.IsRetryable(err) || attempt >= policy.MaxAttempts {
+			return true, nil, nil
+		}
+
+		se.log(workerID, "step %v on %v failed with a retryable error (attempt %d of %d): %v",
+			step.Op(), step.URN(), attempt, policy.MaxAttempts, err)
+		se.plan.Diag().Warningf(diag.GetResourceOperationRetryWarning(step.URN()),
+			step.Op(), step.URN(), attempt, policy.MaxAttempts, nextRetryTime, err)
+		return false, nil, nil
+	}
+	_, _, retryErr := retry.Until(se.ctx, policy.acceptor(accept))
+	contract.IgnoreError(retryErr)
+
+	return status, stepComplete, err
+}
+
+// applyStepWithTimeout applies a single step. If the step performs a resource operation for which the resource has a
+// custom timeout, the step fails if the operation does not complete in time. The timeout is enforced here rather than
+// by each provider so that it applies equally to plugins and to providers that run within the engine. Because the
+// operation may still be in progress when it times out, the resource's status is unknown.
+func (se *stepExecutor) applyStepWithTimeout(step Step) (resource.Status, StepCompleteFunc, error) {
+	timeout := stepTimeout(step)
+	if se.preview || timeout <= 0 {
+		return step.Apply(se.preview)
+	}
+
+	type applyResult struct {
+		status       resource.Status
+		stepComplete StepCompleteFunc
+		err          error
+	}
+	done := make(chan applyResult, 1)
+	go func() {
+		status, stepComplete, err := step.Apply(se.preview)
+		done <- applyResult{status: status, stepComplete: stepComplete, err: err}
+	}()
+
+	duration := time.Duration(timeout * float64(time.Second))
+	select {
+	case result := <-done:
+		return result.status, result.stepComplete, result.err
+	case <-time.After(duration):
+		return resource.StatusUnknown, nil, StepTimeoutError{Op: step.Op(), URN: step.URN(), Timeout: duration}
+	}
+}
+
+// stepTimeout returns the custom timeout, in seconds, of the resource operation performed by the given step, or zero
+// if the operation has no custom timeout.
+func stepTimeout(step Step) float64 {
+	switch s := step.(type) {
+	case *CreateStep:
+		return s.new.CustomTimeouts.Create
+	case *UpdateStep:
+		return s.new.CustomTimeouts.Update
+	case *DeleteStep:
+		return s.old.CustomTimeouts.Delete
+	default:
+		return 0
+	}
+}
+
+// StepTimeoutError is returned when a resource operation does not complete within the resource's custom timeout.
+type StepTimeoutError struct {
+	Op      StepOp        // the operation that timed out.
+	URN     resource.URN  // the URN of the resource.
+	Timeout time.Duration // the custom timeout of the operation.
+}
+
+func (e StepTimeoutError) Error() string {
+	return fmt.Sprintf("%s of resource %v did not complete within its custom timeout of %v", e.Op, e.URN, e.Timeout)
+}
+
+// isRetryableOp returns true if steps with the given operation may be retried after a transient failure.
+func isRetryableOp(op StepOp) bool {
+	switch op {
+	case OpCreate, OpCreateReplacement, OpUpdate, OpDelete, OpDeleteReplaced, OpRead, OpReadReplacement, OpRefresh:
+		return true
+	default:
+		return false
+	}
+}
+
 // log is a simple logging helper for the step executor.
 func (se *stepExecutor) log(workerID int, msg string, args ...interface{}) {
 	if logging.V(stepExecutorLogLevel) {
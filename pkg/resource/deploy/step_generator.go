@@ -20,6 +20,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/resource/graph"
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/util/result"
@@ -33,19 +34,32 @@ type stepGenerator struct {
 	plan *Plan   // the plan to which this step generator belongs
 	opts Options // options for this step generator
 
-	urns           map[resource.URN]bool    // set of URNs discovered for this plan
-	reads          map[resource.URN]bool    // set of URNs read for this plan
-	deletes        map[resource.URN]bool    // set of URNs deleted in this plan
-	replaces       map[resource.URN]bool    // set of URNs replaced in this plan
-	updates        map[resource.URN]bool    // set of URNs updated in this plan
-	creates        map[resource.URN]bool    // set of URNs created in this plan
-	sames          map[resource.URN]bool    // set of URNs that were not changed in this plan
-	pendingDeletes map[*resource.State]bool // set of resources (not URNs!) that are pending deletion
+	urns           map[resource.URN]bool         // set of URNs discovered for this plan
+	reads          map[resource.URN]bool         // set of URNs read for this plan
+	imports        map[resource.URN]bool         // set of URNs imported in this plan
+	deletes        map[resource.URN]bool         // set of URNs deleted in this plan
+	replaces       map[resource.URN]bool         // set of URNs replaced in this plan
+	updates        map[resource.URN]bool         // set of URNs updated in this plan
+	creates        map[resource.URN]bool         // set of URNs created in this plan
+	sames          map[resource.URN]bool         // set of URNs that were not changed in this plan
+	pendingDeletes map[*resource.State]bool      // set of resources (not URNs!) that are pending deletion
+	targets        map[resource.URN]bool         // set of URNs targeted by this plan, or nil if all URNs are targeted
+	replaceTargets map[resource.URN]bool         // set of URNs that must be replaced regardless of their diffs
+	aliased        map[resource.URN]resource.URN // map from old URNs to the URNs of the new resources that alias them
+	news           []*resource.State             // the new states of the resources in this plan, in registration order
 }
 
 // GenerateReadSteps is responsible for producing one or more steps required to service
 // a ReadResourceEvent coming from the language host.
 func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, *result.Result) {
+	steps, res := sg.generateReadSteps(event)
+	if res == nil {
+		sg.recordNews(steps)
+	}
+	return steps, res
+}
+
+func (sg *stepGenerator) generateReadSteps(event ReadResourceEvent) ([]Step, *result.Result) {
 	urn := sg.plan.generateURN(event.Parent(), event.Type(), event.Name())
 	newState := resource.NewState(event.Type(),
 		urn,
@@ -59,7 +73,10 @@ func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, *re
 		true,  /*external*/
 		event.Dependencies(),
 		nil, /* initErrors */
-		event.Provider())
+		event.Provider(),
+		resource.CustomTimeouts{},
+		false, /*deleteBeforeReplace*/
+		false /*retainOnDelete*/)
 	old, hasOld := sg.plan.Olds()[urn]
 
 	// If the snapshot has an old resource for this URN and it's not external, we're going
@@ -81,7 +98,7 @@ func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, *re
 		sg.replaces[urn] = true
 		return []Step{
 			NewReadReplacementStep(sg.plan, event, old, newState),
-			NewReplaceStep(sg.plan, old, newState, nil, true),
+			NewReplaceStep(sg.plan, old, newState, nil, nil, true),
 		}, nil
 	}
 
@@ -102,6 +119,24 @@ func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, *re
 // and Check on the provider associated with that resource. If those fail, an error
 // is returned.
 func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *result.Result) {
+	steps, res := sg.generateSteps(event)
+	if res == nil {
+		sg.recordNews(steps)
+	}
+	return steps, res
+}
+
+// recordNews records the new resource states produced by the given steps so that they may be analyzed once the plan's
+// source has finished.
+func (sg *stepGenerator) recordNews(steps []Step) {
+	for _, step := range steps {
+		if new := step.New(); new != nil && (len(sg.news) == 0 || sg.news[len(sg.news)-1] != new) {
+			sg.news = append(sg.news, new)
+		}
+	}
+}
+
+func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, *result.Result) {
 	var invalid bool // will be set to true if this object fails validation.
 
 	goal := event.Goal()
@@ -114,8 +149,69 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 	}
 	sg.urns[urn] = true
 
-	// Check for an old resource so that we can figure out if this is a create, delete, etc., and/or to diff.
+	// If another resource has already claimed this URN as an alias, its old state now belongs to that resource.
+	if claimant, has := sg.aliased[urn]; has {
+		invalid = true
+		sg.plan.Diag().Errorf(diag.GetDuplicateResourceAliasError(urn), urn, urn, claimant)
+	}
+
+	// Check for an old resource so that we can figure out if this is a create, delete, etc., and/or to diff. If there
+	// is no such resource, look for one under each of this resource's aliases in turn. A matching resource is treated
+	// as this resource: it will be renamed rather than deleted and re-created.
 	old, hasOld := sg.plan.Olds()[urn]
+	if !hasOld {
+		for _, alias := range goal.Aliases {
+			aliasOld, has := sg.plan.Olds()[alias]
+			if !has || sg.urns[alias] {
+				continue
+			}
+			if claimant, claimed := sg.aliased[alias]; claimed {
+				invalid = true
+				sg.plan.Diag().Errorf(diag.GetDuplicateResourceAliasError(urn), urn, alias, claimant)
+				break
+			}
+
+			logging.V(7).Infof("Planner found existing resource '%v' for aliased resource '%v'", alias, urn)
+			old, hasOld = aliasOld, true
+			sg.aliased[alias] = urn
+			break
+		}
+	}
+
+	// If this is a targeted plan and this resource is not among the targets, leave its existing state untouched.
+	// Providers have no externally-visible effects, so new providers are created regardless in order to serve any
+	// targeted resources that may need them.
+	if !sg.isTargeted(urn, goal) {
+		if invalid {
+			return nil, result.Bail()
+		}
+		if hasOld {
+			logging.V(7).Infof("Planner decided not to update untargeted resource '%v'", urn)
+			sg.sames[urn] = true
+			new := resource.NewState(old.Type, urn, old.Custom, false, "", old.Inputs, nil, sg.aliasedURN(old.Parent),
+				old.Protect, old.External, sg.aliasedURNs(old.Dependencies), old.InitErrors, old.Provider,
+				old.CustomTimeouts, old.DeleteBeforeReplace, old.RetainOnDelete)
+			return []Step{NewSameStep(sg.plan, event, old, new)}, nil
+		}
+		if !providers.IsProviderType(goal.Type) {
+			sg.plan.Diag().Errorf(diag.GetUntargetedCreateError(urn), urn)
+			return nil, result.Bail()
+		}
+	}
+
+	// If this resource is to be imported, ensure that it is not already managed by this stack. A program may continue
+	// to specify the ID of a resource that it has already imported, in which case the resource is treated normally.
+	// If the resource was not described by a program, its inputs will be taken from its existing state.
+	importing := goal.ID != ""
+	if importing && hasOld && !old.External {
+		if len(sg.opts.Imports) != 0 || old.ID != goal.ID {
+			sg.plan.Diag().Errorf(diag.GetImportManagedResourceError(urn), urn, goal.ID)
+			return nil, result.Bail()
+		}
+		importing = false
+	}
+	inputsFromState := importing && len(sg.opts.Imports) != 0
+
 	var oldInputs resource.PropertyMap
 	var oldOutputs resource.PropertyMap
 	if hasOld {
@@ -123,11 +219,23 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 		oldOutputs = old.Outputs
 	}
 
+	// If the goal state asks us to ignore changes to any of its properties, reset those properties to their old
+	// values before checking or diffing anything.
+	goalProps := goal.Properties
+	if hasOld && !old.External && len(goal.IgnoreChanges) > 0 {
+		props, res := processIgnoreChanges(goalProps, oldInputs, goal.IgnoreChanges)
+		if res != nil {
+			return nil, res
+		}
+		goalProps = props
+	}
+
 	// Produce a new state object that we'll build up as operations are performed.  Ultimately, this is what will
 	// get serialized into the checkpoint file.
-	inputs := goal.Properties
+	inputs := goalProps
 	new := resource.NewState(goal.Type, urn, goal.Custom, false, "", inputs, nil, goal.Parent, goal.Protect, false,
-		goal.Dependencies, goal.InitErrors, goal.Provider)
+		goal.Dependencies, goal.InitErrors, goal.Provider, goal.CustomTimeouts, goal.DeleteBeforeReplace,
+		goal.RetainOnDelete)
 
 	// Fetch the provider for this resource type, assuming it isn't just a logical one.
 	var prov plugin.Provider
@@ -162,14 +270,14 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 	wasExternal := hasOld && old.External
 
 	// Ensure the provider is okay with this resource and fetch the inputs to pass to subsequent methods.
-	if prov != nil {
+	if prov != nil && !inputsFromState {
 		var failures []plugin.CheckFailure
 
 		// If we are re-creating this resource because it was deleted earlier, the old inputs are now
 		// invalid (they got deleted) so don't consider them. Similarly, if the old resource was External,
 		// don't consider those inputs since Pulumi does not own them.
 		if recreating || wasExternal {
-			inputs, failures, err = prov.Check(urn, nil, goal.Properties, allowUnknowns)
+			inputs, failures, err = prov.Check(urn, nil, goalProps, allowUnknowns)
 		} else {
 			inputs, failures, err = prov.Check(urn, oldInputs, inputs, allowUnknowns)
 		}
@@ -182,8 +290,13 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 		new.Inputs = inputs
 	}
 
-	// Next, give each analyzer -- if any -- a chance to inspect the resource too.
-	for _, a := range sg.plan.analyzers {
+	// Next, give each analyzer -- if any -- a chance to inspect the resource too. Imports that derive their inputs from
+	// the resource's state have no inputs to inspect until they have been read.
+	analyzers := sg.plan.analyzers
+	if inputsFromState {
+		analyzers = nil
+	}
+	for _, a := range analyzers {
 		var analyzer plugin.Analyzer
 		analyzer, err = sg.plan.ctx.Host.Analyzer(a)
 		if err != nil {
@@ -197,9 +310,10 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 			return nil, result.FromError(err)
 		}
 		for _, failure := range failures {
-			invalid = true
-			sg.plan.Diag().Errorf(
-				diag.GetAnalyzeResourceFailureError(urn), a, urn, failure.Property, failure.Reason)
+			failure.URN = urn
+			if sg.issuePolicyViolation(a, failure) {
+				invalid = true
+			}
 		}
 	}
 
@@ -208,6 +322,15 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 		return nil, result.Bail()
 	}
 
+	// If we are importing this resource, its current state is read from its provider rather than created. If an
+	// external resource with the same URN exists, the import takes ownership of it.
+	if importing {
+		logging.V(7).Infof("Planner decided to import '%v' (id=%v)", urn, goal.ID)
+		sg.imports[urn] = true
+		new.ID = goal.ID
+		return []Step{NewImportStep(sg.plan, event, old, new, inputsFromState)}, nil
+	}
+
 	// There are four cases we need to consider when figuring out what to do with this resource.
 	//
 	// Case 1: recreating
@@ -230,8 +353,8 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 		delete(sg.deletes, urn)
 		sg.replaces[urn] = true
 		return []Step{
-			NewReplaceStep(sg.plan, old, new, nil, false),
-			NewCreateReplacementStep(sg.plan, event, old, new, nil, false),
+			NewReplaceStep(sg.plan, old, new, nil, nil, false),
+			NewCreateReplacementStep(sg.plan, event, old, new, nil, nil, false),
 		}, nil
 	}
 
@@ -251,8 +374,8 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 		}
 
 		return []Step{
-			NewCreateReplacementStep(sg.plan, event, old, new, nil, true),
-			NewReplaceStep(sg.plan, old, new, nil, true),
+			NewCreateReplacementStep(sg.plan, event, old, new, nil, nil, true),
+			NewReplaceStep(sg.plan, old, new, nil, nil, true),
 		}, nil
 	}
 
@@ -286,16 +409,24 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 				"unrecognized diff state for %s: %d", urn, diff.Changes)
 		}
 
+		// If the resource was explicitly marked for replacement, replace it regardless of the provider's diff. Any
+		// request to delete the resource before replacing it is still honored.
+		forceReplace := sg.replaceTargets[urn]
+		if forceReplace {
+			logging.V(7).Infof("Planner was asked to replace '%v'", urn)
+			diff.Changes = plugin.DiffSome
+		}
+
 		// If there were changes, check for a replacement vs. an in-place update.
 		if diff.Changes == plugin.DiffSome {
-			if diff.Replace() {
+			if diff.Replace() || forceReplace {
 				sg.replaces[urn] = true
 
 				// If we are going to perform a replacement, we need to recompute the default values.  The above logic
 				// had assumed that we were going to carry them over from the old resource, which is no longer true.
 				if prov != nil {
 					var failures []plugin.CheckFailure
-					inputs, failures, err = prov.Check(urn, nil, goal.Properties, allowUnknowns)
+					inputs, failures, err = prov.Check(urn, nil, goalProps, allowUnknowns)
 					if err != nil {
 						return nil, result.FromError(err)
 					} else if sg.issueCheckErrors(new, urn, failures) {
@@ -321,9 +452,10 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 				//       until pulumi/pulumi#624 is resolved, we cannot safely perform this operation on resources
 				//       that have dependent resources (we try to delete the resource while they refer to it).
 				//
-				// The provider is responsible for requesting which of these two modes to use.
+				// The provider is responsible for requesting which of these two modes to use, although a program may
+				// also request the DeleteBeforeCreate mode for any resource.
 
-				if diff.DeleteBeforeReplace {
+				if diff.DeleteBeforeReplace || new.DeleteBeforeReplace {
 					logging.V(7).Infof("Planner decided to delete-before-replacement for resource '%v'", urn)
 					contract.Assert(sg.plan.depGraph != nil)
 
@@ -342,6 +474,13 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 						for i := len(dependents) - 1; i >= 0; i-- {
 							dependentResource := dependents[i]
 
+							// Untargeted resources must not be disturbed, so we cannot delete them here.
+							if sg.targets != nil && !sg.targets[dependentResource.URN] {
+								sg.plan.Diag().Errorf(diag.GetUntargetedDependentDeleteError(urn), urn,
+									dependentResource.URN)
+								return nil, result.Bail()
+							}
+
 							// If we already deleted this resource due to some other DBR, don't do it again.
 							if sg.deletes[urn] {
 								continue
@@ -359,14 +498,14 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 
 					return append(steps,
 						NewDeleteReplacementStep(sg.plan, old, false),
-						NewReplaceStep(sg.plan, old, new, diff.ReplaceKeys, false),
-						NewCreateReplacementStep(sg.plan, event, old, new, diff.ReplaceKeys, false),
+						NewReplaceStep(sg.plan, old, new, diff.ReplaceKeys, diff.DetailedDiff, false),
+						NewCreateReplacementStep(sg.plan, event, old, new, diff.ReplaceKeys, diff.DetailedDiff, false),
 					), nil
 				}
 
 				return []Step{
-					NewCreateReplacementStep(sg.plan, event, old, new, diff.ReplaceKeys, true),
-					NewReplaceStep(sg.plan, old, new, diff.ReplaceKeys, true),
+					NewCreateReplacementStep(sg.plan, event, old, new, diff.ReplaceKeys, diff.DetailedDiff, true),
+					NewReplaceStep(sg.plan, old, new, diff.ReplaceKeys, diff.DetailedDiff, true),
 					// note that the delete step is generated "later" on, after all creates/updates finish.
 				}, nil
 			}
@@ -376,14 +515,14 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 			if logging.V(7) {
 				logging.V(7).Infof("Planner decided to update '%v' (oldprops=%v inputs=%v", urn, oldInputs, new.Inputs)
 			}
-			return []Step{NewUpdateStep(sg.plan, event, old, new, diff.StableKeys)}, nil
+			return []Step{NewUpdateStep(sg.plan, event, old, new, diff.StableKeys, diff.DetailedDiff)}, nil
 		}
 
 		// If resource was unchanged, but there were initialization errors, generate an empty update
 		// step to attempt to "continue" awaiting initialization.
 		if len(old.InitErrors) > 0 {
 			sg.updates[urn] = true
-			return []Step{NewUpdateStep(sg.plan, event, old, new, diff.StableKeys)}, nil
+			return []Step{NewUpdateStep(sg.plan, event, old, new, diff.StableKeys, diff.DetailedDiff)}, nil
 		}
 
 		// No need to update anything, the properties didn't change.
@@ -402,7 +541,9 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 	return []Step{NewCreateStep(sg.plan, event, new)}, nil
 }
 
-func (sg *stepGenerator) GenerateDeletes() []Step {
+// GenerateDeletes produces the steps required to delete any old resources that were not seen by this plan. If this is
+// a targeted plan, only targeted resources are deleted.
+func (sg *stepGenerator) GenerateDeletes() ([]Step, *result.Result) {
 	// To compute the deletion list, we must walk the list of old resources *backwards*.  This is because the list is
 	// stored in dependency order, and earlier elements are possibly leaf nodes for later elements.  We must not delete
 	// dependencies prior to their dependent nodes.
@@ -442,7 +583,14 @@ func (sg *stepGenerator) GenerateDeletes() []Step {
 				logging.V(7).Infof("Planner decided to delete '%v' due to replacement", res.URN)
 				sg.deletes[res.URN] = true
 				dels = append(dels, NewDeleteReplacementStep(sg.plan, res, true))
-			} else if !sg.sames[res.URN] && !sg.updates[res.URN] && !sg.replaces[res.URN] && !sg.reads[res.URN] {
+			} else if _, aliased := sg.aliased[res.URN]; !aliased && !sg.sames[res.URN] && !sg.updates[res.URN] &&
+				!sg.replaces[res.URN] && !sg.reads[res.URN] && !sg.imports[res.URN] {
+				// If this is a targeted plan and this resource is not among the targets, leave it alone.
+				if sg.targets != nil && !sg.targets[res.URN] {
+					logging.V(7).Infof("Planner decided not to delete untargeted resource '%v'", res.URN)
+					continue
+				}
+
 				// NOTE: we deliberately do not check sg.deletes here, as it is possible for us to issue multiple
 				// delete steps for the same URN if the old checkpoint contained pending deletes.
 				logging.V(7).Infof("Planner decided to delete '%v'", res.URN)
@@ -451,7 +599,23 @@ func (sg *stepGenerator) GenerateDeletes() []Step {
 			}
 		}
 	}
-	return dels
+
+	// If this is a targeted plan, ensure that we are not about to delete any resources upon which an untargeted
+	// resource depends: the untargeted resource would be left referring to a resource that no longer exists.
+	if sg.targets != nil {
+		invalid := false
+		for _, step := range dels {
+			for _, dependent := range sg.untargetedDependents(step.Old()) {
+				invalid = true
+				sg.plan.Diag().Errorf(diag.GetUntargetedDependentDeleteError(step.URN()), step.URN(), dependent.URN)
+			}
+		}
+		if invalid {
+			return nil, result.Bail()
+		}
+	}
+
+	return dels, nil
 }
 
 // GeneratePendingDeletes generates delete steps for all resources that are pending deletion. This function should be
@@ -571,8 +735,10 @@ func (sg *stepGenerator) diff(urn resource.URN, id resource.ID, oldInputs, oldOu
 	// of the resource to Diff, which includes calculated/output properties that may differ from those present
 	// in the input properties. This can cause unexpected diffs.
 	//
-	// For now, simply apply the legacy diffing behavior before deferring to the provider.
-	if oldInputs.DeepEquals(newInputs) {
+	// For now, simply apply the legacy diffing behavior before deferring to the provider. Resources that must be
+	// replaced regardless are an exception: the provider must still tell us whether to delete before replacing them.
+	// Stack references are another: their outputs may change even though their inputs do not.
+	if oldInputs.DeepEquals(newInputs) && !sg.replaceTargets[urn] && urn.Type() != providers.StackReferenceType {
 		return plugin.DiffResult{Changes: plugin.DiffNone}, nil
 	}
 
@@ -593,6 +759,103 @@ func (sg *stepGenerator) diff(urn resource.URN, id resource.ID, oldInputs, oldOu
 	return diff, nil
 }
 
+// processIgnoreChanges returns a copy of the given new inputs in which each property named by the given list of
+// property paths has been reset to its value in the old inputs. Properties that are absent from the old inputs are
+// removed from the new inputs.
+func processIgnoreChanges(inputs, oldInputs resource.PropertyMap,
+	ignoreChanges []string) (resource.PropertyMap, *result.Result) {
+
+	ignoredInputs := inputs.Copy()
+	var invalidPaths []string
+	for _, p := range ignoreChanges {
+		path, err := resource.ParsePropertyPath(p)
+		if err != nil {
+			invalidPaths = append(invalidPaths, p)
+			continue
+		}
+		reset, ok := path.Reset(oldInputs, ignoredInputs)
+		if !ok {
+			invalidPaths = append(invalidPaths, p)
+			continue
+		}
+		ignoredInputs = reset
+	}
+	if len(invalidPaths) != 0 {
+		return nil, result.Errorf("cannot ignore changes to the following properties because one or more elements of "+
+			"the path are missing: %q", invalidPaths)
+	}
+	return ignoredInputs, nil
+}
+
+// aliasedURN returns the URN of the resource that has claimed the given URN as an alias in this plan, or the given URN
+// if no resource has done so.
+func (sg *stepGenerator) aliasedURN(urn resource.URN) resource.URN {
+	if aliased, has := sg.aliased[urn]; has {
+		return aliased
+	}
+	return urn
+}
+
+// aliasedURNs maps aliasedURN over the given list of URNs.
+func (sg *stepGenerator) aliasedURNs(urns []resource.URN) []resource.URN {
+	if urns == nil {
+		return nil
+	}
+	result := make([]resource.URN, len(urns))
+	for i, urn := range urns {
+		result[i] = sg.aliasedURN(urn)
+	}
+	return result
+}
+
+// isTargeted returns true if the resource with the given URN and goal state should be operated upon by this plan. If
+// dependents of targets are to be included, any resource whose parent, dependencies, or provider are targeted is
+// itself added to the set of targets.
+func (sg *stepGenerator) isTargeted(urn resource.URN, goal *resource.Goal) bool {
+	if sg.targets == nil || sg.targets[urn] {
+		return true
+	}
+	if !sg.opts.TargetDependents {
+		return false
+	}
+
+	deps := append([]resource.URN{goal.Parent}, goal.Dependencies...)
+	if goal.Provider != "" {
+		if ref, err := providers.ParseReference(goal.Provider); err == nil {
+			deps = append(deps, ref.URN())
+		}
+	}
+	for _, dep := range deps {
+		if sg.targets[dep] {
+			sg.targets[urn] = true
+			return true
+		}
+	}
+	return false
+}
+
+// untargetedDependents returns the set of old resources that are not targeted by this plan but that depend upon the
+// given resource, either directly, indirectly, or by parentage.
+func (sg *stepGenerator) untargetedDependents(res *resource.State) []*resource.State {
+	var dependents []*resource.State
+	if sg.plan.depGraph != nil {
+		dependents = sg.plan.depGraph.DependingOn(res)
+	}
+	for _, candidate := range sg.plan.prev.Resources {
+		if candidate.Parent == res.URN {
+			dependents = append(dependents, candidate)
+		}
+	}
+
+	var untargeted []*resource.State
+	for _, dependent := range dependents {
+		if !dependent.Delete && !sg.targets[dependent.URN] && !sg.deletes[dependent.URN] {
+			untargeted = append(untargeted, dependent)
+		}
+	}
+	return untargeted
+}
+
 // issueCheckErrors prints any check errors to the diagnostics sink.
 func (sg *stepGenerator) issueCheckErrors(new *resource.State, urn resource.URN,
 	failures []plugin.CheckFailure) bool {
@@ -612,18 +875,112 @@ func (sg *stepGenerator) issueCheckErrors(new *resource.State, urn resource.URN,
 	return true
 }
 
+// AnalyzeResources gives each analyzer a chance to inspect the full set of resources registered by the plan's source
+// once the source has finished. It returns a result if any analyzer fails or reports a mandatory policy violation.
+func (sg *stepGenerator) AnalyzeResources() *result.Result {
+	// If no resources were registered (e.g. because the stack is being destroyed), there is nothing to analyze.
+	if len(sg.plan.analyzers) == 0 || len(sg.news) == 0 {
+		return nil
+	}
+
+	resources := make([]plugin.AnalyzerResource, len(sg.news))
+	for i, new := range sg.news {
+		resources[i] = plugin.AnalyzerResource{
+			URN:          new.URN,
+			Type:         new.Type,
+			Parent:       new.Parent,
+			Dependencies: new.Dependencies,
+			Inputs:       new.Inputs,
+			Outputs:      new.Outputs,
+		}
+	}
+
+	var invalid bool
+	for _, a := range sg.plan.analyzers {
+		analyzer, err := sg.plan.ctx.Host.Analyzer(a)
+		if err != nil {
+			return result.FromError(err)
+		} else if analyzer == nil {
+			return result.Errorf("analyzer '%v' could not be loaded from your $PATH", a)
+		}
+		failures, err := analyzer.AnalyzeStack(resources)
+		if err != nil {
+			return result.FromError(err)
+		}
+		for _, failure := range failures {
+			if sg.issuePolicyViolation(a, failure) {
+				invalid = true
+			}
+		}
+	}
+	if invalid {
+		return result.Bail()
+	}
+	return nil
+}
+
+// issuePolicyViolation reports a failure returned by an analyzer, and returns true if the failure is mandatory. If the
+// plan has no event listener, the failure is reported to the diagnostics sink instead.
+func (sg *stepGenerator) issuePolicyViolation(analyzer tokens.QName, failure plugin.AnalyzeFailure) bool {
+	mandatory := failure.EnforcementLevel == plugin.Mandatory
+	switch {
+	case sg.opts.Events != nil:
+		sg.opts.Events.OnPolicyViolation(analyzer, failure)
+	case mandatory:
+		sg.plan.Diag().Errorf(diag.GetAnalyzeResourceFailureError(failure.URN),
+			analyzer, failure.URN, failure.Property, failure.Reason)
+	default:
+		sg.plan.Diag().Warningf(diag.GetAnalyzeResourceFailureWarning(failure.URN),
+			analyzer, failure.URN, failure.Property, failure.Reason)
+	}
+	return mandatory
+}
+
 // newStepGenerator creates a new step generator that operates on the given plan.
 func newStepGenerator(plan *Plan, opts Options) *stepGenerator {
+	// If this plan is targeted, compute the set of targets. When dependents are included, any old resource that
+	// depends upon a target is also a target. Import plans only target the resources being imported.
+	var targets map[resource.URN]bool
+	if len(opts.Imports) != 0 {
+		targets = make(map[resource.URN]bool)
+		for _, imp := range opts.Imports {
+			targets[plan.generateURN("", imp.Type, imp.Name)] = true
+		}
+	} else if len(opts.Targets) != 0 {
+		targets = make(map[resource.URN]bool)
+		for _, urn := range opts.Targets {
+			targets[urn] = true
+		}
+		if opts.TargetDependents && plan.depGraph != nil {
+			for _, urn := range opts.Targets {
+				if old, ok := plan.olds[urn]; ok {
+					for _, dependent := range plan.depGraph.DependingOn(old) {
+						targets[dependent.URN] = true
+					}
+				}
+			}
+		}
+	}
+
+	replaceTargets := make(map[resource.URN]bool)
+	for _, urn := range opts.ReplaceTargets {
+		replaceTargets[urn] = true
+	}
+
 	return &stepGenerator{
 		plan:           plan,
 		opts:           opts,
 		urns:           make(map[resource.URN]bool),
 		reads:          make(map[resource.URN]bool),
+		imports:        make(map[resource.URN]bool),
 		creates:        make(map[resource.URN]bool),
 		sames:          make(map[resource.URN]bool),
 		replaces:       make(map[resource.URN]bool),
 		updates:        make(map[resource.URN]bool),
 		deletes:        make(map[resource.URN]bool),
 		pendingDeletes: make(map[*resource.State]bool),
+		targets:        targets,
+		replaceTargets: replaceTargets,
+		aliased:        make(map[resource.URN]resource.URN),
 	}
 }
@@ -0,0 +1,189 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+// PlannedStep records a single step that was generated while previewing an update.
+type PlannedStep struct {
+	Op        StepOp                 // the operation performed by the step.
+	URN       resource.URN           // the URN of the resource affected by the step.
+	OldInputs resource.PropertyMap   // the resource's inputs before the step, if any.
+	NewInputs resource.PropertyMap   // the resource's inputs after the step, if any.
+	Keys      []resource.PropertyKey // the keys causing replacement (only for CreateStep and ReplaceStep).
+}
+
+// UpdatePlan is the list of steps generated by a preview, in the order in which they were generated. A saved plan may
+// be used to constrain a later update so that it performs exactly the operations that were previewed.
+type UpdatePlan struct {
+	Steps []PlannedStep
+}
+
+// NewPlannedStep records the given step.
+func NewPlannedStep(step Step) PlannedStep {
+	planned := PlannedStep{Op: step.Op(), URN: step.URN()}
+	if old := step.Old(); old != nil {
+		planned.OldInputs = old.Inputs
+	}
+	if new := step.New(); new != nil {
+		planned.NewInputs = new.Inputs
+	}
+	switch s := step.(type) {
+	case *CreateStep:
+		planned.Keys = s.Keys()
+	case *ReplaceStep:
+		planned.Keys = s.Keys()
+	}
+	return planned
+}
+
+// record appends the given steps to the plan.
+func (p *UpdatePlan) record(steps []Step) {
+	for _, step := range steps {
+		p.Steps = append(p.Steps, NewPlannedStep(step))
+	}
+}
+
+// PlanViolationError is returned when an update generates a step that diverges from its saved plan.
+type PlanViolationError struct {
+	URN     resource.URN // the URN of the offending resource.
+	Message string       // a description of the divergence.
+}
+
+func (e PlanViolationError) Error() string {
+	return fmt.Sprintf("resource %v violates the saved plan: %s", e.URN, e.Message)
+}
+
+// planChecker ensures that the steps generated by an update match the steps of a saved plan. The steps for each
+// resource must be generated in the same order in which they were planned.
+type planChecker struct {
+	pending map[resource.URN][]PlannedStep // the planned steps that have not yet been generated, by URN.
+}
+
+func newPlanChecker(plan *UpdatePlan) *planChecker {
+	pending := make(map[resource.URN][]PlannedStep)
+	for _, step := range plan.Steps {
+		pending[step.URN] = append(pending[step.URN], step)
+	}
+	return &planChecker{pending: pending}
+}
+
+// check returns an error if any of the given steps diverges from the next planned step for its resource.
+func (c *planChecker) check(steps []Step) error {
+	for _, step := range steps {
+		urn := step.URN()
+		pending := c.pending[urn]
+		if len(pending) == 0 {
+			return PlanViolationError{URN: urn, Message: fmt.Sprintf("no %v step was planned", step.Op())}
+		}
+
+		planned, actual := pending[0], NewPlannedStep(step)
+		if planned.Op != actual.Op {
+			return PlanViolationError{
+				URN:     urn,
+				Message: fmt.Sprintf("a %v step was planned, but a %v step was generated", planned.Op, actual.Op),
+			}
+		}
+		if !inputsMatch(planned.OldInputs, actual.OldInputs) {
+			return PlanViolationError{URN: urn, Message: "its prior inputs have changed since the plan was saved"}
+		}
+		if !inputsMatch(planned.NewInputs, actual.NewInputs) {
+			return PlanViolationError{URN: urn, Message: "its inputs differ from the planned inputs"}
+		}
+		if !keysMatch(planned.Keys, actual.Keys) {
+			return PlanViolationError{URN: urn, Message: "it is being replaced due to changes in different properties"}
+		}
+
+		if len(pending) == 1 {
+			delete(c.pending, urn)
+		} else {
+			c.pending[urn] = pending[1:]
+		}
+	}
+	return nil
+}
+
+// finish returns an error if any planned steps were never generated.
+func (c *planChecker) finish() error {
+	for urn, pending := range c.pending {
+		return PlanViolationError{
+			URN:     urn,
+			Message: fmt.Sprintf("a %v step was planned, but was not generated", pending[0].Op),
+		}
+	}
+	return nil
+}
+
+// inputsMatch returns true if the given planned and actual inputs are equal. Values that were unknown in either set
+// of inputs are considered equal to any value: a preview does not know the outputs of resources that have yet to be
+// created or updated.
+func inputsMatch(planned, actual resource.PropertyMap) bool {
+	return valuesMatch(resource.NewObjectProperty(planned), resource.NewObjectProperty(actual))
+}
+
+func valuesMatch(planned, actual resource.PropertyValue) bool {
+	switch {
+	case planned.IsComputed() || planned.IsOutput() || actual.IsComputed() || actual.IsOutput():
+		return true
+	case planned.IsSecret() && actual.IsSecret():
+		return valuesMatch(planned.SecretValue().Element, actual.SecretValue().Element)
+	case planned.IsArray() && actual.IsArray():
+		p, a := planned.ArrayValue(), actual.ArrayValue()
+		if len(p) != len(a) {
+			return false
+		}
+		for i := range p {
+			if !valuesMatch(p[i], a[i]) {
+				return false
+			}
+		}
+		return true
+	case planned.IsObject() && actual.IsObject():
+		p, a := planned.ObjectValue(), actual.ObjectValue()
+		for k, v := range p {
+			if !valuesMatch(v, a[k]) {
+				return false
+			}
+		}
+		for k, v := range a {
+			if _, has := p[k]; !has && !v.IsNull() {
+				return false
+			}
+		}
+		return true
+	default:
+		return planned.DeepEquals(actual)
+	}
+}
+
+func keysMatch(planned, actual []resource.PropertyKey) bool {
+	if len(planned) != len(actual) {
+		return false
+	}
+	set := make(map[resource.PropertyKey]bool)
+	for _, k := range planned {
+		set[k] = true
+	}
+	for _, k := range actual {
+		if !set[k] {
+			return false
+		}
+	}
+	return true
+}
@@ -15,9 +15,15 @@
 package edit
 
 import (
+	"strings"
+
+	"github.com/pkg/errors"
+
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/resource/graph"
+	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 )
 
@@ -88,3 +94,414 @@ func LocateResource(snap *deploy.Snapshot, urn resource.URN) []*resource.State {
 
 	return resources
 }
+
+// RenameResource gives a resource a new name. Every resource that shares the resource's URN (e.g. an old copy that is
+// pending deletion) is renamed along with it, and all references to its old URN are rewritten to refer to its new URN.
+// A resource's children are unaffected, as their URNs incorporate the type of their parent but not its name.
+func RenameResource(snapshot *deploy.Snapshot, res *resource.State, newName tokens.QName) error {
+	contract.Require(snapshot != nil, "snapshot")
+	contract.Require(res != nil, "state")
+
+	if newName == "" || strings.Contains(string(newName), resource.URNNameDelimiter) {
+		return errors.Errorf("%q is not a valid resource name", newName)
+	}
+
+	urn := res.URN
+	newURN := resource.NewURN(urn.Stack(), urn.Project(), parentType(urn), urn.Type(), newName)
+	if newURN == urn {
+		return nil
+	}
+	if len(LocateResource(snapshot, newURN)) != 0 {
+		return errors.Errorf("a resource named %q already exists (%s)", newName, newURN)
+	}
+
+	return rewriteURNs(snapshot.Resources, map[resource.URN]resource.URN{urn: newURN})
+}
+
+// ReparentResource changes the parent of a resource. Because a resource's URN incorporates the types of its ancestors,
+// the URNs of the resource and of all of its descendants are rewritten, along with all references to them. The
+// snapshot's resources are then reordered as necessary so that each resource still follows its parent.
+func ReparentResource(snapshot *deploy.Snapshot, res *resource.State, newParent *resource.State) error {
+	contract.Require(snapshot != nil, "snapshot")
+	contract.Require(res != nil, "state")
+	contract.Require(newParent != nil, "newParent")
+
+	// A resource cannot become its own ancestor.
+	descendants := locateDescendants(snapshot.Resources, res.URN)
+	if newParent.URN == res.URN || descendants[newParent.URN] {
+		return errors.Errorf("resource %s cannot be parented to itself or to one of its descendants", res.URN)
+	}
+
+	// Compute the new URN of the resource and then of each of its descendants, each of which follows its parent in
+	// the snapshot.
+	renames := map[resource.URN]resource.URN{
+		res.URN: moveURN(res.URN, newParent.URN, res.URN.Stack(), res.URN.Project()),
+	}
+	for _, r := range snapshot.Resources {
+		if descendants[r.URN] {
+			newParentURN, ok := renames[r.Parent]
+			contract.Assertf(ok, "descendant %s precedes its parent %s", r.URN, r.Parent)
+			renames[r.URN] = moveURN(r.URN, newParentURN, r.URN.Stack(), r.URN.Project())
+		}
+	}
+	for old, urn := range renames {
+		if urn != old && len(LocateResource(snapshot, urn)) != 0 {
+			return errors.Errorf("cannot reparent %s: resource %s already exists", res.URN, urn)
+		}
+	}
+
+	// The snapshot may hold several states for the resource (e.g. if an old copy is pending deletion). Each of them is
+	// renamed, so each of them must be reparented as well.
+	var states []*resource.State
+	for _, r := range snapshot.Resources {
+		if r.URN == res.URN {
+			states = append(states, r)
+		}
+	}
+
+	if err := rewriteURNs(snapshot.Resources, renames); err != nil {
+		return err
+	}
+	for _, r := range states {
+		r.Parent = newParent.URN
+	}
+	snapshot.Resources = sortResources(snapshot.Resources)
+	return nil
+}
+
+// MoveResources moves resources, along with all of their descendants, from one snapshot to another, rewriting their
+// URNs to belong to the given stack and project. Resources whose parent is the source's root stack resource are
+// parented to the destination's root stack resource. The providers used by the moved resources are copied to the
+// destination, unless it already has a provider with the same URN, in which case that provider is used instead.
+//
+// Moving a resource must not leave a dangling reference in either snapshot: resources that are moved may only depend
+// upon resources that are also moved, and vice versa.
+func MoveResources(source, dest *deploy.Snapshot, resources []*resource.State,
+	stack tokens.QName, project tokens.PackageName) error {
+
+	contract.Require(source != nil, "source")
+	contract.Require(dest != nil, "dest")
+
+	// Determine the full set of URNs to move.
+	moving := make(map[resource.URN]bool)
+	for _, res := range resources {
+		if providers.IsProviderType(res.Type) {
+			return errors.Errorf("provider %s cannot be moved directly; "+
+				"providers are copied along with the resources that use them", res.URN)
+		}
+		if res.Type == resource.RootStackType {
+			return errors.Errorf("the root stack resource %s cannot be moved", res.URN)
+		}
+		moving[res.URN] = true
+		for urn := range locateDescendants(source.Resources, res.URN) {
+			moving[urn] = true
+		}
+	}
+
+	var destRoot resource.URN
+	for _, res := range dest.Resources {
+		if res.Type == resource.RootStackType && res.Parent == "" {
+			destRoot = res.URN
+		}
+	}
+
+	// Split the source's resources into those that stay and those that move, and ensure that neither set refers to a
+	// resource in the other.
+	var remaining, moved []*resource.State
+	for _, res := range source.Resources {
+		if moving[res.URN] {
+			moved = append(moved, res)
+		} else {
+			remaining = append(remaining, res)
+		}
+	}
+	for _, res := range remaining {
+		if moving[res.Parent] {
+			return errors.Errorf("cannot move %s: it is the parent of %s, which is not being moved",
+				res.Parent, res.URN)
+		}
+		for _, dep := range res.Dependencies {
+			if moving[dep] {
+				return errors.Errorf("cannot move %s: resource %s depends on it", dep, res.URN)
+			}
+		}
+		if res.Provider != "" {
+			ref, err := providers.ParseReference(res.Provider)
+			if err != nil {
+				return errors.Wrapf(err, "parsing provider reference for %s", res.URN)
+			}
+			if moving[ref.URN()] {
+				return errors.Errorf("cannot move %s: resource %s uses it as its provider", ref.URN(), res.URN)
+			}
+		}
+	}
+
+	// Compute the new URNs of the moved resources, each of which follows its parent in the snapshot.
+	renames := make(map[resource.URN]resource.URN)
+	rename := func(res *resource.State) error {
+		if _, has := renames[res.URN]; has {
+			return nil
+		}
+
+		var newParent resource.URN
+		switch {
+		case res.Parent == "":
+		case moving[res.Parent]:
+			newParent = renames[res.Parent]
+		case isRootStack(res.Parent):
+			newParent = destRoot
+		default:
+			return errors.Errorf("cannot move %s: its parent %s is not being moved", res.URN, res.Parent)
+		}
+		for _, dep := range res.Dependencies {
+			if !moving[dep] {
+				return errors.Errorf("cannot move %s: it depends on %s, which is not being moved", res.URN, dep)
+			}
+		}
+
+		renames[res.URN] = moveURN(res.URN, newParent, stack, project)
+		return nil
+	}
+	for _, res := range moved {
+		if err := rename(res); err != nil {
+			return err
+		}
+	}
+
+	// Copy each provider used by a moved resource to the destination, unless it already has an equivalent provider.
+	// Providers are copied rather than moved, as resources that remain in the source may still use them. Providers
+	// that are descended from a moved resource are moved along with it, as they are used only by moved resources.
+	destProviders := make(map[resource.URN]*resource.State)
+	for _, res := range dest.Resources {
+		if providers.IsProviderType(res.Type) && !res.Delete {
+			destProviders[res.URN] = res
+		}
+	}
+	providerRefs := make(map[string]string)
+	var copied []*resource.State
+	for _, res := range moved {
+		if res.Provider == "" || providerRefs[res.Provider] != "" {
+			continue
+		}
+		ref, err := providers.ParseReference(res.Provider)
+		if err != nil {
+			return errors.Wrapf(err, "parsing provider reference for %s", res.URN)
+		}
+		if moving[ref.URN()] {
+			if ref, err = providers.NewReference(renames[ref.URN()], ref.ID()); err != nil {
+				return err
+			}
+			providerRefs[res.Provider] = ref.String()
+			continue
+		}
+		var prov *resource.State
+		for _, candidate := range LocateResource(source, ref.URN()) {
+			if candidate.ID == ref.ID() {
+				prov = candidate
+			}
+		}
+		if prov == nil {
+			return errors.Errorf("resource %s refers to unknown provider %s", res.URN, ref)
+		}
+
+		// If the provider has already been copied on behalf of another resource, or if the destination already has
+		// a provider with the same URN, use that provider.
+		newURN := moveURN(prov.URN, "", stack, project)
+		if isRootStack(prov.Parent) {
+			newURN = moveURN(prov.URN, destRoot, stack, project)
+		}
+		target, has := destProviders[newURN]
+		if !has {
+			if err = rename(prov); err != nil {
+				return err
+			}
+			target = copyState(prov)
+			copied = append(copied, target)
+			destProviders[newURN] = target
+		}
+		newRef, err := providers.NewReference(newURN, target.ID)
+		if err != nil {
+			return err
+		}
+		providerRefs[res.Provider] = newRef.String()
+	}
+
+	for _, res := range moved {
+		for _, existing := range LocateResource(dest, renames[res.URN]) {
+			if !existing.Delete && !res.Delete {
+				return errors.Errorf("cannot move %s: the destination already contains %s", res.URN, existing.URN)
+			}
+		}
+	}
+
+	// Finally, rewrite the moved resources and add them to the destination.
+	movedSet := append(copied, moved...)
+	for _, res := range moved {
+		if newRef, ok := providerRefs[res.Provider]; ok {
+			res.Provider = newRef
+		}
+	}
+	for _, res := range movedSet {
+		if isRootStack(res.Parent) && !moving[res.Parent] {
+			res.Parent = destRoot
+		}
+	}
+	if err := rewriteURNs(movedSet, renames); err != nil {
+		return err
+	}
+
+	source.Resources = remaining
+	dest.Resources = sortResources(append(dest.Resources, movedSet...))
+	return nil
+}
+
+// copyState returns a deep copy of the given resource state, so that the copy may be modified without affecting the
+// original.
+func copyState(state *resource.State) *resource.State {
+	clone := *state
+	if state.Dependencies != nil {
+		clone.Dependencies = append([]resource.URN{}, state.Dependencies...)
+	}
+	if state.InitErrors != nil {
+		clone.InitErrors = append([]string{}, state.InitErrors...)
+	}
+	clone.Inputs = copyPropertyMap(state.Inputs)
+	clone.Outputs = copyPropertyMap(state.Outputs)
+	return &clone
+}
+
+// copyPropertyMap returns a deep copy of the given property map.
+func copyPropertyMap(props resource.PropertyMap) resource.PropertyMap {
+	if props == nil {
+		return nil
+	}
+	clone := make(resource.PropertyMap, len(props))
+	for k, v := range props {
+		clone[k] = copyPropertyValue(v)
+	}
+	return clone
+}
+
+// copyPropertyValue returns a deep copy of the given property value. Assets and archives are immutable, and so are
+// shared with the original.
+func copyPropertyValue(v resource.PropertyValue) resource.PropertyValue {
+	switch {
+	case v.IsArray():
+		elems := make([]resource.PropertyValue, len(v.ArrayValue()))
+		for i, e := range v.ArrayValue() {
+			elems[i] = copyPropertyValue(e)
+		}
+		return resource.NewArrayProperty(elems)
+	case v.IsObject():
+		return resource.NewObjectProperty(copyPropertyMap(v.ObjectValue()))
+	case v.IsSecret():
+		return resource.MakeSecret(copyPropertyValue(v.SecretValue().Element))
+	default:
+		return v
+	}
+}
+
+// parentType returns the qualified type of the parent that is encoded in the given URN, if any.
+func parentType(urn resource.URN) tokens.Type {
+	qualified := string(urn.QualifiedType())
+	if i := strings.LastIndex(qualified, resource.URNTypeDelimiter); i != -1 {
+		return tokens.Type(qualified[:i])
+	}
+	return ""
+}
+
+// moveURN returns the URN that the resource with the given URN would have if it were a child of the given parent in
+// the given stack and project.
+func moveURN(urn resource.URN, parent resource.URN, stack tokens.QName, project tokens.PackageName) resource.URN {
+	var parentType tokens.Type
+	if parent != "" && !isRootStack(parent) {
+		parentType = parent.QualifiedType()
+	}
+	return resource.NewURN(stack, project, parentType, urn.Type(), urn.Name())
+}
+
+// isRootStack returns true if the given URN refers to a root stack resource.
+func isRootStack(urn resource.URN) bool {
+	return urn != "" && urn.Type() == resource.RootStackType
+}
+
+// locateDescendants returns the set of URNs of the resources that are descended from the resource with the given URN.
+func locateDescendants(resources []*resource.State, urn resource.URN) map[resource.URN]bool {
+	descendants := make(map[resource.URN]bool)
+	for _, res := range resources {
+		if res.Parent == urn || descendants[res.Parent] {
+			descendants[res.URN] = true
+		}
+	}
+	return descendants
+}
+
+// rewriteURNs replaces each URN in the given map wherever it appears in the given resources: in their URNs and in their
+// references to their parents, dependencies, and providers.
+func rewriteURNs(resources []*resource.State, renames map[resource.URN]resource.URN) error {
+	rename := func(urn resource.URN) resource.URN {
+		if newURN, ok := renames[urn]; ok {
+			return newURN
+		}
+		return urn
+	}
+
+	for _, res := range resources {
+		res.URN = rename(res.URN)
+		res.Parent = rename(res.Parent)
+		for i, dep := range res.Dependencies {
+			res.Dependencies[i] = rename(dep)
+		}
+		if res.Provider != "" {
+			ref, err := providers.ParseReference(res.Provider)
+			if err != nil {
+				return errors.Wrapf(err, "parsing provider reference for %s", res.URN)
+			}
+			if newURN, ok := renames[ref.URN()]; ok {
+				newRef, err := providers.NewReference(newURN, ref.ID())
+				if err != nil {
+					return err
+				}
+				res.Provider = newRef.String()
+			}
+		}
+	}
+	return nil
+}
+
+// sortResources reorders the given resources such that each follows its parent, its provider, and its dependencies,
+// while otherwise preserving their relative order.
+func sortResources(resources []*resource.State) []*resource.State {
+	byURN := make(map[resource.URN][]*resource.State)
+	for _, res := range resources {
+		byURN[res.URN] = append(byURN[res.URN], res)
+	}
+
+	sorted := make([]*resource.State, 0, len(resources))
+	visited := make(map[*resource.State]bool)
+	var visit func(res *resource.State)
+	visitURN := func(urn resource.URN) {
+		for _, res := range byURN[urn] {
+			visit(res)
+		}
+	}
+	visit = func(res *resource.State) {
+		if visited[res] {
+			return
+		}
+		visited[res] = true
+
+		visitURN(res.Parent)
+		if ref, err := providers.ParseReference(res.Provider); err == nil {
+			visitURN(ref.URN())
+		}
+		for _, dep := range res.Dependencies {
+			visitURN(dep)
+		}
+		sorted = append(sorted, res)
+	}
+	for _, res := range resources {
+		visit(res)
+	}
+	return sorted
+}
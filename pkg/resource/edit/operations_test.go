@@ -48,6 +48,15 @@ func NewResource(name string, provider *resource.State, deps ...resource.URN) *r
 	}
 }
 
+func NewChildResource(name string, parent *resource.State, provider *resource.State,
+	deps ...resource.URN) *resource.State {
+
+	res := NewResource(name, provider, deps...)
+	res.URN = resource.NewURN("test", "test", parent.URN.QualifiedType(), res.Type, tokens.QName(name))
+	res.Parent = parent.URN
+	return res
+}
+
 func NewProviderResource(pkg, name, id string, deps ...resource.URN) *resource.State {
 	t := providers.MakeProviderType(tokens.Package(pkg))
 	return &resource.State{
@@ -258,3 +267,330 @@ func TestLocateResourceExact(t *testing.T) {
 	assert.Len(t, resList, 1)
 	assert.Contains(t, resList, a)
 }
+
+func TestRenameResource(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	child := NewChildResource("child", a, pA, a.URN)
+	b := NewResource("b", pA, a.URN)
+	snap := NewSnapshot([]*resource.State{
+		pA,
+		a,
+		child,
+		b,
+	})
+
+	err := RenameResource(snap, a, "renamed")
+	assert.NoError(t, err)
+	assert.Equal(t, resource.NewURN("test", "test", "", "a:b:c", "renamed"), a.URN)
+	assert.Equal(t, a.URN, child.Parent)
+	assert.Equal(t, []resource.URN{a.URN}, child.Dependencies)
+	assert.Equal(t, resource.NewURN("test", "test", "a:b:c", "a:b:c", "child"), child.URN)
+	assert.Equal(t, []resource.URN{a.URN}, b.Dependencies)
+	assert.NoError(t, snap.VerifyIntegrity())
+}
+
+func TestRenameProviderResource(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	snap := NewSnapshot([]*resource.State{
+		pA,
+		a,
+	})
+
+	err := RenameResource(snap, pA, "p2")
+	assert.NoError(t, err)
+	ref, err := providers.ParseReference(a.Provider)
+	assert.NoError(t, err)
+	assert.Equal(t, pA.URN, ref.URN())
+	assert.Equal(t, pA.ID, ref.ID())
+	assert.NoError(t, snap.VerifyIntegrity())
+}
+
+func TestFailedRenameResourceConflict(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA)
+	snap := NewSnapshot([]*resource.State{
+		pA,
+		a,
+		b,
+	})
+
+	err := RenameResource(snap, a, "b")
+	assert.Error(t, err)
+	assert.Equal(t, resource.NewURN("test", "test", "", "a:b:c", "a"), a.URN)
+
+	err = RenameResource(snap, a, "invalid::name")
+	assert.Error(t, err)
+}
+
+func TestReparentResource(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	child := NewChildResource("child", a, pA)
+	grandchild := NewChildResource("grandchild", child, pA)
+	b := NewResource("b", pA, grandchild.URN)
+	c := NewResource("c", pA)
+	snap := NewSnapshot([]*resource.State{
+		pA,
+		a,
+		child,
+		grandchild,
+		b,
+		c,
+	})
+
+	err := ReparentResource(snap, child, c)
+	assert.NoError(t, err)
+	assert.Equal(t, c.URN, child.Parent)
+	assert.Equal(t, resource.NewURN("test", "test", "a:b:c", "a:b:c", "child"), child.URN)
+	assert.Equal(t, child.URN, grandchild.Parent)
+	assert.Equal(t, resource.NewURN("test", "test", "a:b:c$a:b:c", "a:b:c", "grandchild"), grandchild.URN)
+	assert.Equal(t, []resource.URN{grandchild.URN}, b.Dependencies)
+	assert.Equal(t, []*resource.State{pA, a, c, child, grandchild, b}, snap.Resources)
+	assert.NoError(t, snap.VerifyIntegrity())
+}
+
+func TestReparentResourcePendingDelete(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	child := NewChildResource("child", a, pA)
+	oldChild := NewChildResource("child", a, pA)
+	oldChild.Delete = true
+	c := NewResource("c", pA)
+	snap := NewSnapshot([]*resource.State{
+		pA,
+		a,
+		child,
+		oldChild,
+		c,
+	})
+
+	// Both the live resource and its copy that is pending deletion are reparented.
+	err := ReparentResource(snap, child, c)
+	assert.NoError(t, err)
+	for _, res := range []*resource.State{child, oldChild} {
+		assert.Equal(t, c.URN, res.Parent)
+		assert.Equal(t, resource.NewURN("test", "test", "a:b:c", "a:b:c", "child"), res.URN)
+	}
+	assert.NoError(t, snap.VerifyIntegrity())
+}
+
+func TestFailedReparentResourceCycle(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	child := NewChildResource("child", a, pA)
+	snap := NewSnapshot([]*resource.State{
+		pA,
+		a,
+		child,
+	})
+
+	err := ReparentResource(snap, a, child)
+	assert.Error(t, err)
+	err = ReparentResource(snap, a, a)
+	assert.Error(t, err)
+	assert.Equal(t, []*resource.State{pA, a, child}, snap.Resources)
+}
+
+func TestMoveResources(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	child := NewChildResource("child", a, pA, a.URN)
+	b := NewResource("b", pA)
+	source := NewSnapshot([]*resource.State{
+		pA,
+		a,
+		child,
+		b,
+	})
+	dest := NewSnapshot(nil)
+
+	err := MoveResources(source, dest, []*resource.State{a}, "dest", "proj")
+	assert.NoError(t, err)
+	assert.Equal(t, []*resource.State{pA, b}, source.Resources)
+	assert.Len(t, dest.Resources, 3)
+
+	// The provider is copied, rather than moved, and the moved resources refer to the copy.
+	destProvider := dest.Resources[0]
+	assert.NotEqual(t, pA, destProvider)
+	assert.Equal(t, resource.NewURN("dest", "proj", "", pA.Type, "p1"), destProvider.URN)
+	assert.Equal(t, resource.NewURN("test", "test", "", pA.Type, "p1"), pA.URN)
+	assert.Equal(t, []*resource.State{destProvider, a, child}, dest.Resources)
+
+	assert.Equal(t, resource.NewURN("dest", "proj", "", "a:b:c", "a"), a.URN)
+	assert.Equal(t, resource.NewURN("dest", "proj", "a:b:c", "a:b:c", "child"), child.URN)
+	assert.Equal(t, a.URN, child.Parent)
+	assert.Equal(t, []resource.URN{a.URN}, child.Dependencies)
+	ref, err := providers.ParseReference(child.Provider)
+	assert.NoError(t, err)
+	assert.Equal(t, destProvider.URN, ref.URN())
+
+	assert.NoError(t, source.VerifyIntegrity())
+	assert.NoError(t, dest.VerifyIntegrity())
+}
+
+func TestMoveResourcesProviderCopyIsIndependent(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	pA.Inputs["tags"] = resource.NewObjectProperty(resource.PropertyMap{"env": resource.NewStringProperty("dev")})
+	pA.Outputs["zones"] = resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("a")})
+	a := NewResource("a", pA)
+	source := NewSnapshot([]*resource.State{
+		pA,
+		a,
+	})
+	dest := NewSnapshot(nil)
+	oldURN := pA.URN
+
+	err := MoveResources(source, dest, []*resource.State{a}, "dest", "proj")
+	assert.NoError(t, err)
+	assert.Equal(t, []*resource.State{pA}, source.Resources)
+	assert.Equal(t, oldURN, pA.URN)
+
+	var destProvider *resource.State
+	for _, res := range dest.Resources {
+		if providers.IsProviderType(res.Type) {
+			destProvider = res
+		}
+	}
+	if !assert.NotNil(t, destProvider) {
+		return
+	}
+
+	// Modifying the copy must not modify the provider left behind.
+	destProvider.Inputs["tags"].ObjectValue()["env"] = resource.NewStringProperty("prod")
+	destProvider.Outputs["zones"].ArrayValue()[0] = resource.NewStringProperty("b")
+	assert.Equal(t, "dev", pA.Inputs["tags"].ObjectValue()["env"].StringValue())
+	assert.Equal(t, "a", pA.Outputs["zones"].ArrayValue()[0].StringValue())
+}
+
+func TestCopyState(t *testing.T) {
+	dep := NewResource("d", nil)
+	res := NewResource("a", nil, dep.URN)
+	res.InitErrors = []string{"failed"}
+	res.Inputs["secret"] = resource.MakeSecret(resource.NewObjectProperty(resource.PropertyMap{
+		"value": resource.NewStringProperty("hunter2"),
+	}))
+
+	clone := copyState(res)
+	clone.Dependencies[0] = "urn:pulumi:dest::proj::a:b:c::d"
+	clone.InitErrors[0] = "succeeded"
+	clone.Inputs["secret"].SecretValue().Element.ObjectValue()["value"] = resource.NewStringProperty("swordfish")
+
+	assert.Equal(t, []resource.URN{dep.URN}, res.Dependencies)
+	assert.Equal(t, []string{"failed"}, res.InitErrors)
+	assert.Equal(t, "hunter2", res.Inputs["secret"].SecretValue().Element.ObjectValue()["value"].StringValue())
+}
+
+func TestMoveResourcesExistingProvider(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	source := NewSnapshot([]*resource.State{
+		pA,
+		a,
+	})
+	pDest := NewProviderResource("a", "p1", "1")
+	pDest.URN = resource.NewURN("dest", "proj", "", pDest.Type, "p1")
+	dest := NewSnapshot([]*resource.State{
+		pDest,
+	})
+
+	err := MoveResources(source, dest, []*resource.State{a}, "dest", "proj")
+	assert.NoError(t, err)
+	assert.Equal(t, []*resource.State{pA}, source.Resources)
+	assert.Equal(t, []*resource.State{pDest, a}, dest.Resources)
+	ref, err := providers.ParseReference(a.Provider)
+	assert.NoError(t, err)
+	assert.Equal(t, pDest.URN, ref.URN())
+	assert.Equal(t, pDest.ID, ref.ID())
+	assert.NoError(t, dest.VerifyIntegrity())
+}
+
+func TestMoveResourcesChildProvider(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	pChild := NewProviderResource("a", "p2", "1")
+	pChild.URN = resource.NewURN("test", "test", a.URN.QualifiedType(), pChild.Type, "p2")
+	pChild.Parent = a.URN
+	child := NewChildResource("child", a, pChild)
+	b := NewResource("b", pA)
+	source := NewSnapshot([]*resource.State{
+		pA,
+		a,
+		pChild,
+		child,
+		b,
+	})
+	dest := NewSnapshot(nil)
+
+	// A provider that is parented by a moved resource is moved along with it.
+	err := MoveResources(source, dest, []*resource.State{a}, "dest", "proj")
+	assert.NoError(t, err)
+	assert.Equal(t, []*resource.State{pA, b}, source.Resources)
+	assert.Len(t, dest.Resources, 4)
+	assert.Equal(t, resource.NewURN("dest", "proj", "a:b:c", pChild.Type, "p2"), pChild.URN)
+	ref, err := providers.ParseReference(child.Provider)
+	assert.NoError(t, err)
+	assert.Equal(t, pChild.URN, ref.URN())
+	assert.Equal(t, pChild.ID, ref.ID())
+
+	assert.NoError(t, source.VerifyIntegrity())
+	assert.NoError(t, dest.VerifyIntegrity())
+}
+
+func TestFailedMoveResourcesChildProvider(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	pChild := NewProviderResource("a", "p2", "1")
+	pChild.URN = resource.NewURN("test", "test", a.URN.QualifiedType(), pChild.Type, "p2")
+	pChild.Parent = a.URN
+	b := NewResource("b", pChild)
+	source := NewSnapshot([]*resource.State{
+		pA,
+		a,
+		pChild,
+		b,
+	})
+	dest := NewSnapshot(nil)
+
+	// b, which is not being moved, uses a provider that is parented by a.
+	err := MoveResources(source, dest, []*resource.State{a}, "dest", "proj")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "uses it as its provider")
+	}
+	assert.Equal(t, []*resource.State{pA, a, pChild, b}, source.Resources)
+	assert.Empty(t, dest.Resources)
+}
+
+func TestFailedMoveResourcesDependency(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA, a.URN)
+	c := NewResource("c", pA)
+	d := NewResource("d", pA, c.URN)
+	source := NewSnapshot([]*resource.State{
+		pA,
+		a,
+		b,
+		c,
+		d,
+	})
+	dest := NewSnapshot(nil)
+
+	// b depends on a, which is not being moved.
+	err := MoveResources(source, dest, []*resource.State{b}, "dest", "proj")
+	assert.Error(t, err)
+
+	// d, which is not being moved, depends on c.
+	err = MoveResources(source, dest, []*resource.State{c}, "dest", "proj")
+	assert.Error(t, err)
+
+	// Providers may not be moved directly.
+	err = MoveResources(source, dest, []*resource.State{pA}, "dest", "proj")
+	assert.Error(t, err)
+
+	assert.Equal(t, []*resource.State{pA, a, b, c, d}, source.Resources)
+	assert.Empty(t, dest.Resources)
+	assert.Equal(t, resource.NewURN("test", "test", "", "a:b:c", "b"), b.URN)
+}
@@ -15,6 +15,7 @@
 package plugin
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/pulumi/pulumi/pkg/resource"
@@ -32,12 +33,48 @@ type Analyzer interface {
 	Name() tokens.QName
 	// Analyze analyzes a single resource object, and returns any errors that it finds.
 	Analyze(t tokens.Type, props resource.PropertyMap) ([]AnalyzeFailure, error)
+	// AnalyzeStack analyzes all of a stack's resources once its plan has been generated, and returns any errors that
+	// it finds.  Unlike Analyze, it may express rules that span multiple resources.
+	AnalyzeStack(resources []AnalyzerResource) ([]AnalyzeFailure, error)
 	// GetPluginInfo returns this plugin's information.
 	GetPluginInfo() (workspace.PluginInfo, error)
 }
 
+// AnalyzerResource describes a single resource in a stack that is being analyzed by AnalyzeStack.
+type AnalyzerResource struct {
+	URN          resource.URN         // the resource's URN.
+	Type         tokens.Type          // the resource's type.
+	Parent       resource.URN         // the resource's parent, if any.
+	Dependencies []resource.URN       // the resources on which this resource depends.
+	Inputs       resource.PropertyMap // the resource's inputs.
+	Outputs      resource.PropertyMap // the resource's outputs, if they are known.
+}
+
+// EnforcementLevel indicates how a policy violation reported by an analyzer is handled.
+type EnforcementLevel int
+
+const (
+	// Mandatory violations cause the update to fail.
+	Mandatory EnforcementLevel = 0
+	// Advisory violations are reported, but do not prevent the update from proceeding.
+	Advisory EnforcementLevel = 1
+)
+
+func (l EnforcementLevel) String() string {
+	switch l {
+	case Mandatory:
+		return "mandatory"
+	case Advisory:
+		return "advisory"
+	default:
+		return fmt.Sprintf("EnforcementLevel(%d)", int(l))
+	}
+}
+
 // AnalyzeFailure indicates that resource analysis failed; it contains the property and reason for the failure.
 type AnalyzeFailure struct {
-	Property resource.PropertyKey // the property that failed the analysis.
-	Reason   string               // the reason the property failed the analysis.
+	Property         resource.PropertyKey // the property that failed the analysis.
+	Reason           string               // the reason the property failed the analysis.
+	EnforcementLevel EnforcementLevel     // how the failure is handled.
+	URN              resource.URN         // the resource that failed the analysis, if reported by AnalyzeStack.
 }
@@ -20,6 +20,7 @@ import (
 
 	"github.com/blang/semver"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
 
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/tokens"
@@ -93,15 +94,72 @@ func (a *analyzer) Analyze(t tokens.Type, props resource.PropertyMap) ([]Analyze
 		return nil, rpcError
 	}
 
+	failures := convertFailures(resp.GetFailures())
+	logging.V(7).Infof("%s success: failures=#%d", label, len(failures))
+	return failures, nil
+}
+
+// AnalyzeStack analyzes all of a stack's resources once its plan has been generated, and returns any errors that it
+// finds.
+func (a *analyzer) AnalyzeStack(resources []AnalyzerResource) ([]AnalyzeFailure, error) {
+	label := fmt.Sprintf("%s.AnalyzeStack()", a.label())
+	logging.V(7).Infof("%s executing (#resources=%d)", label, len(resources))
+
+	mresources := make([]*pulumirpc.AnalyzerResource, len(resources))
+	for i, res := range resources {
+		mprops, err := MarshalProperties(res.Inputs, MarshalOptions{KeepUnknowns: true})
+		if err != nil {
+			return nil, err
+		}
+		moutputs, err := MarshalProperties(res.Outputs, MarshalOptions{KeepUnknowns: true})
+		if err != nil {
+			return nil, err
+		}
+		var deps []string
+		for _, dep := range res.Dependencies {
+			deps = append(deps, string(dep))
+		}
+		mresources[i] = &pulumirpc.AnalyzerResource{
+			Type:         string(res.Type),
+			Properties:   mprops,
+			Urn:          string(res.URN),
+			Parent:       string(res.Parent),
+			Dependencies: deps,
+			Outputs:      moutputs,
+		}
+	}
+
+	resp, err := a.client.AnalyzeStack(a.ctx.Request(), &pulumirpc.AnalyzeStackRequest{Resources: mresources})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("%s failed: err=%v", label, rpcError)
+
+		// It's possible this is just an older analyzer, prior to the emergence of the AnalyzeStack method.  In such
+		// cases, the analyzer has no stack-wide policies to report.
+		if rpcError.Code() == codes.Unimplemented {
+			return nil, nil
+		}
+
+		return nil, rpcError
+	}
+
+	failures := convertFailures(resp.GetFailures())
+	logging.V(7).Infof("%s success: failures=#%d", label, len(failures))
+	return failures, nil
+}
+
+// convertFailures converts the failures in an analyzer response into their plugin representation.
+func convertFailures(mfailures []*pulumirpc.AnalyzeFailure) []AnalyzeFailure {
 	var failures []AnalyzeFailure
-	for _, failure := range resp.GetFailures() {
+	for _, failure := range mfailures {
 		failures = append(failures, AnalyzeFailure{
-			Property: resource.PropertyKey(failure.Property),
-			Reason:   failure.Reason,
+			Property:         resource.PropertyKey(failure.Property),
+			Reason:           failure.Reason,
+			EnforcementLevel: EnforcementLevel(failure.EnforcementLevel),
+			URN:              resource.URN(failure.Urn),
 		})
 	}
-	logging.V(7).Infof("%s success: failures=#%d", label, len(failures))
-	return failures, nil
+	return failures
 }
 
 // GetPluginInfo returns this plugin's information.
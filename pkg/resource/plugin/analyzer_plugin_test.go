@@ -0,0 +1,86 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil/rpcerror"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+type testAnalyzerClient struct {
+	pulumirpc.AnalyzerClient
+
+	analyzeStackF func(req *pulumirpc.AnalyzeStackRequest) (*pulumirpc.AnalyzeResponse, error)
+}
+
+func (c *testAnalyzerClient) AnalyzeStack(ctx context.Context, req *pulumirpc.AnalyzeStackRequest,
+	opts ...grpc.CallOption) (*pulumirpc.AnalyzeResponse, error) {
+
+	return c.analyzeStackF(req)
+}
+
+func TestAnalyzeStack(t *testing.T) {
+	resources := []AnalyzerResource{{
+		URN:    resource.URN("urn:pulumi:stack::project::pkg:index:typ::res"),
+		Type:   tokens.Type("pkg:index:typ"),
+		Inputs: resource.PropertyMap{"foo": resource.NewStringProperty("bar")},
+	}}
+
+	client := &testAnalyzerClient{
+		analyzeStackF: func(req *pulumirpc.AnalyzeStackRequest) (*pulumirpc.AnalyzeResponse, error) {
+			assert.Len(t, req.GetResources(), 1)
+			return &pulumirpc.AnalyzeResponse{Failures: []*pulumirpc.AnalyzeFailure{{
+				Property:         "foo",
+				Reason:           "foo must not be bar",
+				EnforcementLevel: pulumirpc.EnforcementLevel_MANDATORY,
+				Urn:              req.GetResources()[0].GetUrn(),
+			}}}, nil
+		},
+	}
+	a := &analyzer{ctx: &Context{}, name: "test", client: client}
+
+	failures, err := a.AnalyzeStack(resources)
+	assert.NoError(t, err)
+	assert.Equal(t, []AnalyzeFailure{{
+		Property:         "foo",
+		Reason:           "foo must not be bar",
+		EnforcementLevel: Mandatory,
+		URN:              resources[0].URN,
+	}}, failures)
+
+	// Older analyzers that do not implement AnalyzeStack report no failures.
+	client.analyzeStackF = func(req *pulumirpc.AnalyzeStackRequest) (*pulumirpc.AnalyzeResponse, error) {
+		return nil, rpcerror.New(codes.Unimplemented, "unknown method AnalyzeStack")
+	}
+	failures, err = a.AnalyzeStack(resources)
+	assert.NoError(t, err)
+	assert.Empty(t, failures)
+
+	// Any other error is reported.
+	client.analyzeStackF = func(req *pulumirpc.AnalyzeStackRequest) (*pulumirpc.AnalyzeResponse, error) {
+		return nil, rpcerror.New(codes.Internal, "analyzer crashed")
+	}
+	_, err = a.AnalyzeStack(resources)
+	assert.Error(t, err)
+}
@@ -15,6 +15,7 @@
 package plugin
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/pulumi/pulumi/pkg/resource"
@@ -52,18 +53,21 @@ type Provider interface {
 	// Diff checks what impacts a hypothetical update will have on the resource's properties.
 	Diff(urn resource.URN, id resource.ID, olds resource.PropertyMap, news resource.PropertyMap,
 		allowUnknowns bool) (DiffResult, error)
-	// Create allocates a new instance of the provided resource and returns its unique resource.ID.
-	Create(urn resource.URN, news resource.PropertyMap) (resource.ID, resource.PropertyMap, resource.Status, error)
+	// Create allocates a new instance of the provided resource and returns its unique resource.ID. The timeout, which
+	// is represented in seconds, is the time that the provider may spend on the operation, or zero for its default.
+	// The engine enforces non-zero timeouts itself, so providers need not fail operations that exceed them.
+	Create(urn resource.URN, news resource.PropertyMap,
+		timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error)
 	// Read the current live state associated with a resource.  Enough state must be include in the inputs to uniquely
 	// identify the resource; this is typically just the resource ID, but may also include some properties.  If the
 	// resource is missing (for instance, because it has been deleted), the resulting property map will be nil.
 	Read(urn resource.URN, id resource.ID,
 		props resource.PropertyMap) (resource.PropertyMap, resource.Status, error)
-	// Update updates an existing resource with new values.
-	Update(urn resource.URN, id resource.ID,
-		olds resource.PropertyMap, news resource.PropertyMap) (resource.PropertyMap, resource.Status, error)
-	// Delete tears down an existing resource.
-	Delete(urn resource.URN, id resource.ID, props resource.PropertyMap) (resource.Status, error)
+	// Update updates an existing resource with new values. The timeout is interpreted as it is by Create.
+	Update(urn resource.URN, id resource.ID, olds resource.PropertyMap, news resource.PropertyMap,
+		timeout float64) (resource.PropertyMap, resource.Status, error)
+	// Delete tears down an existing resource. The timeout is interpreted as it is by Create.
+	Delete(urn resource.URN, id resource.ID, props resource.PropertyMap, timeout float64) (resource.Status, error)
 	// Invoke dynamically executes a built-in function in the provider.
 	Invoke(tok tokens.ModuleMember, args resource.PropertyMap) (resource.PropertyMap, []CheckFailure, error)
 	// GetPluginInfo returns this plugin's information.
@@ -95,15 +99,76 @@ const (
 	DiffSome DiffChanges = 2
 )
 
+// DiffKind represents the kind of change made to a single property.
+type DiffKind int
+
+const (
+	// DiffAdd indicates that the property was added.
+	DiffAdd DiffKind = 0
+	// DiffAddReplace indicates that the property was added and that this change requires a replace.
+	DiffAddReplace DiffKind = 1
+	// DiffDelete indicates that the property was removed.
+	DiffDelete DiffKind = 2
+	// DiffDeleteReplace indicates that the property was removed and that this change requires a replace.
+	DiffDeleteReplace DiffKind = 3
+	// DiffUpdate indicates that the property's value was changed.
+	DiffUpdate DiffKind = 4
+	// DiffUpdateReplace indicates that the property's value was changed and that this change requires a replace.
+	DiffUpdateReplace DiffKind = 5
+)
+
+// IsValid returns true if this is a known kind of property change.
+func (k DiffKind) IsValid() bool {
+	return k >= DiffAdd && k <= DiffUpdateReplace
+}
+
+func (k DiffKind) String() string {
+	switch k {
+	case DiffAdd:
+		return "add"
+	case DiffAddReplace:
+		return "add-replace"
+	case DiffDelete:
+		return "delete"
+	case DiffDeleteReplace:
+		return "delete-replace"
+	case DiffUpdate:
+		return "update"
+	case DiffUpdateReplace:
+		return "update-replace"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(k))
+	}
+}
+
+// IsReplace returns true if this kind of change requires a replace.
+func (k DiffKind) IsReplace() bool {
+	return k == DiffAddReplace || k == DiffDeleteReplace || k == DiffUpdateReplace
+}
+
+// PropertyDiff describes the change made to a single property.
+type PropertyDiff struct {
+	Kind DiffKind // the kind of change.
+}
+
 // DiffResult indicates whether an operation should replace or update an existing resource.
 type DiffResult struct {
 	Changes             DiffChanges            // true if this diff represents a changed resource.
 	ReplaceKeys         []resource.PropertyKey // an optional list of replacement keys.
 	StableKeys          []resource.PropertyKey // an optional list of property keys that are stable.
 	DeleteBeforeReplace bool                   // if true, this resource must be deleted before recreating it.
+
+	// DetailedDiff is an optional map from property paths (e.g. `tags.env` or `rules[2].port`) to the changes made to
+	// those properties. If present, it describes precisely how the resource will change.
+	DetailedDiff map[string]PropertyDiff
 }
 
 // Replace returns true if this diff represents a replacement.
 func (r DiffResult) Replace() bool {
+	for _, v := range r.DetailedDiff {
+		if v.Kind.IsReplace() {
+			return true
+		}
+	}
 	return len(r.ReplaceKeys) > 0
 }
@@ -254,6 +254,7 @@ func (p *provider) Check(urn resource.URN,
 		if err != nil {
 			return nil, nil, err
 		}
+		annotateSecrets(inputs, news)
 	}
 
 	// And now any properties that failed verification.
@@ -319,20 +320,35 @@ func (p *provider) Diff(urn resource.URN, id resource.ID,
 	for _, stable := range resp.GetStables() {
 		stables = append(stables, resource.PropertyKey(stable))
 	}
+	var detailedDiff map[string]PropertyDiff
+	if pd := resp.GetDetailedDiff(); len(pd) > 0 {
+		detailedDiff = make(map[string]PropertyDiff)
+		for path, diff := range pd {
+			// Providers may be newer than the engine and report kinds of changes that we do not know about. Treat any
+			// such change as a simple update.
+			kind := DiffKind(diff.GetKind())
+			if !kind.IsValid() {
+				logging.V(7).Infof("%s: unknown diff kind %v for property %v", label, int(kind), path)
+				kind = DiffUpdate
+			}
+			detailedDiff[path] = PropertyDiff{Kind: kind}
+		}
+	}
 	changes := resp.GetChanges()
 	deleteBeforeReplace := resp.GetDeleteBeforeReplace()
-	logging.V(7).Infof("%s success: changes=%d #replaces=%d #stables=%d delbefrepl=%v",
-		label, changes, len(replaces), len(stables), deleteBeforeReplace)
+	logging.V(7).Infof("%s success: changes=%d #replaces=%d #stables=%d delbefrepl=%v #detailed=%d",
+		label, changes, len(replaces), len(stables), deleteBeforeReplace, len(detailedDiff))
 	return DiffResult{
 		Changes:             DiffChanges(changes),
 		ReplaceKeys:         replaces,
 		StableKeys:          stables,
 		DeleteBeforeReplace: deleteBeforeReplace,
+		DetailedDiff:        detailedDiff,
 	}, nil
 }
 
 // Create allocates a new instance of the provided resource and assigns its unique resource.ID and outputs afterwards.
-func (p *provider) Create(urn resource.URN, props resource.PropertyMap) (resource.ID,
+func (p *provider) Create(urn resource.URN, props resource.PropertyMap, timeout float64) (resource.ID,
 	resource.PropertyMap, resource.Status, error) {
 	contract.Assert(urn != "")
 	contract.Assert(props != nil)
@@ -361,6 +377,7 @@ func (p *provider) Create(urn resource.URN, props resource.PropertyMap) (resourc
 	resp, err := client.Create(p.ctx.Request(), &pulumirpc.CreateRequest{
 		Urn:        string(urn),
 		Properties: mprops,
+		Timeout:    timeout,
 	})
 	if err != nil {
 		resourceStatus, id, liveObject, resourceError = parseError(err)
@@ -385,6 +402,7 @@ func (p *provider) Create(urn resource.URN, props resource.PropertyMap) (resourc
 	if err != nil {
 		return "", nil, resourceStatus, err
 	}
+	annotateSecrets(outs, props)
 
 	logging.V(7).Infof("%s success: id=%s; #outs=%d", label, id, len(outs))
 	if resourceError == nil {
@@ -458,14 +476,15 @@ func (p *provider) Read(
 	if err != nil {
 		return nil, resourceStatus, err
 	}
+	annotateSecrets(results, props)
 
 	logging.V(7).Infof("%s success; #outs=%d", label, len(results))
 	return results, resourceStatus, resourceError
 }
 
 // Update updates an existing resource with new values.
-func (p *provider) Update(urn resource.URN, id resource.ID,
-	olds resource.PropertyMap, news resource.PropertyMap) (resource.PropertyMap, resource.Status, error) {
+func (p *provider) Update(urn resource.URN, id resource.ID, olds resource.PropertyMap, news resource.PropertyMap,
+	timeout float64) (resource.PropertyMap, resource.Status, error) {
 	contract.Assert(urn != "")
 	contract.Assert(id != "")
 	contract.Assert(news != nil)
@@ -497,10 +516,11 @@ func (p *provider) Update(urn resource.URN, id resource.ID,
 	var resourceError error
 	var resourceStatus = resource.StatusOK
 	resp, err := client.Update(p.ctx.Request(), &pulumirpc.UpdateRequest{
-		Id:   string(id),
-		Urn:  string(urn),
-		Olds: molds,
-		News: mnews,
+		Id:      string(id),
+		Urn:     string(urn),
+		Olds:    molds,
+		News:    mnews,
+		Timeout: timeout,
 	})
 	if err != nil {
 		resourceStatus, _, liveObject, resourceError = parseError(err)
@@ -519,6 +539,7 @@ func (p *provider) Update(urn resource.URN, id resource.ID,
 	if err != nil {
 		return nil, resourceStatus, err
 	}
+	annotateSecrets(outs, news)
 
 	logging.V(7).Infof("%s success; #outs=%d", label, len(outs))
 	if resourceError == nil {
@@ -528,7 +549,8 @@ func (p *provider) Update(urn resource.URN, id resource.ID,
 }
 
 // Delete tears down an existing resource.
-func (p *provider) Delete(urn resource.URN, id resource.ID, props resource.PropertyMap) (resource.Status, error) {
+func (p *provider) Delete(urn resource.URN, id resource.ID, props resource.PropertyMap,
+	timeout float64) (resource.Status, error) {
 	contract.Assert(urn != "")
 	contract.Assert(id != "")
 
@@ -553,6 +575,7 @@ func (p *provider) Delete(urn resource.URN, id resource.ID, props resource.Prope
 		Id:         string(id),
 		Urn:        string(urn),
 		Properties: mprops,
+		Timeout:    timeout,
 	}); err != nil {
 		resourceStatus, rpcErr := resourceStateAndError(err)
 		logging.V(7).Infof("%s failed: %v", label, rpcErr)
@@ -703,7 +726,7 @@ func resourceStateAndError(err error) (resource.Status, *rpcerror.Error) {
 	rpcError := rpcerror.Convert(err)
 	logging.V(8).Infof("provider received rpc error `%s`: `%s`", rpcError.Code(), rpcError.Message())
 	switch rpcError.Code() {
-	case codes.Internal, codes.DataLoss, codes.Unknown:
+	case codes.Internal, codes.DataLoss, codes.Unknown, codes.DeadlineExceeded:
 		logging.V(8).Infof("rpc error kind `%s` may not be recoverable", rpcError.Code())
 		return resource.StatusUnknown, rpcError
 	}
@@ -757,3 +780,34 @@ func (ie *InitError) Error() string {
 	}
 	return err.Error()
 }
+
+// annotateSecrets marks as secret each property in outs whose counterpart in ins is secret. Providers are not aware of
+// secrets, so the values that they return must be re-marked in order to prevent them from being persisted or displayed
+// in plaintext.
+func annotateSecrets(outs, ins resource.PropertyMap) {
+	if outs == nil || ins == nil {
+		return
+	}
+
+	for key, in := range ins {
+		if out, has := outs[key]; has {
+			outs[key] = annotateSecret(out, in)
+		}
+	}
+}
+
+// annotateSecret returns out marked as secret if in is secret. Objects and arrays are annotated element-wise.
+func annotateSecret(out, in resource.PropertyValue) resource.PropertyValue {
+	switch {
+	case in.IsSecret() && !out.IsSecret():
+		return resource.MakeSecret(out)
+	case in.IsObject() && out.IsObject():
+		annotateSecrets(out.ObjectValue(), in.ObjectValue())
+	case in.IsArray() && out.IsArray():
+		outs, ins := out.ArrayValue(), in.ArrayValue()
+		for i := 0; i < len(outs) && i < len(ins); i++ {
+			outs[i] = annotateSecret(outs[i], ins[i])
+		}
+	}
+	return out
+}
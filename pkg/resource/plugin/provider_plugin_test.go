@@ -0,0 +1,112 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+type testProviderClient struct {
+	pulumirpc.ResourceProviderClient
+
+	diffF func(req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error)
+}
+
+func (c *testProviderClient) Diff(ctx context.Context, req *pulumirpc.DiffRequest,
+	opts ...grpc.CallOption) (*pulumirpc.DiffResponse, error) {
+
+	return c.diffF(req)
+}
+
+func newTestProvider(client pulumirpc.ResourceProviderClient) *provider {
+	cfgdone := make(chan bool)
+	close(cfgdone)
+	return &provider{ctx: &Context{}, pkg: "test", clientRaw: client, cfgknown: true, cfgdone: cfgdone}
+}
+
+func TestDiffUnknownDiffKind(t *testing.T) {
+	p := newTestProvider(&testProviderClient{
+		diffF: func(req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
+			return &pulumirpc.DiffResponse{
+				Changes: pulumirpc.DiffResponse_DIFF_SOME,
+				DetailedDiff: map[string]*pulumirpc.PropertyDiff{
+					"foo": {Kind: pulumirpc.PropertyDiff_ADD_REPLACE},
+					"bar": {Kind: pulumirpc.PropertyDiff_Kind(42)},
+				},
+			}, nil
+		},
+	})
+
+	olds := resource.PropertyMap{"bar": resource.NewStringProperty("baz")}
+	news := resource.PropertyMap{
+		"foo": resource.NewStringProperty("qux"),
+		"bar": resource.NewStringProperty("qux"),
+	}
+	diff, err := p.Diff("urn:pulumi:stack::project::test:index:typ::res", "id", olds, news, false)
+	assert.NoError(t, err)
+	assert.Equal(t, DiffSome, diff.Changes)
+	assert.Equal(t, map[string]PropertyDiff{
+		"foo": {Kind: DiffAddReplace},
+		"bar": {Kind: DiffUpdate},
+	}, diff.DetailedDiff)
+}
+
+func TestDiffKindString(t *testing.T) {
+	assert.Equal(t, "update-replace", DiffUpdateReplace.String())
+	assert.Equal(t, "unknown(42)", DiffKind(42).String())
+}
+
+func TestAnnotateSecrets(t *testing.T) {
+	secret := resource.MakeSecret(resource.NewStringProperty("hunter2"))
+	ins := resource.PropertyMap{
+		"password": secret,
+		"nested": resource.NewObjectProperty(resource.PropertyMap{
+			"password": secret,
+		}),
+		"list": resource.NewArrayProperty([]resource.PropertyValue{
+			resource.NewStringProperty("public"),
+			secret,
+			resource.NewObjectProperty(resource.PropertyMap{"password": secret}),
+		}),
+	}
+	outs := resource.PropertyMap{
+		"password": resource.NewStringProperty("hunter2"),
+		"nested": resource.NewObjectProperty(resource.PropertyMap{
+			"password": resource.NewStringProperty("hunter2"),
+		}),
+		"list": resource.NewArrayProperty([]resource.PropertyValue{
+			resource.NewStringProperty("public"),
+			resource.NewStringProperty("hunter2"),
+			resource.NewObjectProperty(resource.PropertyMap{"password": resource.NewStringProperty("hunter2")}),
+			resource.NewStringProperty("extra"),
+		}),
+	}
+
+	annotateSecrets(outs, ins)
+	assert.True(t, outs["password"].IsSecret())
+	assert.True(t, outs["nested"].ObjectValue()["password"].IsSecret())
+	list := outs["list"].ArrayValue()
+	assert.False(t, list[0].IsSecret())
+	assert.True(t, list[1].IsSecret())
+	assert.True(t, list[2].ObjectValue()["password"].IsSecret())
+	assert.False(t, list[3].IsSecret())
+}
@@ -34,6 +34,7 @@ type MarshalOptions struct {
 	RejectUnknowns     bool   // true if we should return errors on unknown values. Takes precedence over KeepUnknowns.
 	ElideAssetContents bool   // true if we are eliding the contents of assets.
 	ComputeAssetHashes bool   // true if we are computing missing asset hashes on the fly.
+	KeepSecrets        bool   // true if we are keeping secrets (otherwise we replace them with their underlying value).
 }
 
 const (
@@ -140,6 +141,19 @@ func MarshalPropertyValue(v resource.PropertyValue, opts MarshalOptions) (*struc
 			return marshalUnknownProperty(v.OutputValue().Element, opts), nil
 		}
 		return nil, nil // return nil and the caller will ignore it.
+	} else if v.IsSecret() {
+		// If we are not keeping secrets, simply marshal the underlying value. Otherwise, wrap the underlying value in
+		// an object tagged with the secret signature so that we can recover it on the other end.
+		elem, err := MarshalPropertyValue(v.SecretValue().Element, opts)
+		if err != nil || elem == nil || !opts.KeepSecrets {
+			return elem, err
+		}
+		return MarshalStruct(&structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				string(resource.SigKey): MarshalString(resource.SecretSig, opts),
+				"value":                 elem,
+			},
+		}, opts), nil
 	}
 
 	contract.Failf("Unrecognized property value in RPC[%s]: %v (type=%v)", opts.Label, v.V, reflect.TypeOf(v.V))
@@ -266,6 +280,18 @@ func UnmarshalPropertyValue(v *structpb.Value, opts MarshalOptions) (*resource.P
 		}
 
 		// Before returning it as an object, check to see if it's a known recoverable type.
+		if resource.HasSig(obj, resource.SecretSig) {
+			value, has := obj["value"]
+			if !has {
+				// The underlying value was an unknown that was skipped; skip the secret as well.
+				return nil, nil
+			}
+			if !opts.KeepSecrets {
+				return &value, nil
+			}
+			m := resource.MakeSecret(value)
+			return &m, nil
+		}
 		objmap := obj.Mappable()
 		asset, isasset, err := resource.DeserializeAsset(objmap)
 		if err != nil {
@@ -121,3 +121,25 @@ func TestComputedReject(t *testing.T) {
 		assert.Nil(t, cpropU)
 	}
 }
+
+func TestSecretSerialize(t *testing.T) {
+	// Ensure that secrets round trip when KeepSecrets == true.
+	opts := MarshalOptions{KeepSecrets: true}
+	sprop, err := MarshalPropertyValue(resource.MakeSecret(resource.NewStringProperty("shh")), opts)
+	assert.Nil(t, err)
+	sig := sprop.GetStructValue().Fields[string(resource.SigKey)]
+	assert.Equal(t, resource.SecretSig, sig.GetStringValue())
+	spropU, err := UnmarshalPropertyValue(sprop, opts)
+	assert.Nil(t, err)
+	assert.True(t, spropU.IsSecret())
+	assert.Equal(t, "shh", spropU.SecretValue().Element.StringValue())
+
+	// Ensure that secrets are passed as their plain values when KeepSecrets == false.
+	pprop, err := MarshalPropertyValue(resource.MakeSecret(resource.NewStringProperty("shh")), MarshalOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "shh", pprop.GetStringValue())
+	ppropU, err := UnmarshalPropertyValue(sprop, MarshalOptions{})
+	assert.Nil(t, err)
+	assert.False(t, ppropU.IsSecret())
+	assert.Equal(t, "shh", ppropU.StringValue())
+}
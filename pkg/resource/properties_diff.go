@@ -282,6 +282,14 @@ func (v PropertyValue) DeepEquals(other PropertyValue) bool {
 		return v.ArchiveValue().Equals(other.ArchiveValue())
 	}
 
+	// Secrets are equal if their underlying values are deeply equal.
+	if v.IsSecret() {
+		if !other.IsSecret() {
+			return false
+		}
+		return v.SecretValue().Element.DeepEquals(other.SecretValue().Element)
+	}
+
 	// Object values are equal if their contents are deeply equal.
 	if v.IsObject() {
 		if !other.IsObject() {
@@ -0,0 +1,214 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// PropertyPath represents a path to a nested property. The path is composed of strings, which access properties of
+// object values, and integers, which access elements of array values.
+type PropertyPath []interface{}
+
+// ParsePropertyPath parses a property path into a PropertyPath value.
+//
+// A property path is essentially a JavaScript property access expression in which all elements are literals. Valid
+// property paths obey the following grammar:
+//
+//     propertyName       := [^.[] { [^.[] }
+//     quotedPropertyName := '"' ( '\' any | [^"] ) { ( '\' any | [^"] ) } '"'
+//     arrayIndex         := [0-9] { [0-9] }
+//     propertyIndex      := '[' ( quotedPropertyName | arrayIndex ) ']'
+//     rootProperty       := ( propertyName | propertyIndex )
+//     propertyAccessor   := ( ( '.' propertyName ) | propertyIndex )
+//     path               := rootProperty { propertyAccessor }
+//
+// For example, `tags.Name`, `rules[0].port`, and `metadata.labels["app.kubernetes.io/name"]` are all valid paths.
+func ParsePropertyPath(path string) (PropertyPath, error) {
+	if path == "" {
+		return nil, errors.New("property path must not be empty")
+	}
+
+	var elements PropertyPath
+	for i := 0; i < len(path); {
+		switch {
+		case path[i] == '[':
+			// An index: either a quoted property name or an array index, followed by a closing bracket.
+			i++
+			if i < len(path) && path[i] == '"' {
+				var key bytes.Buffer
+				for i++; i < len(path) && path[i] != '"'; i++ {
+					if path[i] == '\\' && i+1 < len(path) {
+						i++
+					}
+					key.WriteByte(path[i])
+				}
+				if i >= len(path) {
+					return nil, errors.Errorf("missing closing quote in property path '%s'", path)
+				}
+				elements = append(elements, key.String())
+				i++
+			} else {
+				start := i
+				for i < len(path) && path[i] >= '0' && path[i] <= '9' {
+					i++
+				}
+				index, err := strconv.Atoi(path[start:i])
+				if err != nil {
+					return nil, errors.Errorf("invalid array index in property path '%s'", path)
+				}
+				elements = append(elements, index)
+			}
+			if i >= len(path) || path[i] != ']' {
+				return nil, errors.Errorf("missing closing bracket in property path '%s'", path)
+			}
+			i++
+		case path[i] == '.' && len(elements) == 0:
+			return nil, errors.Errorf("property path '%s' must not begin with '.'", path)
+		case path[i] == '.' || len(elements) == 0:
+			// A property name, which runs until the next accessor.
+			if path[i] == '.' {
+				i++
+			}
+			start := i
+			for i < len(path) && path[i] != '.' && path[i] != '[' {
+				i++
+			}
+			if start == i {
+				return nil, errors.Errorf("missing property name in property path '%s'", path)
+			}
+			elements = append(elements, path[start:i])
+		default:
+			return nil, errors.Errorf("expected '.' or '[' at offset %d in property path '%s'", i, path)
+		}
+	}
+	return elements, nil
+}
+
+// Get attempts to get the value at the path from the given value. It returns false if any element of the path does
+// not exist.
+func (p PropertyPath) Get(v PropertyValue) (PropertyValue, bool) {
+	for _, key := range p {
+		switch {
+		case v.IsArray():
+			index, ok := key.(int)
+			if !ok || index < 0 || index >= len(v.ArrayValue()) {
+				return PropertyValue{}, false
+			}
+			v = v.ArrayValue()[index]
+		case v.IsObject():
+			name, ok := key.(string)
+			if !ok {
+				return PropertyValue{}, false
+			}
+			if v, ok = v.ObjectValue()[PropertyKey(name)]; !ok {
+				return PropertyValue{}, false
+			}
+		default:
+			return PropertyValue{}, false
+		}
+	}
+	return v, true
+}
+
+// Set attempts to set the value at the path in the given destination value. All elements of the path except the last
+// must already exist. Rather than modifying the destination, Set returns a copy of it that contains the new value;
+// values that do not lie along the path are shared with the original. It returns false if the path does not exist.
+func (p PropertyPath) Set(dest, v PropertyValue) (PropertyValue, bool) {
+	return p.replace(dest, &v)
+}
+
+// Delete attempts to delete the value at the path in the given destination value. Like Set, Delete does not modify
+// the destination but returns an updated copy of it. It returns false if the path does not exist.
+func (p PropertyPath) Delete(dest PropertyValue) (PropertyValue, bool) {
+	return p.replace(dest, nil)
+}
+
+// Reset attempts to reset the value at the path in new to its value in old. If the value does not exist in old, it is
+// deleted from new. Reset returns an updated copy of new, and false if the value exists in old but its parent does
+// not exist in new.
+func (p PropertyPath) Reset(old, new PropertyMap) (PropertyMap, bool) {
+	var result PropertyValue
+	var ok bool
+	if oldValue, has := p.Get(NewObjectProperty(old)); has {
+		result, ok = p.Set(NewObjectProperty(new), oldValue)
+	} else if _, has = p.Get(NewObjectProperty(new)); has {
+		result, ok = p.Delete(NewObjectProperty(new))
+	} else {
+		return new, true
+	}
+	if !ok {
+		return new, false
+	}
+	return result.ObjectValue(), true
+}
+
+// replace returns a copy of dest in which the value at the path is replaced by v or, if v is nil, deleted.
+func (p PropertyPath) replace(dest PropertyValue, v *PropertyValue) (PropertyValue, bool) {
+	if len(p) == 0 {
+		if v == nil {
+			return dest, false
+		}
+		return *v, true
+	}
+
+	key, rest := p[0], p[1:]
+	switch {
+	case dest.IsArray():
+		arr := dest.ArrayValue()
+		index, ok := key.(int)
+		if !ok || index < 0 || index >= len(arr) {
+			return dest, false
+		}
+		if len(rest) == 0 && v == nil {
+			return NewArrayProperty(append(append([]PropertyValue{}, arr[:index]...), arr[index+1:]...)), true
+		}
+		elem, ok := rest.replace(arr[index], v)
+		if !ok {
+			return dest, false
+		}
+		newArr := append([]PropertyValue{}, arr...)
+		newArr[index] = elem
+		return NewArrayProperty(newArr), true
+	case dest.IsObject():
+		obj := dest.ObjectValue()
+		name, ok := key.(string)
+		if !ok {
+			return dest, false
+		}
+		newObj := obj.Copy()
+		if len(rest) == 0 {
+			if v == nil {
+				delete(newObj, PropertyKey(name))
+			} else {
+				newObj[PropertyKey(name)] = *v
+			}
+			return NewObjectProperty(newObj), true
+		}
+		child, has := obj[PropertyKey(name)]
+		if !has {
+			return dest, false
+		}
+		if newObj[PropertyKey(name)], ok = rest.replace(child, v); !ok {
+			return dest, false
+		}
+		return NewObjectProperty(newObj), true
+	default:
+		return dest, false
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePropertyPath(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]PropertyPath{
+		"root":                   {"root"},
+		"root.nested":            {"root", "nested"},
+		`root["nested"]`:         {"root", "nested"},
+		"root.double.nest":       {"root", "double", "nest"},
+		`root["double"].nest`:    {"root", "double", "nest"},
+		`root["double"]["nest"]`: {"root", "double", "nest"},
+		"root[0]":                {"root", 0},
+		"root[0].nested":         {"root", 0, "nested"},
+		"root[0][1]":             {"root", 0, 1},
+		`root["key.with.dots"]`:  {"root", "key.with.dots"},
+		`root["key \"quoted\""]`: {"root", `key "quoted"`},
+		`["root key"].nested`:    {"root key", "nested"},
+		"[0]":                    {0},
+		"root.nested[10].deep":   {"root", "nested", 10, "deep"},
+	}
+	for input, expected := range cases {
+		actual, err := ParsePropertyPath(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, actual, input)
+	}
+
+	invalid := []string{"", ".root", "root.", "root..nested", "root[", "root[0", `root["nested`, `root["nested"`,
+		"root[a]", "root[]", "root[0]nested"}
+	for _, input := range invalid {
+		_, err := ParsePropertyPath(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestPropertyPathGetSetDelete(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObjectProperty(NewPropertyMapFromMap(map[string]interface{}{
+		"root": map[string]interface{}{
+			"nested": []interface{}{"a", "b"},
+		},
+	}))
+
+	v, ok := PropertyPath{"root", "nested", 1}.Get(obj)
+	assert.True(t, ok)
+	assert.Equal(t, NewStringProperty("b"), v)
+	_, ok = PropertyPath{"root", "missing"}.Get(obj)
+	assert.False(t, ok)
+	_, ok = PropertyPath{"root", "nested", 2}.Get(obj)
+	assert.False(t, ok)
+
+	// Setting a value returns an updated copy and leaves the original untouched.
+	updated, ok := PropertyPath{"root", "nested", 0}.Set(obj, NewStringProperty("c"))
+	assert.True(t, ok)
+	v, _ = PropertyPath{"root", "nested", 0}.Get(updated)
+	assert.Equal(t, NewStringProperty("c"), v)
+	v, _ = PropertyPath{"root", "nested", 0}.Get(obj)
+	assert.Equal(t, NewStringProperty("a"), v)
+
+	// The last element of the path need not exist, but its parent must.
+	updated, ok = PropertyPath{"root", "other"}.Set(obj, NewNumberProperty(42))
+	assert.True(t, ok)
+	v, _ = PropertyPath{"root", "other"}.Get(updated)
+	assert.Equal(t, NewNumberProperty(42), v)
+	_, ok = PropertyPath{"missing", "other"}.Set(obj, NewNumberProperty(42))
+	assert.False(t, ok)
+
+	updated, ok = PropertyPath{"root", "nested", 0}.Delete(obj)
+	assert.True(t, ok)
+	v, _ = PropertyPath{"root", "nested"}.Get(updated)
+	assert.Equal(t, NewArrayProperty([]PropertyValue{NewStringProperty("b")}), v)
+	updated, ok = PropertyPath{"root"}.Delete(obj)
+	assert.True(t, ok)
+	assert.Len(t, updated.ObjectValue(), 0)
+	assert.Len(t, obj.ObjectValue(), 1)
+}
+
+func TestPropertyPathReset(t *testing.T) {
+	t.Parallel()
+
+	old := NewPropertyMapFromMap(map[string]interface{}{
+		"tags": map[string]interface{}{"Name": "old", "Owner": "me"},
+		"size": 1,
+	})
+	new := NewPropertyMapFromMap(map[string]interface{}{
+		"tags":  map[string]interface{}{"Name": "new"},
+		"size":  2,
+		"extra": true,
+	})
+
+	reset, ok := PropertyPath{"tags", "Name"}.Reset(old, new)
+	assert.True(t, ok)
+	assert.Equal(t, "old", reset["tags"].ObjectValue()["Name"].StringValue())
+	assert.Equal(t, "new", new["tags"].ObjectValue()["Name"].StringValue())
+
+	reset, ok = PropertyPath{"tags", "Owner"}.Reset(old, new)
+	assert.True(t, ok)
+	assert.Equal(t, "me", reset["tags"].ObjectValue()["Owner"].StringValue())
+
+	reset, ok = PropertyPath{"extra"}.Reset(old, new)
+	assert.True(t, ok)
+	assert.False(t, reset.HasValue("extra"))
+
+	reset, ok = PropertyPath{"size"}.Reset(old, new)
+	assert.True(t, ok)
+	assert.Equal(t, NewNumberProperty(1), reset["size"])
+
+	// Paths that exist in neither map are left alone.
+	reset, ok = PropertyPath{"missing", "nested"}.Reset(old, new)
+	assert.True(t, ok)
+	assert.Equal(t, new, reset)
+
+	// Paths whose parents do not exist in the new map cannot be reset.
+	_, ok = PropertyPath{"tags", "Name"}.Reset(old, PropertyMap{})
+	assert.False(t, ok)
+}
@@ -0,0 +1,652 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/util/mapper"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil/rpcerror"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// Resource implements the lifecycle of a single resource type.  Each resource type is registered with a Go struct
+// type whose fields, tagged with `pulumi:"..."`, describe the resource's properties; the inputs and state passed to
+// and returned from each operation are pointers to values of that type.
+type Resource interface {
+	// Create allocates a new instance of the resource with the given inputs and returns its ID and state.  If the
+	// resource was allocated but could not be initialized, Create should return its ID and state along with an
+	// *InitError so that the resource can be recorded and later updated or deleted.
+	Create(ctx context.Context, urn resource.URN, inputs interface{}) (resource.ID, interface{}, error)
+	// Read returns the current live state of the resource with the given ID, or nil if the resource no longer exists.
+	Read(ctx context.Context, urn resource.URN, id resource.ID, state interface{}) (interface{}, error)
+	// Update updates the resource with the given ID from its current state to its new inputs and returns its new
+	// state.  As with Create, an *InitError indicates that the resource was updated but could not be initialized.
+	Update(ctx context.Context, urn resource.URN, id resource.ID, olds, news interface{}) (interface{}, error)
+	// Delete tears down the resource with the given ID.
+	Delete(ctx context.Context, urn resource.URN, id resource.ID, state interface{}) error
+}
+
+// Checker may be implemented by a Resource in order to validate its inputs and supply defaults for them.  If a
+// Resource does not implement Checker, its inputs are only checked against its struct type.
+type Checker interface {
+	// Check validates the new inputs for a resource, given its old inputs (which are nil if the resource is being
+	// created), and returns the inputs to use for the resource along with any validation failures.
+	Check(ctx context.Context, urn resource.URN, olds, news interface{}) (interface{}, []plugin.CheckFailure, error)
+}
+
+// Differ may be implemented by a Resource in order to compute the changes between its current state and its new
+// inputs.  If a Resource does not implement Differ, it is updated whenever one of the properties of its struct type
+// differs between its state and its new inputs, including properties that have been removed from its inputs, and is
+// never replaced.  Properties that only appear in the state must be tagged with `output`, e.g.
+// `pulumi:"status,output"`, so that they are not compared.
+type Differ interface {
+	// Diff computes the changes that updating a resource from its current state to its new inputs will make.
+	Diff(ctx context.Context, urn resource.URN, id resource.ID, olds, news interface{}) (plugin.DiffResult, error)
+}
+
+// ConfigureFunc configures a provider with the given configuration variables.
+type ConfigureFunc func(ctx context.Context, vars map[string]string) error
+
+// InitError may be returned by a Resource's Create or Update operation to indicate that the resource was provisioned
+// but failed to initialize, e.g. because it never became healthy.
+type InitError struct {
+	Reasons []string // the reasons that the resource failed to initialize.
+}
+
+func (e *InitError) Error() string {
+	if len(e.Reasons) == 0 {
+		return "resource failed to initialize"
+	}
+	return "resource failed to initialize: " + strings.Join(e.Reasons, "; ")
+}
+
+// Provider is a resource provider whose resource types are implemented by Resources.  It handles the marshaling of
+// properties to and from their Go struct types, unknown values during previews, partially initialized resources, and
+// cancellation, and may be served from Main.
+type Provider struct {
+	host      *HostClient
+	name      tokens.Package
+	version   string
+	configure ConfigureFunc
+	resources map[tokens.Type]*resourceType
+
+	canceled   chan struct{}
+	cancelOnce sync.Once
+}
+
+var _ pulumirpc.ResourceProviderServer = (*Provider)(nil)
+
+// resourceType is a resource type registered with a provider.
+type resourceType struct {
+	typ     reflect.Type
+	res     Resource
+	outputs map[resource.PropertyKey]bool // the properties tagged as outputs, which are never inputs.
+}
+
+// NewProvider creates a new provider for the given package.  configure, if non-nil, is called when the provider is
+// configured.
+func NewProvider(host *HostClient, name tokens.Package, version string, configure ConfigureFunc) *Provider {
+	return &Provider{
+		host:      host,
+		name:      name,
+		version:   version,
+		configure: configure,
+		resources: make(map[tokens.Type]*resourceType),
+		canceled:  make(chan struct{}),
+	}
+}
+
+// Host returns the client for the engine that loaded this provider.
+func (p *Provider) Host() *HostClient {
+	return p.host
+}
+
+// RegisterResource registers the implementation of the given resource type.  properties is a value of (or a pointer
+// to) the Go struct type that describes the resource's properties.
+func (p *Provider) RegisterResource(t tokens.Type, properties interface{}, res Resource) {
+	typ := reflect.TypeOf(properties)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	contract.Assertf(typ.Kind() == reflect.Struct, "properties of resource type %v must be a struct (got %v)", t, typ)
+	contract.Assertf(p.resources[t] == nil, "resource type %v has already been registered", t)
+	p.resources[t] = &resourceType{typ: typ, res: res, outputs: outputProperties(typ)}
+}
+
+// outputProperties returns the properties of the given struct type whose fields are tagged as outputs.
+func outputProperties(typ reflect.Type) map[resource.PropertyKey]bool {
+	outputs := make(map[resource.PropertyKey]bool)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			for k := range outputProperties(field.Type) {
+				outputs[k] = true
+			}
+			continue
+		}
+
+		parts := strings.Split(field.Tag.Get("pulumi"), ",")
+		for _, part := range parts[1:] {
+			if part == outputTag {
+				outputs[resource.PropertyKey(parts[0])] = true
+			}
+		}
+	}
+	return outputs
+}
+
+// getResourceType returns the registered resource type of the resource with the given URN.
+func (p *Provider) getResourceType(urn string) (*resourceType, error) {
+	t := resource.URN(urn).Type()
+	rt, has := p.resources[t]
+	if !has {
+		return nil, errors.Errorf("unknown resource type '%v'", t)
+	}
+	return rt, nil
+}
+
+// operationContext returns a context for a resource operation that is canceled if the provider is canceled or if the
+// operation does not complete within the given timeout, in seconds.  A zero timeout means that there is no timeout.
+func (p *Provider) operationContext(ctx context.Context, timeout float64) (context.Context, context.CancelFunc) {
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout*float64(time.Second)))
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	go func() {
+		select {
+		case <-p.canceled:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// Configure configures the resource provider with "globals" that control its behavior.
+func (p *Provider) Configure(ctx context.Context, req *pulumirpc.ConfigureRequest) (*pbempty.Empty, error) {
+	if p.configure != nil {
+		if err := p.configure(ctx, req.GetVariables()); err != nil {
+			return nil, err
+		}
+	}
+	return &pbempty.Empty{}, nil
+}
+
+// Invoke dynamically executes a built-in function in the provider.  Providers built using this framework do not
+// export any functions.
+func (p *Provider) Invoke(ctx context.Context, req *pulumirpc.InvokeRequest) (*pulumirpc.InvokeResponse, error) {
+	return nil, rpcerror.Newf(codes.Unimplemented, "unknown function '%s'", req.GetTok())
+}
+
+// Check validates that the given property bag is valid for a resource of the given type.
+func (p *Provider) Check(ctx context.Context, req *pulumirpc.CheckRequest) (*pulumirpc.CheckResponse, error) {
+	label := "Check(" + req.GetUrn() + ")"
+	rt, err := p.getResourceType(req.GetUrn())
+	if err != nil {
+		return nil, err
+	}
+
+	news, err := unmarshalProperties(label+".news", req.GetNews())
+	if err != nil {
+		return nil, err
+	}
+	newObj, failures := rt.decode(news, true)
+	if len(failures) > 0 {
+		return &pulumirpc.CheckResponse{Failures: marshalCheckFailures(failures)}, nil
+	}
+
+	// If the resource doesn't validate its own inputs, we're done.  Return the inputs in their original form.
+	checker, ok := rt.res.(Checker)
+	if !ok {
+		return &pulumirpc.CheckResponse{Inputs: req.GetNews()}, nil
+	}
+
+	var oldObj interface{}
+	if olds, err := unmarshalProperties(label+".olds", req.GetOlds()); err != nil {
+		return nil, err
+	} else if len(olds) > 0 {
+		if oldObj, failures = rt.decode(olds, false); len(failures) > 0 {
+			return nil, errors.Errorf("decoding old inputs: %v", failures[0].Reason)
+		}
+	}
+
+	ctx, cancel := p.operationContext(ctx, 0)
+	defer cancel()
+
+	checked, failures, err := checker.Check(ctx, resource.URN(req.GetUrn()), oldObj, newObj)
+	if err != nil {
+		return nil, err
+	} else if len(failures) > 0 {
+		return &pulumirpc.CheckResponse{Failures: marshalCheckFailures(failures)}, nil
+	}
+
+	inputs, err := rt.encode(checked)
+	if err != nil {
+		return nil, err
+	}
+
+	// Any inputs that were unknown could not be passed to the resource, so preserve them as they were.
+	for k, v := range news {
+		if v.ContainsUnknowns() {
+			inputs[k] = v
+		}
+	}
+
+	rpcInputs, err := marshalProperties(label+".inputs", inputs)
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.CheckResponse{Inputs: rpcInputs}, nil
+}
+
+// Diff checks what impacts a hypothetical update will have on the resource's properties.
+func (p *Provider) Diff(ctx context.Context, req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
+	label := "Diff(" + req.GetUrn() + ")"
+	rt, err := p.getResourceType(req.GetUrn())
+	if err != nil {
+		return nil, err
+	}
+
+	olds, err := unmarshalProperties(label+".olds", req.GetOlds())
+	if err != nil {
+		return nil, err
+	}
+	news, err := unmarshalProperties(label+".news", req.GetNews())
+	if err != nil {
+		return nil, err
+	}
+
+	oldObj, failures := rt.decode(olds, false)
+	if len(failures) > 0 {
+		return nil, errors.Errorf("decoding state: %v", failures[0].Reason)
+	}
+	newObj, failures := rt.decode(news, false)
+	if len(failures) > 0 {
+		return nil, errors.Errorf("decoding inputs: %v", failures[0].Reason)
+	}
+
+	ctx, cancel := p.operationContext(ctx, 0)
+	defer cancel()
+
+	var result plugin.DiffResult
+	if differ, ok := rt.res.(Differ); ok {
+		result, err = differ.Diff(ctx, resource.URN(req.GetUrn()), resource.ID(req.GetId()), oldObj, newObj)
+		if err != nil {
+			return nil, err
+		}
+	} else if result, err = rt.diff(oldObj, newObj); err != nil {
+		return nil, err
+	}
+
+	// Any inputs that are unknown may differ from the resource's current state once they are known.
+	for _, v := range news {
+		if v.ContainsUnknowns() {
+			result.Changes = plugin.DiffSome
+		}
+	}
+
+	return marshalDiffResult(result), nil
+}
+
+// Create allocates a new instance of the provided resource and returns its unique ID afterwards.
+func (p *Provider) Create(ctx context.Context, req *pulumirpc.CreateRequest) (*pulumirpc.CreateResponse, error) {
+	label := "Create(" + req.GetUrn() + ")"
+	rt, err := p.getResourceType(req.GetUrn())
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, err := unmarshalProperties(label+".inputs", req.GetProperties())
+	if err != nil {
+		return nil, err
+	}
+	obj, failures := rt.decode(inputs, false)
+	if len(failures) > 0 {
+		return nil, errors.Errorf("decoding inputs: %v", failures[0].Reason)
+	}
+
+	ctx, cancel := p.operationContext(ctx, req.GetTimeout())
+	defer cancel()
+
+	logging.V(9).Infof("%s executing", label)
+	id, state, err := rt.res.Create(ctx, resource.URN(req.GetUrn()), obj)
+	if err != nil {
+		return nil, rt.resourceError(label, id, state, err)
+	} else if id == "" {
+		return nil, errors.Errorf("%s: provider did not return an ID", label)
+	}
+
+	rpcState, err := rt.marshalState(label+".state", state)
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.CreateResponse{Id: string(id), Properties: rpcState}, nil
+}
+
+// Read the current live state associated with a resource.
+func (p *Provider) Read(ctx context.Context, req *pulumirpc.ReadRequest) (*pulumirpc.ReadResponse, error) {
+	label := "Read(" + req.GetUrn() + ")"
+	rt, err := p.getResourceType(req.GetUrn())
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := unmarshalProperties(label+".state", req.GetProperties())
+	if err != nil {
+		return nil, err
+	}
+	obj, failures := rt.decode(props, false)
+	if len(failures) > 0 {
+		return nil, errors.Errorf("decoding state: %v", failures[0].Reason)
+	}
+
+	ctx, cancel := p.operationContext(ctx, 0)
+	defer cancel()
+
+	logging.V(9).Infof("%s executing", label)
+	id := resource.ID(req.GetId())
+	state, err := rt.res.Read(ctx, resource.URN(req.GetUrn()), id, obj)
+	if err != nil {
+		return nil, err
+	} else if isNil(state) {
+		// The resource no longer exists.
+		return &pulumirpc.ReadResponse{}, nil
+	}
+
+	rpcState, err := rt.marshalState(label+".state", state)
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.ReadResponse{Id: string(id), Properties: rpcState}, nil
+}
+
+// Update updates an existing resource with new values.
+func (p *Provider) Update(ctx context.Context, req *pulumirpc.UpdateRequest) (*pulumirpc.UpdateResponse, error) {
+	label := "Update(" + req.GetUrn() + ")"
+	rt, err := p.getResourceType(req.GetUrn())
+	if err != nil {
+		return nil, err
+	}
+
+	olds, err := unmarshalProperties(label+".olds", req.GetOlds())
+	if err != nil {
+		return nil, err
+	}
+	news, err := unmarshalProperties(label+".news", req.GetNews())
+	if err != nil {
+		return nil, err
+	}
+	oldObj, failures := rt.decode(olds, false)
+	if len(failures) > 0 {
+		return nil, errors.Errorf("decoding state: %v", failures[0].Reason)
+	}
+	newObj, failures := rt.decode(news, false)
+	if len(failures) > 0 {
+		return nil, errors.Errorf("decoding inputs: %v", failures[0].Reason)
+	}
+
+	ctx, cancel := p.operationContext(ctx, req.GetTimeout())
+	defer cancel()
+
+	logging.V(9).Infof("%s executing", label)
+	id := resource.ID(req.GetId())
+	state, err := rt.res.Update(ctx, resource.URN(req.GetUrn()), id, oldObj, newObj)
+	if err != nil {
+		return nil, rt.resourceError(label, id, state, err)
+	}
+
+	rpcState, err := rt.marshalState(label+".state", state)
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.UpdateResponse{Properties: rpcState}, nil
+}
+
+// Delete tears down an existing resource with the given ID.
+func (p *Provider) Delete(ctx context.Context, req *pulumirpc.DeleteRequest) (*pbempty.Empty, error) {
+	label := "Delete(" + req.GetUrn() + ")"
+	rt, err := p.getResourceType(req.GetUrn())
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := unmarshalProperties(label+".state", req.GetProperties())
+	if err != nil {
+		return nil, err
+	}
+	obj, failures := rt.decode(props, false)
+	if len(failures) > 0 {
+		return nil, errors.Errorf("decoding state: %v", failures[0].Reason)
+	}
+
+	ctx, cancel := p.operationContext(ctx, req.GetTimeout())
+	defer cancel()
+
+	logging.V(9).Infof("%s executing", label)
+	if err = rt.res.Delete(ctx, resource.URN(req.GetUrn()), resource.ID(req.GetId()), obj); err != nil {
+		return nil, err
+	}
+	return &pbempty.Empty{}, nil
+}
+
+// Cancel signals the provider to abort all outstanding resource operations.
+func (p *Provider) Cancel(ctx context.Context, req *pbempty.Empty) (*pbempty.Empty, error) {
+	p.cancelOnce.Do(func() { close(p.canceled) })
+	return &pbempty.Empty{}, nil
+}
+
+// GetPluginInfo returns generic information about this plugin, like its version.
+func (p *Provider) GetPluginInfo(ctx context.Context, req *pbempty.Empty) (*pulumirpc.PluginInfo, error) {
+	return &pulumirpc.PluginInfo{Version: p.version}, nil
+}
+
+// decode decodes the given properties into a new value of the resource type's struct type and returns a pointer to
+// it.  Unknown properties are left unset.  If strict is true, properties that are missing or that the type does not
+// declare are reported as failures; this is only appropriate for new inputs, as state may have been written by a
+// different version of the resource type.  Any failures to decode the properties are returned as check failures.
+func (rt *resourceType) decode(props resource.PropertyMap, strict bool) (interface{}, []plugin.CheckFailure) {
+	obj, unknowns := make(map[string]interface{}), make(map[string]bool)
+	for k, v := range props {
+		if v.ContainsUnknowns() {
+			unknowns[string(k)] = true
+			continue
+		}
+		obj[string(k)] = v.MapRepl(nil, mappableValue)
+	}
+
+	target := reflect.New(rt.typ)
+	err := newMapper(strict).Decode(obj, target.Interface())
+	if err == nil {
+		return target.Interface(), nil
+	}
+
+	var failures []plugin.CheckFailure
+	for _, failure := range err.Failures() {
+		if missing, ok := failure.(*mapper.MissingError); ok && unknowns[missing.Field()] {
+			continue
+		}
+		checkFailure := plugin.CheckFailure{Reason: failure.Error()}
+		if ferr, ok := failure.(mapper.FieldError); ok {
+			checkFailure.Property, checkFailure.Reason = resource.PropertyKey(ferr.Field()), ferr.Reason()
+		}
+		failures = append(failures, checkFailure)
+	}
+	if len(failures) == 0 {
+		return target.Interface(), nil
+	}
+	return nil, failures
+}
+
+// diff compares the given state and inputs of a resource that does not implement Differ.  Both are compared as
+// values of the resource type's struct type, so that properties the type does not declare are ignored and inputs that
+// have been removed are noticed; output properties are not compared, as they never appear in the inputs.
+func (rt *resourceType) diff(oldObj, newObj interface{}) (plugin.DiffResult, error) {
+	olds, err := rt.encode(oldObj)
+	if err != nil {
+		return plugin.DiffResult{}, errors.Wrap(err, "encoding state")
+	}
+	news, err := rt.encode(newObj)
+	if err != nil {
+		return plugin.DiffResult{}, errors.Wrap(err, "encoding inputs")
+	}
+
+	result := plugin.DiffResult{Changes: plugin.DiffNone}
+	for _, props := range []resource.PropertyMap{olds, news} {
+		for k := range props {
+			if !rt.outputs[k] && !olds[k].DeepEquals(news[k]) {
+				result.Changes = plugin.DiffSome
+			}
+		}
+	}
+	return result, nil
+}
+
+// mappableValue replaces nested unknown values with nil and secret values with their plaintext so that properties may
+// be decoded into Go values.
+func mappableValue(v resource.PropertyValue) (interface{}, bool) {
+	switch {
+	case v.IsComputed() || v.IsOutput():
+		return nil, true
+	case v.IsSecret():
+		return v.SecretValue().Element.MapRepl(nil, mappableValue), true
+	default:
+		return nil, false
+	}
+}
+
+// outputTag is the struct tag option that marks a property as an output of a resource rather than one of its inputs.
+// Outputs are necessarily optional.
+const outputTag = "output"
+
+// newMapper returns a mapper for resource properties that recognizes outputTag.  A strict mapper rejects missing and
+// unrecognized properties.
+func newMapper(strict bool) mapper.Mapper {
+	return mapper.New(&mapper.Opts{
+		IgnoreMissing:      !strict,
+		IgnoreUnrecognized: !strict,
+		OptionalTags:       []string{"omitempty", "optional", outputTag},
+	})
+}
+
+// encode encodes the given value of the resource type's struct type as a property map.
+func (rt *resourceType) encode(obj interface{}) (resource.PropertyMap, error) {
+	if t := reflect.TypeOf(obj); t != reflect.PtrTo(rt.typ) && t != rt.typ {
+		return nil, errors.Errorf("expected a value of type %v, got %v", rt.typ, t)
+	}
+	m, err := newMapper(false).Encode(obj)
+	if err != nil {
+		return nil, err
+	}
+	return resource.NewPropertyMapFromMap(m), nil
+}
+
+// marshalState encodes and marshals the given state of a resource.
+func (rt *resourceType) marshalState(label string, state interface{}) (*_struct.Struct, error) {
+	props, err := rt.encode(state)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s: encoding state", label)
+	}
+	return marshalProperties(label, props)
+}
+
+// resourceError translates an error returned by a resource's Create or Update operation into an error to return to
+// the engine.  If the resource failed to initialize, the error carries the resource's ID and state.
+func (rt *resourceType) resourceError(label string, id resource.ID, state interface{}, err error) error {
+	initErr, ok := errors.Cause(err).(*InitError)
+	if !ok || isNil(state) {
+		return err
+	}
+
+	rpcState, merr := rt.marshalState(label+".state", state)
+	if merr != nil {
+		return err
+	}
+	return rpcerror.WithDetails(rpcerror.New(codes.Unknown, err.Error()), &pulumirpc.ErrorResourceInitFailed{
+		Id:         string(id),
+		Properties: rpcState,
+		Reasons:    initErr.Reasons,
+	})
+}
+
+// isNil returns true if the given value is nil or a nil pointer.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+func unmarshalProperties(label string, props *_struct.Struct) (resource.PropertyMap, error) {
+	return plugin.UnmarshalProperties(props, plugin.MarshalOptions{Label: label, KeepUnknowns: true})
+}
+
+func marshalProperties(label string, props resource.PropertyMap) (*_struct.Struct, error) {
+	return plugin.MarshalProperties(props, plugin.MarshalOptions{Label: label, KeepUnknowns: true})
+}
+
+func marshalCheckFailures(failures []plugin.CheckFailure) []*pulumirpc.CheckFailure {
+	result := make([]*pulumirpc.CheckFailure, len(failures))
+	for i, failure := range failures {
+		result[i] = &pulumirpc.CheckFailure{Property: string(failure.Property), Reason: failure.Reason}
+	}
+	return result
+}
+
+func marshalDiffResult(result plugin.DiffResult) *pulumirpc.DiffResponse {
+	var replaces, stables []string
+	for _, k := range result.ReplaceKeys {
+		replaces = append(replaces, string(k))
+	}
+	for _, k := range result.StableKeys {
+		stables = append(stables, string(k))
+	}
+	sort.Strings(replaces)
+	sort.Strings(stables)
+
+	var detailedDiff map[string]*pulumirpc.PropertyDiff
+	if len(result.DetailedDiff) > 0 {
+		detailedDiff = make(map[string]*pulumirpc.PropertyDiff)
+		for path, diff := range result.DetailedDiff {
+			detailedDiff[path] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_Kind(diff.Kind)}
+		}
+	}
+
+	return &pulumirpc.DiffResponse{
+		Replaces:            replaces,
+		Stables:             stables,
+		DeleteBeforeReplace: result.DeleteBeforeReplace,
+		Changes:             pulumirpc.DiffResponse_DiffChanges(result.Changes),
+		DetailedDiff:        detailedDiff,
+	}
+}
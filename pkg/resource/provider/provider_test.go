@@ -0,0 +1,365 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil/rpcerror"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+type bucket struct {
+	Name   string            `pulumi:"name"`
+	Size   float64           `pulumi:"size,optional"`
+	Tags   map[string]string `pulumi:"tags,optional"`
+	Status string            `pulumi:"status,output"`
+}
+
+type bucketResource struct {
+	create func(ctx context.Context, inputs *bucket) (resource.ID, interface{}, error)
+	check  func(olds, news *bucket) (interface{}, []plugin.CheckFailure, error)
+}
+
+func (r *bucketResource) Create(ctx context.Context, urn resource.URN,
+	inputs interface{}) (resource.ID, interface{}, error) {
+
+	return r.create(ctx, inputs.(*bucket))
+}
+
+func (r *bucketResource) Read(ctx context.Context, urn resource.URN, id resource.ID,
+	state interface{}) (interface{}, error) {
+
+	return state, nil
+}
+
+func (r *bucketResource) Update(ctx context.Context, urn resource.URN, id resource.ID,
+	olds, news interface{}) (interface{}, error) {
+
+	return news, nil
+}
+
+func (r *bucketResource) Delete(ctx context.Context, urn resource.URN, id resource.ID, state interface{}) error {
+	return nil
+}
+
+type checkedBucketResource struct {
+	bucketResource
+}
+
+func (r *checkedBucketResource) Check(ctx context.Context, urn resource.URN,
+	olds, news interface{}) (interface{}, []plugin.CheckFailure, error) {
+
+	o, _ := olds.(*bucket)
+	return r.check(o, news.(*bucket))
+}
+
+type differBucketResource struct {
+	bucketResource
+	diff func(ctx context.Context) (plugin.DiffResult, error)
+}
+
+func (r *differBucketResource) Diff(ctx context.Context, urn resource.URN, id resource.ID,
+	olds, news interface{}) (plugin.DiffResult, error) {
+
+	return r.diff(ctx)
+}
+
+const bucketURN = "urn:pulumi:stack::project::test:index:Bucket::b"
+
+func newBucketProvider(res Resource) *Provider {
+	p := NewProvider(nil, tokens.Package("test"), "0.1.0", nil)
+	p.RegisterResource(tokens.Type("test:index:Bucket"), bucket{}, res)
+	return p
+}
+
+func marshal(t *testing.T, props resource.PropertyMap) *_struct.Struct {
+	s, err := marshalProperties("test", props)
+	assert.NoError(t, err)
+	return s
+}
+
+func unmarshal(t *testing.T, s *_struct.Struct) resource.PropertyMap {
+	props, err := unmarshalProperties("test", s)
+	assert.NoError(t, err)
+	return props
+}
+
+func TestCheck(t *testing.T) {
+	p := newBucketProvider(&checkedBucketResource{bucketResource{
+		check: func(olds, news *bucket) (interface{}, []plugin.CheckFailure, error) {
+			if news.Size < 0 {
+				return nil, []plugin.CheckFailure{{Property: "size", Reason: "size must not be negative"}}, nil
+			}
+			news.Size = 10
+			return news, nil, nil
+		},
+	}})
+
+	// Inputs that do not match the resource's type are reported as failures.
+	resp, err := p.Check(context.Background(), &pulumirpc.CheckRequest{
+		Urn:  bucketURN,
+		News: marshal(t, resource.PropertyMap{"size": resource.NewNumberProperty(1)}),
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.GetFailures(), 1) {
+		assert.Equal(t, "name", resp.GetFailures()[0].GetProperty())
+	}
+
+	// Inputs that the resource's type does not declare are reported as failures.
+	resp, err = p.Check(context.Background(), &pulumirpc.CheckRequest{
+		Urn: bucketURN,
+		News: marshal(t, resource.PropertyMap{
+			"name":  resource.NewStringProperty("b"),
+			"color": resource.NewStringProperty("red"),
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetFailures(), 1)
+
+	// Failures returned by the resource are reported.
+	resp, err = p.Check(context.Background(), &pulumirpc.CheckRequest{
+		Urn: bucketURN,
+		News: marshal(t, resource.PropertyMap{
+			"name": resource.NewStringProperty("b"),
+			"size": resource.NewNumberProperty(-1),
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*pulumirpc.CheckFailure{{Property: "size", Reason: "size must not be negative"}},
+		resp.GetFailures())
+
+	// Unknown inputs are not passed to the resource, but are preserved in the checked inputs.
+	resp, err = p.Check(context.Background(), &pulumirpc.CheckRequest{
+		Urn: bucketURN,
+		News: marshal(t, resource.PropertyMap{
+			"name": resource.MakeComputed(resource.NewStringProperty("")),
+			"tags": resource.NewObjectProperty(resource.PropertyMap{
+				"owner": resource.MakeComputed(resource.NewStringProperty("")),
+			}),
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.GetFailures())
+	inputs := unmarshal(t, resp.GetInputs())
+	assert.True(t, inputs["name"].IsComputed())
+	assert.True(t, inputs["tags"].ContainsUnknowns())
+	assert.Equal(t, resource.NewNumberProperty(10), inputs["size"])
+}
+
+func TestDiff(t *testing.T) {
+	p := newBucketProvider(&bucketResource{})
+
+	olds := marshal(t, resource.PropertyMap{
+		"name":   resource.NewStringProperty("b"),
+		"status": resource.NewStringProperty("ready"),
+	})
+
+	resp, err := p.Diff(context.Background(), &pulumirpc.DiffRequest{
+		Urn:  bucketURN,
+		Olds: olds,
+		News: marshal(t, resource.PropertyMap{"name": resource.NewStringProperty("b")}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pulumirpc.DiffResponse_DIFF_NONE, resp.GetChanges())
+
+	resp, err = p.Diff(context.Background(), &pulumirpc.DiffRequest{
+		Urn:  bucketURN,
+		Olds: olds,
+		News: marshal(t, resource.PropertyMap{"name": resource.MakeComputed(resource.NewStringProperty(""))}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pulumirpc.DiffResponse_DIFF_SOME, resp.GetChanges())
+}
+
+func TestDiffRemovedInput(t *testing.T) {
+	p := newBucketProvider(&bucketResource{})
+
+	olds := marshal(t, resource.PropertyMap{
+		"name":   resource.NewStringProperty("b"),
+		"size":   resource.NewNumberProperty(10),
+		"tags":   resource.NewObjectProperty(resource.PropertyMap{"env": resource.NewStringProperty("dev")}),
+		"status": resource.NewStringProperty("ready"),
+	})
+
+	// Removing an optional input is a change, even though the new inputs contain nothing that differs.
+	for _, removed := range []resource.PropertyKey{"size", "tags"} {
+		news := resource.PropertyMap{
+			"name": resource.NewStringProperty("b"),
+			"size": resource.NewNumberProperty(10),
+			"tags": resource.NewObjectProperty(resource.PropertyMap{"env": resource.NewStringProperty("dev")}),
+		}
+		delete(news, removed)
+
+		resp, err := p.Diff(context.Background(), &pulumirpc.DiffRequest{
+			Urn:  bucketURN,
+			Olds: olds,
+			News: marshal(t, news),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, pulumirpc.DiffResponse_DIFF_SOME, resp.GetChanges(), string(removed))
+	}
+
+	// Outputs never appear in the inputs, so they are not compared.
+	resp, err := p.Diff(context.Background(), &pulumirpc.DiffRequest{
+		Urn:  bucketURN,
+		Olds: olds,
+		News: marshal(t, resource.PropertyMap{
+			"name": resource.NewStringProperty("b"),
+			"size": resource.NewNumberProperty(10),
+			"tags": resource.NewObjectProperty(resource.PropertyMap{"env": resource.NewStringProperty("dev")}),
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pulumirpc.DiffResponse_DIFF_NONE, resp.GetChanges())
+}
+
+func TestDecodeState(t *testing.T) {
+	p := newBucketProvider(&bucketResource{})
+
+	// State may contain properties that the resource's type no longer declares, and may lack properties that it
+	// now requires, e.g. if it was written by an older version of the provider.
+	state := marshal(t, resource.PropertyMap{
+		"size":   resource.NewNumberProperty(10),
+		"region": resource.NewStringProperty("us-west-2"),
+	})
+
+	readResp, err := p.Read(context.Background(), &pulumirpc.ReadRequest{
+		Id:         "b-1234",
+		Urn:        bucketURN,
+		Properties: state,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, resource.NewNumberProperty(10), unmarshal(t, readResp.GetProperties())["size"])
+
+	diffResp, err := p.Diff(context.Background(), &pulumirpc.DiffRequest{
+		Id:   "b-1234",
+		Urn:  bucketURN,
+		Olds: state,
+		News: marshal(t, resource.PropertyMap{
+			"name": resource.NewStringProperty("b"),
+			"size": resource.NewNumberProperty(10),
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pulumirpc.DiffResponse_DIFF_SOME, diffResp.GetChanges())
+
+	_, err = p.Delete(context.Background(), &pulumirpc.DeleteRequest{
+		Id:         "b-1234",
+		Urn:        bucketURN,
+		Properties: state,
+	})
+	assert.NoError(t, err)
+}
+
+func TestCreateInitError(t *testing.T) {
+	p := newBucketProvider(&bucketResource{
+		create: func(ctx context.Context, inputs *bucket) (resource.ID, interface{}, error) {
+			inputs.Status = "unhealthy"
+			return "b-1234", inputs, &InitError{Reasons: []string{"bucket is unhealthy"}}
+		},
+	})
+
+	_, err := p.Create(context.Background(), &pulumirpc.CreateRequest{
+		Urn:        bucketURN,
+		Properties: marshal(t, resource.PropertyMap{"name": resource.NewStringProperty("b")}),
+	})
+	assert.Error(t, err)
+
+	rpcErr, ok := rpcerror.FromError(err)
+	if !assert.True(t, ok) || !assert.Len(t, rpcErr.Details(), 1) {
+		return
+	}
+	initErr, ok := rpcErr.Details()[0].(*pulumirpc.ErrorResourceInitFailed)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, "b-1234", initErr.GetId())
+	assert.Equal(t, []string{"bucket is unhealthy"}, initErr.GetReasons())
+	state := unmarshal(t, initErr.GetProperties())
+	assert.Equal(t, resource.NewStringProperty("unhealthy"), state["status"])
+}
+
+func TestCancel(t *testing.T) {
+	started := make(chan struct{})
+	p := newBucketProvider(&bucketResource{
+		create: func(ctx context.Context, inputs *bucket) (resource.ID, interface{}, error) {
+			close(started)
+			<-ctx.Done()
+			return "", nil, ctx.Err()
+		},
+	})
+
+	done := make(chan error)
+	go func() {
+		_, err := p.Create(context.Background(), &pulumirpc.CreateRequest{
+			Urn:        bucketURN,
+			Properties: marshal(t, resource.PropertyMap{"name": resource.NewStringProperty("b")}),
+		})
+		done <- err
+	}()
+
+	<-started
+	_, err := p.Cancel(context.Background(), &pbempty.Empty{})
+	assert.NoError(t, err)
+
+	select {
+	case err = <-done:
+		assert.Equal(t, context.Canceled, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("operation was not canceled")
+	}
+}
+
+func TestCancelDiff(t *testing.T) {
+	started := make(chan struct{})
+	p := newBucketProvider(&differBucketResource{
+		diff: func(ctx context.Context) (plugin.DiffResult, error) {
+			close(started)
+			<-ctx.Done()
+			return plugin.DiffResult{}, ctx.Err()
+		},
+	})
+
+	done := make(chan error)
+	go func() {
+		_, err := p.Diff(context.Background(), &pulumirpc.DiffRequest{
+			Urn:  bucketURN,
+			Olds: marshal(t, resource.PropertyMap{"name": resource.NewStringProperty("a")}),
+			News: marshal(t, resource.PropertyMap{"name": resource.NewStringProperty("b")}),
+		})
+		done <- err
+	}()
+
+	<-started
+	_, err := p.Cancel(context.Background(), &pbempty.Empty{})
+	assert.NoError(t, err)
+
+	select {
+	case err = <-done:
+		assert.Equal(t, context.Canceled, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("diff was not canceled")
+	}
+}
@@ -21,29 +21,56 @@ import (
 // Goal is a desired state for a resource object.  Normally it represents a subset of the resource's state expressed by
 // a program, however if Output is true, it represents a more complete, post-deployment view of the state.
 type Goal struct {
-	Type         tokens.Type  // the type of resource.
-	Name         tokens.QName // the name for the resource's URN.
-	Custom       bool         // true if this resource is custom, managed by a plugin.
-	Properties   PropertyMap  // the resource's property state.
-	Parent       URN          // an optional parent URN for this resource.
-	Protect      bool         // true to protect this resource from deletion.
-	Dependencies []URN        // dependencies of this resource object.
-	Provider     string       // the provider to use for this resource.
-	InitErrors   []string     // errors encountered as we attempted to initialize the resource.
+	Type                tokens.Type    // the type of resource.
+	Name                tokens.QName   // the name for the resource's URN.
+	Custom              bool           // true if this resource is custom, managed by a plugin.
+	Properties          PropertyMap    // the resource's property state.
+	Parent              URN            // an optional parent URN for this resource.
+	Protect             bool           // true to protect this resource from deletion.
+	Dependencies        []URN          // dependencies of this resource object.
+	Provider            string         // the provider to use for this resource.
+	InitErrors          []string       // errors encountered as we attempted to initialize the resource.
+	ID                  ID             // the ID of an existing resource to import, if any.
+	IgnoreChanges       []string       // a list of property paths whose changes should be ignored.
+	Aliases             []URN          // additional URNs that this resource may have been known by.
+	CustomTimeouts      CustomTimeouts // custom timeouts for the resource's create, update and delete operations.
+	DeleteBeforeReplace bool           // true if this resource must be deleted before its replacement is created.
+	RetainOnDelete      bool           // true if this resource should be dropped from the state rather than deleted.
 }
 
 // NewGoal allocates a new resource goal state.
 func NewGoal(t tokens.Type, name tokens.QName, custom bool, props PropertyMap,
-	parent URN, protect bool, dependencies []URN, provider string, initErrors []string) *Goal {
+	parent URN, protect bool, dependencies []URN, provider string, initErrors []string, id ID,
+	ignoreChanges []string, aliases []URN, customTimeouts CustomTimeouts, deleteBeforeReplace bool,
+	retainOnDelete bool) *Goal {
 	return &Goal{
-		Type:         t,
-		Name:         name,
-		Custom:       custom,
-		Properties:   props,
-		Parent:       parent,
-		Protect:      protect,
-		Dependencies: dependencies,
-		Provider:     provider,
-		InitErrors:   initErrors,
+		Type:                t,
+		Name:                name,
+		Custom:              custom,
+		Properties:          props,
+		Parent:              parent,
+		Protect:             protect,
+		Dependencies:        dependencies,
+		Provider:            provider,
+		InitErrors:          initErrors,
+		ID:                  id,
+		IgnoreChanges:       ignoreChanges,
+		Aliases:             aliases,
+		CustomTimeouts:      customTimeouts,
+		DeleteBeforeReplace: deleteBeforeReplace,
+		RetainOnDelete:      retainOnDelete,
 	}
 }
+
+// CustomTimeouts overrides the default timeouts for a resource's create, update and delete operations. Each timeout is
+// represented in seconds; a value of zero uses the provider's default.
+type CustomTimeouts struct {
+	Create float64 `json:"create,omitempty" yaml:"create,omitempty"`
+	Update float64 `json:"update,omitempty" yaml:"update,omitempty"`
+	Delete float64 `json:"delete,omitempty" yaml:"delete,omitempty"`
+}
+
+// IsZero returns true if none of the timeouts have been overridden.
+func (c CustomTimeouts) IsZero() bool {
+	return c == CustomTimeouts{}
+}
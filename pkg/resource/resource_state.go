@@ -36,29 +36,40 @@ type State struct {
 	Dependencies []URN       // the resource's dependencies
 	InitErrors   []string    // the set of errors encountered in the process of initializing resource.
 	Provider     string      // the provider to use for this resource.
+
+	// CustomTimeouts records the timeouts for the resource's create, update and delete operations, if any.
+	CustomTimeouts CustomTimeouts
+	// DeleteBeforeReplace is true if the resource must be deleted before its replacement is created.
+	DeleteBeforeReplace bool
+	// RetainOnDelete is true if the resource should be dropped from the state rather than deleted by its provider.
+	RetainOnDelete bool
 }
 
 // NewState creates a new resource value from existing resource state information.
 func NewState(t tokens.Type, urn URN, custom bool, del bool, id ID,
 	inputs PropertyMap, outputs PropertyMap, parent URN, protect bool,
-	external bool, dependencies []URN, initErrors []string, provider string) *State {
+	external bool, dependencies []URN, initErrors []string, provider string, customTimeouts CustomTimeouts,
+	deleteBeforeReplace bool, retainOnDelete bool) *State {
 	contract.Assertf(t != "", "type was empty")
 	contract.Assertf(custom || id == "", "is custom or had empty ID")
 	contract.Assertf(inputs != nil, "inputs was non-nil")
 	return &State{
-		Type:         t,
-		URN:          urn,
-		Custom:       custom,
-		Delete:       del,
-		ID:           id,
-		Inputs:       inputs,
-		Outputs:      outputs,
-		Parent:       parent,
-		Protect:      protect,
-		External:     external,
-		Dependencies: dependencies,
-		InitErrors:   initErrors,
-		Provider:     provider,
+		Type:                t,
+		URN:                 urn,
+		Custom:              custom,
+		Delete:              del,
+		ID:                  id,
+		Inputs:              inputs,
+		Outputs:             outputs,
+		Parent:              parent,
+		Protect:             protect,
+		External:            external,
+		Dependencies:        dependencies,
+		InitErrors:          initErrors,
+		Provider:            provider,
+		CustomTimeouts:      customTimeouts,
+		DeleteBeforeReplace: deleteBeforeReplace,
+		RetainOnDelete:      retainOnDelete,
 	}
 }
 
@@ -69,12 +69,18 @@ func UnmarshalVersionedCheckpointToLatestCheckpoint(bytes []byte) (*apitype.Chec
 	}
 }
 
-// SerializeCheckpoint turns a snapshot into a data structure suitable for serialization.
-func SerializeCheckpoint(stack tokens.QName, config config.Map, snap *deploy.Snapshot) *apitype.VersionedCheckpoint {
+// SerializeCheckpoint turns a snapshot into a data structure suitable for serialization. Any secret values in the
+// snapshot are encrypted using the given encrypter.
+func SerializeCheckpoint(stack tokens.QName, config config.Map, snap *deploy.Snapshot,
+	enc config.Encrypter) (*apitype.VersionedCheckpoint, error) {
 	// If snap is nil, that's okay, we will just create an empty deployment; otherwise, serialize the whole snapshot.
 	var latest *apitype.DeploymentV2
 	if snap != nil {
-		latest = SerializeDeployment(snap)
+		dep, err := SerializeDeployment(snap, enc)
+		if err != nil {
+			return nil, errors.Wrap(err, "serializing deployment")
+		}
+		latest = dep
 	}
 
 	b, err := json.Marshal(apitype.CheckpointV2{
@@ -87,29 +93,61 @@ func SerializeCheckpoint(stack tokens.QName, config config.Map, snap *deploy.Sna
 	return &apitype.VersionedCheckpoint{
 		Version:    apitype.DeploymentSchemaVersionCurrent,
 		Checkpoint: json.RawMessage(b),
-	}
+	}, nil
 }
 
 // DeserializeCheckpoint takes a serialized deployment record and returns its associated snapshot. Returns nil
-// if there have been no deployments performed on this checkpoint.
-func DeserializeCheckpoint(chkpoint *apitype.CheckpointV2) (*deploy.Snapshot, error) {
+// if there have been no deployments performed on this checkpoint. Any secret values in the deployment are decrypted
+// using the given decrypter.
+func DeserializeCheckpoint(chkpoint *apitype.CheckpointV2, dec config.Decrypter) (*deploy.Snapshot, error) {
 	contract.Require(chkpoint != nil, "chkpoint")
 	if chkpoint.Latest != nil {
-		return DeserializeDeploymentV2(*chkpoint.Latest)
+		return DeserializeDeploymentV2(*chkpoint.Latest, dec)
 	}
 
 	return nil, nil
 }
 
 // GetRootStackResource returns the root stack resource from a given snapshot, or nil if not found.  If the stack
-// exists, its output properties, if any, are also returned in the resulting map.
+// exists, its output properties, if any, are also returned in the resulting map. Secret outputs are replaced with the
+// string "[secret]".
 func GetRootStackResource(snap *deploy.Snapshot) (*resource.State, map[string]interface{}) {
 	if snap != nil {
 		for _, res := range snap.Resources {
 			if res.Type == resource.RootStackType {
-				return res, SerializeResource(res).Outputs
+				var outputs map[string]interface{}
+				if res.Outputs != nil {
+					blinded := BlindSecrets(resource.NewObjectProperty(res.Outputs)).ObjectValue()
+					serialized, err := SerializeProperties(blinded, config.NewPanicCrypter())
+					contract.AssertNoError(err)
+					outputs = serialized
+				}
+				return res, outputs
 			}
 		}
 	}
 	return nil, nil
 }
+
+// BlindSecrets returns a copy of the given property value in which each secret has been replaced with the string
+// "[secret]".
+func BlindSecrets(v resource.PropertyValue) resource.PropertyValue {
+	switch {
+	case v.IsSecret():
+		return resource.NewStringProperty("[secret]")
+	case v.IsArray():
+		arr := make([]resource.PropertyValue, len(v.ArrayValue()))
+		for i, elem := range v.ArrayValue() {
+			arr[i] = BlindSecrets(elem)
+		}
+		return resource.NewArrayProperty(arr)
+	case v.IsObject():
+		obj := make(resource.PropertyMap)
+		for k, elem := range v.ObjectValue() {
+			obj[k] = BlindSecrets(elem)
+		}
+		return resource.NewObjectProperty(obj)
+	default:
+		return v
+	}
+}
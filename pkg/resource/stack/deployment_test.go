@@ -21,6 +21,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/tokens"
 )
 
@@ -76,9 +77,13 @@ func TestDeploymentSerialization(t *testing.T) {
 		},
 		[]string{},
 		"",
+		resource.CustomTimeouts{Create: 600, Delete: 30},
+		true,
+		false,
 	)
 
-	dep := SerializeResource(res)
+	dep, err := SerializeResource(res, config.NewPanicCrypter())
+	assert.NoError(t, err)
 
 	// assert some things about the deployment record:
 	assert.NotNil(t, dep)
@@ -88,6 +93,9 @@ func TestDeploymentSerialization(t *testing.T) {
 	assert.Equal(t, 2, len(dep.Dependencies))
 	assert.Equal(t, resource.URN("foo:bar:baz"), dep.Dependencies[0])
 	assert.Equal(t, resource.URN("foo:bar:boo"), dep.Dependencies[1])
+	assert.Equal(t, &resource.CustomTimeouts{Create: 600, Delete: 30}, dep.CustomTimeouts)
+	assert.True(t, dep.DeleteBeforeReplace)
+	assert.False(t, dep.RetainOnDelete)
 
 	// assert some things about the inputs:
 	assert.NotNil(t, dep.Inputs)
@@ -147,12 +155,40 @@ func TestDeploymentSerialization(t *testing.T) {
 	assert.Equal(t, 0, len(dep.Outputs["out-empty-map"].(map[string]interface{})))
 }
 
+// TestSecretSerialization ensures that secret values are encrypted when serialized and recovered when deserialized.
+func TestSecretSerialization(t *testing.T) {
+	crypter := config.NewSymmetricCrypter(make([]byte, config.SymmetricCrypterKeyBytes))
+
+	props := resource.PropertyMap{
+		"plain": resource.NewStringProperty("visible"),
+		"secret": resource.MakeSecret(resource.NewObjectProperty(resource.PropertyMap{
+			"password": resource.NewStringProperty("hunter2"),
+			"ports":    resource.NewArrayProperty([]resource.PropertyValue{resource.NewNumberProperty(80)}),
+		})),
+	}
+
+	serialized, err := SerializeProperties(props, crypter)
+	assert.NoError(t, err)
+	assert.Equal(t, "visible", serialized["plain"])
+
+	// The secret must be recorded as ciphertext tagged with the secret signature.
+	secret, ok := serialized["secret"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, resource.SecretSig, secret[string(resource.SigKey)])
+	assert.NotContains(t, secret["ciphertext"], "hunter2")
+
+	deserialized, err := DeserializeProperties(serialized, crypter)
+	assert.NoError(t, err)
+	assert.True(t, deserialized["secret"].IsSecret())
+	assert.True(t, props.DeepEquals(deserialized))
+}
+
 func TestLoadTooNewDeployment(t *testing.T) {
 	untypedDeployment := &apitype.UntypedDeployment{
 		Version: apitype.DeploymentSchemaVersionCurrent + 1,
 	}
 
-	deployment, err := DeserializeUntypedDeployment(untypedDeployment)
+	deployment, err := DeserializeUntypedDeployment(untypedDeployment, config.NopDecrypter)
 	assert.Nil(t, deployment)
 	assert.Error(t, err)
 	assert.Equal(t, ErrDeploymentSchemaVersionTooNew, err)
@@ -163,7 +199,7 @@ func TestLoadTooOldDeployment(t *testing.T) {
 		Version: DeploymentSchemaVersionOldestSupported - 1,
 	}
 
-	deployment, err := DeserializeUntypedDeployment(untypedDeployment)
+	deployment, err := DeserializeUntypedDeployment(untypedDeployment, config.NopDecrypter)
 	assert.Nil(t, deployment)
 	assert.Error(t, err)
 	assert.Equal(t, ErrDeploymentSchemaVersionTooOld, err)
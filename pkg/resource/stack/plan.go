@@ -0,0 +1,158 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+)
+
+// ErrPlanSchemaVersionTooNew is returned from `DeserializePlan` if the plan was saved by a newer version of the CLI.
+var ErrPlanSchemaVersionTooNew = fmt.Errorf("this plan's version is too new")
+
+// SerializePlan serializes an update plan so that it may be saved. Secret values are encrypted using the given
+// encrypter, and values that were unknown during the preview are recorded as the unknown sentinel string.
+func SerializePlan(plan *deploy.UpdatePlan, enc config.Encrypter) (*apitype.VersionedPlan, error) {
+	steps := make([]apitype.PlannedStepV1, len(plan.Steps))
+	for i, step := range plan.Steps {
+		var err error
+		steps[i] = apitype.PlannedStepV1{Op: apitype.OpType(step.Op), URN: string(step.URN)}
+		if step.OldInputs != nil {
+			if steps[i].OldInputs, err = serializePlannedInputs(step.OldInputs, enc); err != nil {
+				return nil, err
+			}
+		}
+		if step.NewInputs != nil {
+			if steps[i].NewInputs, err = serializePlannedInputs(step.NewInputs, enc); err != nil {
+				return nil, err
+			}
+		}
+		for _, k := range step.Keys {
+			steps[i].Keys = append(steps[i].Keys, string(k))
+		}
+	}
+
+	bytes, err := json.Marshal(apitype.PlanV1{Steps: steps})
+	if err != nil {
+		return nil, err
+	}
+	return &apitype.VersionedPlan{Version: apitype.PlanSchemaVersionCurrent, Plan: bytes}, nil
+}
+
+// DeserializePlan deserializes a saved update plan. Secret values are decrypted using the given decrypter.
+func DeserializePlan(versioned *apitype.VersionedPlan, dec config.Decrypter) (*deploy.UpdatePlan, error) {
+	switch {
+	case versioned.Version > apitype.PlanSchemaVersionCurrent:
+		return nil, ErrPlanSchemaVersionTooNew
+	case versioned.Version < 1:
+		return nil, errors.Errorf("unrecognized plan version %d", versioned.Version)
+	}
+
+	var v1 apitype.PlanV1
+	if err := json.Unmarshal(versioned.Plan, &v1); err != nil {
+		return nil, err
+	}
+
+	plan := &deploy.UpdatePlan{}
+	for _, step := range v1.Steps {
+		planned := deploy.PlannedStep{Op: deploy.StepOp(step.Op), URN: resource.URN(step.URN)}
+		if step.OldInputs != nil {
+			inputs, err := DeserializeProperties(step.OldInputs, dec)
+			if err != nil {
+				return nil, err
+			}
+			planned.OldInputs = restoreUnknowns(inputs)
+		}
+		if step.NewInputs != nil {
+			inputs, err := DeserializeProperties(step.NewInputs, dec)
+			if err != nil {
+				return nil, err
+			}
+			planned.NewInputs = restoreUnknowns(inputs)
+		}
+		for _, k := range step.Keys {
+			planned.Keys = append(planned.Keys, resource.PropertyKey(k))
+		}
+		plan.Steps = append(plan.Steps, planned)
+	}
+	return plan, nil
+}
+
+// serializePlannedInputs serializes a resource's planned inputs. Unlike SerializeProperties, it preserves unknown
+// values, which a saved plan must be able to distinguish from missing values.
+func serializePlannedInputs(inputs resource.PropertyMap, enc config.Encrypter) (map[string]interface{}, error) {
+	return SerializeProperties(replaceUnknowns(resource.NewObjectProperty(inputs)).ObjectValue(), enc)
+}
+
+// replaceUnknowns returns a copy of the given value in which each unknown value has been replaced with the unknown
+// sentinel string.
+func replaceUnknowns(v resource.PropertyValue) resource.PropertyValue {
+	switch {
+	case v.IsComputed() || v.IsOutput():
+		return resource.NewStringProperty(plugin.UnknownStringValue)
+	case v.IsSecret():
+		return resource.MakeSecret(replaceUnknowns(v.SecretValue().Element))
+	case v.IsArray():
+		arr := make([]resource.PropertyValue, len(v.ArrayValue()))
+		for i, elem := range v.ArrayValue() {
+			arr[i] = replaceUnknowns(elem)
+		}
+		return resource.NewArrayProperty(arr)
+	case v.IsObject():
+		obj := make(resource.PropertyMap)
+		for k, elem := range v.ObjectValue() {
+			obj[k] = replaceUnknowns(elem)
+		}
+		return resource.NewObjectProperty(obj)
+	default:
+		return v
+	}
+}
+
+// restoreUnknowns is the inverse of replaceUnknowns.
+func restoreUnknowns(props resource.PropertyMap) resource.PropertyMap {
+	result := make(resource.PropertyMap)
+	for k, v := range props {
+		result[k] = restoreUnknown(v)
+	}
+	return result
+}
+
+func restoreUnknown(v resource.PropertyValue) resource.PropertyValue {
+	switch {
+	case v.IsString() && v.StringValue() == plugin.UnknownStringValue:
+		return resource.MakeComputed(resource.NewStringProperty(""))
+	case v.IsSecret():
+		return resource.MakeSecret(restoreUnknown(v.SecretValue().Element))
+	case v.IsArray():
+		arr := make([]resource.PropertyValue, len(v.ArrayValue()))
+		for i, elem := range v.ArrayValue() {
+			arr[i] = restoreUnknown(elem)
+		}
+		return resource.NewArrayProperty(arr)
+	case v.IsObject():
+		return resource.NewObjectProperty(restoreUnknowns(v.ObjectValue()))
+	default:
+		return v
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+func TestPlanSerialization(t *testing.T) {
+	plan := &deploy.UpdatePlan{Steps: []deploy.PlannedStep{
+		{
+			Op:  deploy.OpReplace,
+			URN: "urn:pulumi:test::test::pkgA:m:typA::resA",
+			OldInputs: resource.PropertyMap{
+				"foo": resource.NewStringProperty("bar"),
+			},
+			NewInputs: resource.PropertyMap{
+				"foo":      resource.NewStringProperty("baz"),
+				"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+				"nested": resource.NewArrayProperty([]resource.PropertyValue{
+					resource.MakeComputed(resource.NewStringProperty("")),
+				}),
+			},
+			Keys: []resource.PropertyKey{"foo"},
+		},
+		{
+			Op:  deploy.OpCreate,
+			URN: "urn:pulumi:test::test::pkgA:m:typA::resB",
+		},
+	}}
+
+	crypter := config.NewSymmetricCrypter(make([]byte, config.SymmetricCrypterKeyBytes))
+	versioned, err := SerializePlan(plan, crypter)
+	assert.NoError(t, err)
+	assert.Equal(t, apitype.PlanSchemaVersionCurrent, versioned.Version)
+
+	// Secrets must be encrypted in the serialized plan.
+	bytes, err := json.Marshal(versioned)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(bytes), "hunter2")
+
+	var roundTripped apitype.VersionedPlan
+	assert.NoError(t, json.Unmarshal(bytes, &roundTripped))
+	actual, err := DeserializePlan(&roundTripped, crypter)
+	assert.NoError(t, err)
+	if !assert.Len(t, actual.Steps, 2) {
+		return
+	}
+
+	step := actual.Steps[0]
+	assert.Equal(t, deploy.OpReplace, step.Op)
+	assert.Equal(t, []resource.PropertyKey{"foo"}, step.Keys)
+	assert.Equal(t, "bar", step.OldInputs["foo"].StringValue())
+	assert.Equal(t, "baz", step.NewInputs["foo"].StringValue())
+	assert.True(t, step.NewInputs["password"].IsSecret())
+	assert.Equal(t, "hunter2", step.NewInputs["password"].SecretValue().Element.StringValue())
+	assert.True(t, step.NewInputs["nested"].ArrayValue()[0].IsComputed())
+
+	assert.Nil(t, actual.Steps[1].OldInputs)
+	assert.Nil(t, actual.Steps[1].NewInputs)
+
+	// Plans saved by newer versions of the CLI are rejected.
+	roundTripped.Version = apitype.PlanSchemaVersionCurrent + 1
+	_, err = DeserializePlan(&roundTripped, crypter)
+	assert.Equal(t, ErrPlanSchemaVersionTooNew, err)
+}
@@ -679,7 +679,7 @@ func (pt *programTester) testLifeCycleInitialize(dir string) error {
 func (pt *programTester) testLifeCycleDestroy(dir string) error {
 	// Destroy and remove the stack.
 	fprintf(pt.opts.Stdout, "Destroying stack\n")
-	destroy := []string{"destroy", "--non-interactive", "--skip-preview"}
+	destroy := []string{"destroy", "--non-interactive", "--skip-preview", "--yes"}
 	if pt.opts.GetDebugUpdates() {
 		destroy = append(destroy, "-d")
 	}
@@ -733,7 +733,7 @@ func (pt *programTester) testPreviewUpdateAndEdits(dir string) error {
 
 	if !pt.opts.SkipRefresh {
 		// Perform a refresh and ensure it doesn't yield changes.
-		refresh := []string{"refresh", "--non-interactive", "--skip-preview"}
+		refresh := []string{"refresh", "--non-interactive", "--skip-preview", "--yes"}
 		if pt.opts.GetDebugUpdates() {
 			refresh = append(refresh, "-d")
 		}
@@ -768,7 +768,7 @@ func (pt *programTester) previewAndUpdate(dir string, name string, shouldFail, e
 	expectNopUpdate bool) error {
 
 	preview := []string{"preview", "--non-interactive"}
-	update := []string{"up", "--non-interactive", "--skip-preview"}
+	update := []string{"up", "--non-interactive", "--skip-preview", "--yes"}
 	if pt.opts.GetDebugUpdates() {
 		preview = append(preview, "-d")
 		update = append(update, "-d")
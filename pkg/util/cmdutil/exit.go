@@ -90,6 +90,11 @@ func runPostCommandHooks(c *cobra.Command, args []string) error {
 func RunFunc(run func(cmd *cobra.Command, args []string) error) func(*cobra.Command, []string) {
 	return func(cmd *cobra.Command, args []string) {
 		if err := run(cmd, args); err != nil {
+			code := -1
+			if exitCodeErr, ok := err.(*ExitCodeError); ok {
+				code = exitCodeErr.Code
+			}
+
 			// Sadly, the fact that we hard-exit below means that it's up to us to replicate the Cobra post-run
 			// behavior here.
 			if postRunErr := runPostCommandHooks(cmd, args); postRunErr != nil {
@@ -105,11 +110,22 @@ func RunFunc(run func(cmd *cobra.Command, args []string) error) func(*cobra.Comm
 				logging.V(3).Infof(DetailedError(err))
 			}
 
-			ExitError(msg)
+			exitErrorCode(code, msg)
 		}
 	}
 }
 
+// ExitCodeError is an error that causes a command run by RunFunc to exit with a specific status code, which allows
+// scripts to distinguish it from other failures.
+type ExitCodeError struct {
+	Code int   // the status code with which to exit.
+	Err  error // the underlying error.
+}
+
+func (e *ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
 // Exit exits with a given error.
 func Exit(err error) {
 	ExitError(errorMessage(err))
@@ -83,6 +83,15 @@ func (r *Error) Details() []interface{} {
 	return r.details
 }
 
+// Retryable returns true if this error belongs to the category of transient failures. An RPC server signals such a
+// failure by returning an error with the ResourceExhausted code (see `NewRetryable`), e.g. because a cloud API
+// throttled its request. A retryable error indicates that the requested operation was not performed and may safely be
+// retried. Note that Unavailable errors are not retryable: gRPC also reports a broken connection to the server with
+// that code, in which case the operation may have been partially performed.
+func (r *Error) Retryable() bool {
+	return r.code == codes.ResourceExhausted
+}
+
 // ErrorCause represents a root cause of an error that ultimately caused
 // an RPC endpoint to issue an error. ErrorCauses are optionally attached
 // to Errors.
@@ -113,6 +122,12 @@ func New(code codes.Code, message string) error {
 	return status.Err()
 }
 
+// NewRetryable creates a new gRPC-compatible `error` with the given message
+// that signals to clients that the failed operation may be retried.
+func NewRetryable(message string) error {
+	return New(codes.ResourceExhausted, message)
+}
+
 // Newf creates a new gRPC-compatible `error` with the given code and
 // formatted message.
 func Newf(code codes.Code, messageFormat string, args ...interface{}) error {
@@ -197,6 +212,15 @@ func Convert(err error) *Error {
 	return converted
 }
 
+// IsRetryable returns true if the given error was created by an RPC server
+// and belongs to the retryable error category. See `Error.Retryable`.
+func IsRetryable(err error) bool {
+	if rpcError, ok := FromError(err); ok && rpcError != nil {
+		return rpcError.Retryable()
+	}
+	return false
+}
+
 func serializeErrorCause(err error) *pulumirpc.ErrorCause {
 	// Go is a surprising language that lets you do wacky stuff like this
 	// to get at implementation details of private structs.
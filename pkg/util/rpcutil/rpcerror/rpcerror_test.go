@@ -137,3 +137,15 @@ func TestErrorString(t *testing.T) {
 
 	assert.Equal(t, "thing failed 2", unwrapped.Error())
 }
+
+func TestRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(NewRetryable("try again later")))
+	assert.True(t, IsRetryable(New(codes.ResourceExhausted, "rate exceeded")))
+	assert.False(t, IsRetryable(New(codes.Internal, "internal error")))
+	assert.False(t, IsRetryable(New(codes.Unavailable, "transport is closing")))
+	assert.False(t, IsRetryable(errors.New("not an rpc error")))
+
+	rpcErr := Convert(NewRetryable("try again later"))
+	assert.True(t, rpcErr.Retryable())
+	assert.True(t, IsRetryable(rpcErr))
+}
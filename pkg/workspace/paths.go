@@ -35,6 +35,7 @@ const (
 	ConfigDir      = "config"     // the name of the folder that holds local configuration information.
 	GitDir         = ".git"       // the name of the folder git uses to store information.
 	HistoryDir     = "history"    // the name of the directory that holds historical information for projects.
+	LockDir        = "locks"      // the name of the directory that holds locks for stacks being updated.
 	PluginDir      = "plugins"    // the name of the directory containing plugins.
 	StackDir       = "stacks"     // the name of the directory that holds stack information for projects.
 	TemplateDir    = "templates"  // the name of the directory containing templates.
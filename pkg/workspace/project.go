@@ -123,8 +123,10 @@ func (proj *Project) Save(path string) error {
 // ProjectStack holds stack specific information about a project.
 // nolint: lll
 type ProjectStack struct {
-	EncryptionSalt string     `json:"encryptionsalt,omitempty" yaml:"encryptionsalt,omitempty"` // base64 encoded encryption salt.
-	Config         config.Map `json:"config,omitempty" yaml:"config,omitempty"`                 // optional config.
+	SecretsProvider string     `json:"secretsprovider,omitempty" yaml:"secretsprovider,omitempty"` // secrets provider.
+	EncryptionSalt  string     `json:"encryptionsalt,omitempty" yaml:"encryptionsalt,omitempty"`   // base64 encoded encryption salt.
+	EncryptedKey    string     `json:"encryptedkey,omitempty" yaml:"encryptedkey,omitempty"`       // wrapped data key.
+	Config          config.Map `json:"config,omitempty" yaml:"config,omitempty"`                   // optional config.
 }
 
 // Save writes a project definition to a file.
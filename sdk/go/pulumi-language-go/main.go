@@ -15,13 +15,19 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
 	"syscall"
 
 	pbempty "github.com/golang/protobuf/ptypes/empty"
@@ -29,6 +35,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/util/rpcutil"
 	"github.com/pulumi/pulumi/pkg/version"
@@ -39,7 +46,10 @@ import (
 // Launches the language host, which in turn fires up an RPC server implementing the LanguageRuntimeServer endpoint.
 func main() {
 	var tracing string
+	var binary string
 	flag.StringVar(&tracing, "tracing", "", "Emit tracing to a Zipkin-compatible tracing endpoint")
+	flag.StringVar(&binary, "binary", "",
+		"Run the given pre-built Go binary rather than building the program from source")
 
 	flag.Parse()
 	args := flag.Args()
@@ -55,7 +65,7 @@ func main() {
 	// Fire up a gRPC server, letting the kernel choose a free port.
 	port, done, err := rpcutil.Serve(0, nil, []func(*grpc.Server) error{
 		func(srv *grpc.Server) error {
-			host := newLanguageHost(engineAddress, tracing)
+			host := newLanguageHost(engineAddress, tracing, binary)
 			pulumirpc.RegisterLanguageRuntimeServer(srv, host)
 			return nil
 		},
@@ -77,19 +87,193 @@ func main() {
 type goLanguageHost struct {
 	engineAddress string
 	tracing       string
+	binary        string
 }
 
-func newLanguageHost(engineAddress, tracing string) pulumirpc.LanguageRuntimeServer {
+func newLanguageHost(engineAddress, tracing, binary string) pulumirpc.LanguageRuntimeServer {
 	return &goLanguageHost{
 		engineAddress: engineAddress,
 		tracing:       tracing,
+		binary:        binary,
 	}
 }
 
 // GetRequiredPlugins computes the complete set of anticipated plugins required by a program.
 func (host *goLanguageHost) GetRequiredPlugins(ctx context.Context,
 	req *pulumirpc.GetRequiredPluginsRequest) (*pulumirpc.GetRequiredPluginsResponse, error) {
-	return &pulumirpc.GetRequiredPluginsResponse{}, nil
+	// To get the plugins required by a program, list the modules that it requires directly and pick out those that
+	// contain Pulumi resource packages.  Modules that are only required indirectly, such as the dependencies of a
+	// resource package, are not needed by the program itself.  Programs that are not built as modules have no
+	// dependency manifest for us to inspect, so we simply report that they require no plugins.
+	modules, err := listDirectModules(programDir(req.GetPwd(), req.GetProgram()))
+	if err != nil {
+		logging.V(3).Infof("one or more errors while discovering plugins: %s", err)
+		return &pulumirpc.GetRequiredPluginsResponse{}, nil
+	}
+
+	var plugins []*pulumirpc.PluginDependency
+	for _, m := range modules {
+		if plugin, ok := getPlugin(m); ok {
+			logging.V(5).Infof("module %s requires plugin %s@%s", m.Path, plugin.Name, plugin.Version)
+			plugins = append(plugins, plugin)
+		}
+	}
+	return &pulumirpc.GetRequiredPluginsResponse{
+		Plugins: plugins,
+	}, nil
+}
+
+// goModule is the minimal amount of module information reported by `go list -m -json` and `go mod edit -json` that
+// we care about.
+type goModule struct {
+	Path     string `json:"Path"`
+	Version  string `json:"Version"`
+	Main     bool   `json:"Main"`
+	Indirect bool   `json:"Indirect"`
+}
+
+// goModFile is the minimal amount of information about a go.mod file reported by `go mod edit -json` that we care
+// about.
+type goModFile struct {
+	Require []*goModule `json:"Require"`
+}
+
+// runGo runs the go tool with the given arguments in the given directory and returns its standard output.
+func runGo(dir string, args ...string) (*bytes.Buffer, error) {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not find go on the $PATH")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(gobin, args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "running go %s: %s", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+	return &stdout, nil
+}
+
+// directRequirements returns the paths of the modules that the main module of the Go program in the given directory
+// requires directly, i.e. those listed in its go.mod without an `// indirect` comment.
+func directRequirements(dir string) (map[string]bool, error) {
+	stdout, err := runGo(dir, "mod", "edit", "-json")
+	if err != nil {
+		return nil, err
+	}
+
+	var modFile goModFile
+	if err = json.NewDecoder(stdout).Decode(&modFile); err != nil {
+		return nil, errors.Wrap(err, "decoding go.mod")
+	}
+
+	direct := make(map[string]bool)
+	for _, m := range modFile.Require {
+		if !m.Indirect {
+			direct[m.Path] = true
+		}
+	}
+	return direct, nil
+}
+
+// listDirectModules returns the modules that the Go program in the given directory requires directly, at the
+// versions selected for its build.
+func listDirectModules(dir string) ([]*goModule, error) {
+	direct, err := directRequirements(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := runGo(dir, "list", "-m", "-json", "all")
+	if err != nil {
+		return nil, err
+	}
+
+	// The output is a sequence of JSON objects, one per module, rather than a single JSON array.  `go list` only
+	// marks the requirements that go.mod itself labels as indirect, so filter out every other dependency ourselves.
+	var modules []*goModule
+	for dec := json.NewDecoder(stdout); ; {
+		var m goModule
+		if err = dec.Decode(&m); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(err, "decoding module information")
+		}
+		if direct[m.Path] {
+			modules = append(modules, &m)
+		}
+	}
+	return modules, nil
+}
+
+// pluginModuleRegexp matches the paths of the modules that contain Pulumi resource packages, e.g.
+// github.com/pulumi/pulumi-aws, optionally followed by a major version suffix.  The first submatch is the name of the
+// package's resource plugin.
+var pluginModuleRegexp = regexp.MustCompile(`^github\.com/pulumi/pulumi-([a-z0-9-]+)(/v[0-9]+)?$`)
+
+// nonPluginModules are the modules whose paths match pluginModuleRegexp, less any major version suffix, but which do
+// not contain resource packages.
+var nonPluginModules = map[string]bool{
+	"github.com/pulumi/pulumi-terraform-bridge": true,
+}
+
+// getPlugin returns the resource plugin, if any, that is required by a program that depends on the given module.
+func getPlugin(m *goModule) (*pulumirpc.PluginDependency, bool) {
+	if m.Main || m.Indirect || m.Version == "" {
+		return nil, false
+	}
+
+	match := pluginModuleRegexp.FindStringSubmatch(m.Path)
+	if match == nil || nonPluginModules[strings.TrimSuffix(m.Path, match[2])] {
+		return nil, false
+	}
+	return &pulumirpc.PluginDependency{
+		Name:    match[1],
+		Kind:    "resource",
+		Version: strings.TrimPrefix(m.Version, "v"),
+	}, true
+}
+
+// programDir returns the directory that contains the program with the given path, which is relative to pwd.
+func programDir(pwd, program string) string {
+	if program == "" {
+		program = "."
+	}
+	if pwd != "" && !filepath.IsAbs(program) {
+		return filepath.Join(pwd, program)
+	}
+	return program
+}
+
+// buildProgram compiles the Go program in the given directory into a binary within outDir, and returns its path.
+func buildProgram(dir, outDir, project string) (string, error) {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		return "", errors.Wrap(err, "could not find go on the $PATH")
+	}
+
+	name := project
+	if name == "" {
+		name = "pulumi-go-program"
+	}
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	binary := filepath.Join(outDir, name)
+
+	logging.V(5).Infof("language host building program in %s to %s", dir, binary)
+
+	// Build the program, sending any compiler diagnostics to stderr so that they are displayed to the user.
+	cmd := exec.Command(gobin, "build", "-o", binary, ".") // nolint: gas, intentionally building to a dynamic path.
+	cmd.Dir = dir
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err = cmd.Run(); err != nil {
+		return "", errors.Wrap(err, "unable to build program")
+	}
+	return binary, nil
 }
 
 // findProgram attempts to find the needed program in various locations on the
@@ -135,11 +319,26 @@ func (host *goLanguageHost) Run(ctx context.Context, req *pulumirpc.RunRequest)
 		return nil, errors.Wrap(err, "failed to prepare environment")
 	}
 
-	// The program to execute is simply the name of the project.  This ensures good Go toolability, whereby
-	// you can simply run `go install .` to build a Pulumi program prior to running it, among other benefits.
-	program, err := findProgram(req.GetProject())
-	if err != nil {
-		return nil, errors.Wrap(err, "problem executing program (could not run language executor)")
+	// If a pre-built binary was supplied, run that.  Otherwise, build the program from source into a temporary
+	// directory that is removed once the program has exited.
+	var program string
+	if host.binary != "" {
+		if program, err = findProgram(host.binary); err != nil {
+			return nil, errors.Wrap(err, "problem executing program (could not run language executor)")
+		}
+	} else {
+		var outDir string
+		if outDir, err = ioutil.TempDir("", "pulumi-go."); err != nil {
+			return nil, errors.Wrap(err, "unable to create temporary directory for program")
+		}
+		defer func() {
+			contract.IgnoreError(os.RemoveAll(outDir))
+		}()
+
+		program, err = buildProgram(programDir(req.GetPwd(), req.GetProgram()), outDir, req.GetProject())
+		if err != nil {
+			return &pulumirpc.RunResponse{Error: err.Error()}, nil
+		}
 	}
 
 	logging.V(5).Infoln("language host launching process: %s", program)
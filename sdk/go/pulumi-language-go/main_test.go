@@ -0,0 +1,100 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+func TestGetPlugin(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		module   goModule
+		expected string
+	}{
+		{goModule{Path: "github.com/pulumi/pulumi-aws", Version: "v0.18.3"}, "aws@0.18.3"},
+		{goModule{Path: "github.com/pulumi/pulumi-kubernetes/v2", Version: "v2.0.0"}, "kubernetes@2.0.0"},
+		{goModule{Path: "github.com/pulumi/pulumi-aws", Version: "v0.18.3", Main: true}, ""},
+		{goModule{Path: "github.com/pulumi/pulumi", Version: "v0.17.0"}, ""},
+		{goModule{Path: "github.com/pulumi/pulumi-aws/examples", Version: "v0.18.3"}, ""},
+		{goModule{Path: "github.com/example/pulumi-aws", Version: "v0.18.3"}, ""},
+		{goModule{Path: "github.com/pulumi/pulumi-aws", Version: "v0.18.3", Indirect: true}, ""},
+		{goModule{Path: "github.com/pulumi/pulumi-terraform-bridge", Version: "v0.18.2"}, ""},
+		{goModule{Path: "github.com/pulumi/pulumi-terraform", Version: "v0.18.1"}, "terraform@0.18.1"},
+	}
+	for _, c := range cases {
+		plugin, ok := getPlugin(&c.module)
+		if c.expected == "" {
+			assert.False(t, ok, c.module.Path)
+			continue
+		}
+		if assert.True(t, ok, c.module.Path) {
+			assert.Equal(t, "resource", plugin.Kind)
+			assert.Equal(t, c.expected, plugin.Name+"@"+plugin.Version)
+		}
+	}
+}
+
+func TestGetRequiredPlugins(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "pulumi-language-go")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, os.RemoveAll(dir)) }()
+
+	// The program requires a resource package, which in turn requires the Terraform bridge. Both modules are
+	// replaced with local copies so that they need not be downloaded.
+	writeFile := func(path, contents string) {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0700))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, path), []byte(contents), 0600))
+	}
+	writeFile("go.mod", "module example.com/program\n"+
+		"\n"+
+		"require github.com/pulumi/pulumi-aws v0.18.3\n"+
+		"\n"+
+		"replace github.com/pulumi/pulumi-aws => ./aws\n"+
+		"\n"+
+		"replace github.com/pulumi/pulumi-terraform-bridge => ./bridge\n")
+	writeFile("aws/go.mod", "module github.com/pulumi/pulumi-aws\n"+
+		"\n"+
+		"require github.com/pulumi/pulumi-terraform-bridge v0.18.2\n")
+	writeFile("bridge/go.mod", "module github.com/pulumi/pulumi-terraform-bridge\n")
+
+	host := &goLanguageHost{}
+	resp, err := host.GetRequiredPlugins(context.Background(), &pulumirpc.GetRequiredPluginsRequest{Pwd: dir})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.GetPlugins(), 1) {
+		plugin := resp.GetPlugins()[0]
+		assert.Equal(t, "aws@0.18.3", plugin.GetName()+"@"+plugin.GetVersion())
+	}
+}
+
+func TestProgramDir(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "/src/proj", programDir("/src/proj", ""))
+	assert.Equal(t, "/src/proj/infra", programDir("/src/proj", "infra"))
+	assert.Equal(t, "/elsewhere", programDir("/src/proj", "/elsewhere"))
+	assert.Equal(t, ".", programDir("", ""))
+}
@@ -31,6 +31,7 @@ func TestConfig(t *testing.T) {
 			"testpkg:bbb":    "true",
 			"testpkg:intint": "42",
 			"testpkg:fpfpfp": "99.963",
+			"testpkg:obj":    `{"name":"db","replicas":[{"host":"a","port":5432}]}`,
 		},
 	})
 	assert.Nil(t, err)
@@ -76,4 +77,41 @@ func TestConfig(t *testing.T) {
 	assert.Equal(t, 99.963, k4)
 	_, err = cfg.Try("missing")
 	assert.NotNil(t, err)
+
+	// Test the structured accessors, which unmarshal into the given output.
+	type replica struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+	type database struct {
+		Name     string    `json:"name"`
+		Replicas []replica `json:"replicas"`
+	}
+	expected := database{Name: "db", Replicas: []replica{{Host: "a", Port: 5432}}}
+
+	var o1 database
+	assert.Nil(t, cfg.GetObject("obj", &o1))
+	assert.Equal(t, expected, o1)
+	o2 := database{Name: "default"}
+	assert.Nil(t, cfg.GetObject("missing", &o2))
+	assert.Equal(t, "default", o2.Name)
+	assert.NotNil(t, cfg.GetObject("sss", &o2))
+
+	var o3 database
+	cfg.RequireObject("obj", &o3)
+	assert.Equal(t, expected, o3)
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("expected missing key for RequireObject to panic")
+			}
+		}()
+		var o database
+		cfg.RequireObject("missing", &o)
+	}()
+
+	var o4 database
+	assert.Nil(t, cfg.TryObject("obj", &o4))
+	assert.Equal(t, expected, o4)
+	assert.NotNil(t, cfg.TryObject("missing", &o4))
 }
@@ -15,6 +15,9 @@
 package config
 
 import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
 	"github.com/spf13/cast"
 
 	"github.com/pulumi/pulumi/sdk/go/pulumi"
@@ -129,3 +132,14 @@ func GetUint64(ctx *pulumi.Context, key string) uint64 {
 	}
 	return 0
 }
+
+// GetObject loads an optional structured configuration value by its key, unmarshaling its JSON representation into
+// output, which must be a pointer. If the value doesn't exist, output is left unchanged and no error is returned.
+func GetObject(ctx *pulumi.Context, key string, output interface{}) error {
+	if v, ok := ctx.GetConfig(key); ok {
+		if err := json.Unmarshal([]byte(v), output); err != nil {
+			return errors.Wrapf(err, "unmarshaling configuration variable '%s'", key)
+		}
+	}
+	return nil
+}
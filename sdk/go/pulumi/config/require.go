@@ -15,6 +15,8 @@
 package config
 
 import (
+	"encoding/json"
+
 	"github.com/spf13/cast"
 
 	"github.com/pulumi/pulumi/pkg/util/contract"
@@ -107,3 +109,12 @@ func RequireUint64(ctx *pulumi.Context, key string) uint64 {
 	v := Require(ctx, key)
 	return cast.ToUint64(v)
 }
+
+// RequireObject loads a structured configuration value by its key, unmarshaling its JSON representation into output,
+// which must be a pointer, or panics if it doesn't exist or cannot be unmarshaled.
+func RequireObject(ctx *pulumi.Context, key string, output interface{}) {
+	v := Require(ctx, key)
+	if err := json.Unmarshal([]byte(v), output); err != nil {
+		contract.Failf("unmarshaling configuration variable '%s': %v", key, err)
+	}
+}
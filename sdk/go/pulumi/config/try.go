@@ -15,6 +15,8 @@
 package config
 
 import (
+	"encoding/json"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cast"
 
@@ -147,3 +149,16 @@ func TryUint64(ctx *pulumi.Context, key string) (uint64, error) {
 	}
 	return cast.ToUint64(v), nil
 }
+
+// TryObject loads a structured configuration value by its key, unmarshaling its JSON representation into output, which
+// must be a pointer, or returns an error if it doesn't exist or cannot be unmarshaled.
+func TryObject(ctx *pulumi.Context, key string, output interface{}) error {
+	v, err := Try(ctx, key)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal([]byte(v), output); err != nil {
+		return errors.Wrapf(err, "unmarshaling configuration variable '%s'", key)
+	}
+	return nil
+}
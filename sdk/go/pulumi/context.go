@@ -41,6 +41,9 @@ type Context struct {
 	rpcs        int         // the number of outstanding RPC requests.
 	rpcsDone    *sync.Cond  // an event signaling completion of RPCs.
 	rpcsLock    *sync.Mutex // a lock protecting the RPC count and event.
+
+	providers     map[URN]map[string]*ProviderResource // the providers inherited by each resource's children.
+	providersLock sync.Mutex                           // a lock protecting the providers map.
 }
 
 // NewContext creates a fresh run context out of the given metadata.
@@ -80,6 +83,7 @@ func NewContext(ctx context.Context, info RunInfo) (*Context, error) {
 		rpcs:        0,
 		rpcsLock:    mutex,
 		rpcsDone:    sync.NewCond(mutex),
+		providers:   make(map[URN]map[string]*ProviderResource),
 	}, nil
 }
 
@@ -117,11 +121,18 @@ func (ctx *Context) GetConfig(key string) (string, bool) {
 }
 
 // Invoke will invoke a provider's function, identified by its token tok.  This function call is synchronous.
-func (ctx *Context) Invoke(tok string, args map[string]interface{}) (map[string]interface{}, error) {
+func (ctx *Context) Invoke(tok string, args map[string]interface{},
+	opts ...InvokeOpt) (map[string]interface{}, error) {
 	if tok == "" {
 		return nil, errors.New("invoke token must not be empty")
 	}
 
+	// Find the provider to use for the invoke, if any.
+	provider, version, err := ctx.getInvokeOptsProvider(tok, opts...)
+	if err != nil {
+		return nil, err
+	}
+
 	// Serialize arguments, first by awaiting them, and then marshaling them to the requisite gRPC values.
 	// TODO[pulumi/pulumi#1483]: feels like we should be propagating dependencies to the outputs, instead of ignoring.
 	_, rpcArgs, _, err := marshalInputs(args)
@@ -138,8 +149,10 @@ func (ctx *Context) Invoke(tok string, args map[string]interface{}) (map[string]
 	// Now, invoke the RPC to the provider synchronously.
 	glog.V(9).Infof("Invoke(%s, #args=%d): RPC call being made synchronously", tok, len(args))
 	resp, err := ctx.monitor.Invoke(ctx.ctx, &pulumirpc.InvokeRequest{
-		Tok:  tok,
-		Args: rpcArgs,
+		Tok:      tok,
+		Args:     rpcArgs,
+		Provider: provider,
+		Version:  version,
 	})
 	if err != nil {
 		glog.V(9).Infof("Invoke(%s, ...): error: %v", tok, err)
@@ -176,7 +189,7 @@ func (ctx *Context) ReadResource(
 	}
 
 	// Prepare the inputs for an impending operation.
-	op, err := ctx.newResourceOperation(true, props, opts...)
+	op, err := ctx.newResourceOperation(t, true, props, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -190,10 +203,14 @@ func (ctx *Context) ReadResource(
 	go func() {
 		glog.V(9).Infof("ReadResource(%s, %s): Goroutine spawned, RPC call being made", t, name)
 		resp, err := ctx.monitor.ReadResource(ctx.ctx, &pulumirpc.ReadResourceRequest{
-			Type:       t,
-			Name:       name,
-			Parent:     op.parent,
-			Properties: op.rpcProps,
+			Id:           string(id),
+			Type:         t,
+			Name:         name,
+			Parent:       op.parent,
+			Properties:   op.rpcProps,
+			Dependencies: op.deps,
+			Provider:     op.provider,
+			Version:      op.version,
 		})
 		if err != nil {
 			glog.V(9).Infof("RegisterResource(%s, %s): error: %v", t, name, err)
@@ -237,10 +254,13 @@ func (ctx *Context) RegisterResource(
 	}
 
 	// Prepare the inputs for an impending operation.
-	op, err := ctx.newResourceOperation(custom, props, opts...)
+	op, err := ctx.newResourceOperation(t, custom, props, opts...)
 	if err != nil {
 		return nil, err
 	}
+	importID, ignoreChanges := ctx.getOptsImport(opts...), ctx.getOptsIgnoreChanges(opts...)
+	aliases, timeouts := ctx.getOptsAliases(opts...), ctx.getOptsCustomTimeouts(opts...)
+	deleteBeforeReplace, retainOnDelete := ctx.getOptsDeleteBeforeReplace(opts...), ctx.getOptsRetainOnDelete(opts...)
 
 	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.
 	if err = ctx.beginRPC(); err != nil {
@@ -252,18 +272,29 @@ func (ctx *Context) RegisterResource(
 	go func() {
 		glog.V(9).Infof("RegisterResource(%s, %s): Goroutine spawned, RPC call being made", t, name)
 		resp, err := ctx.monitor.RegisterResource(ctx.ctx, &pulumirpc.RegisterResourceRequest{
-			Type:         t,
-			Name:         name,
-			Parent:       op.parent,
-			Object:       op.rpcProps,
-			Custom:       custom,
-			Protect:      op.protect,
-			Dependencies: op.deps,
+			Type:                t,
+			Name:                name,
+			Parent:              op.parent,
+			Object:              op.rpcProps,
+			Custom:              custom,
+			Protect:             op.protect,
+			Dependencies:        op.deps,
+			ImportId:            string(importID),
+			IgnoreChanges:       ignoreChanges,
+			Aliases:             aliases,
+			CustomTimeouts:      timeouts,
+			DeleteBeforeReplace: deleteBeforeReplace,
+			RetainOnDelete:      retainOnDelete,
+			Provider:            op.provider,
+			Version:             op.version,
 		})
 		if err != nil {
 			glog.V(9).Infof("RegisterResource(%s, %s): error: %v", t, name, err)
 		} else {
 			glog.V(9).Infof("RegisterResource(%s, %s): success: %s %s ...", t, name, resp.Urn, resp.Id)
+
+			// Make this resource's providers available to its children before they can learn its URN.
+			ctx.setProviders(URN(resp.Urn), op.providers)
 		}
 
 		// No matter the outcome, make sure all promises are resolved.
@@ -296,24 +327,45 @@ func (ctx *Context) RegisterResource(
 
 // resourceOperation reflects all of the inputs necessary to perform core resource RPC operations.
 type resourceOperation struct {
-	ctx      *Context
-	parent   string
-	deps     []string
-	protect  bool
-	props    map[string]interface{}
-	rpcProps *structpb.Struct
-	outURN   *resourceOutput
-	outID    *resourceOutput
-	outState map[string]*resourceOutput
+	ctx       *Context
+	parent    string
+	deps      []string
+	protect   bool
+	provider  string
+	providers map[string]*ProviderResource
+	version   string
+	props     map[string]interface{}
+	rpcProps  *structpb.Struct
+	outURN    *resourceOutput
+	outID     *resourceOutput
+	outState  map[string]*resourceOutput
 }
 
 // newResourceOperation prepares the inputs for a resource operation, shared between read and register.
-func (ctx *Context) newResourceOperation(custom bool, props map[string]interface{},
+func (ctx *Context) newResourceOperation(t string, custom bool, props map[string]interface{},
 	opts ...ResourceOpt) (*resourceOperation, error) {
 	// Get the parent and dependency URNs from the options, in addition to the protection bit.  If there wasn't an
 	// explicit parent, and a root stack resource exists, we will automatically parent to that.
 	parent, optDeps, protect := ctx.getOpts(opts...)
 
+	// Determine the providers inherited by this resource's children and, for custom resources, the provider that
+	// will manage this resource.
+	providers := ctx.getOptsProviders(parent, custom, opts...)
+	var provider string
+	if custom && !isProviderType(t) {
+		p := ctx.getOptsProvider(opts...)
+		if p == nil {
+			p = providers[getPackage(t)]
+		}
+		if p != nil {
+			ref, err := p.reference()
+			if err != nil {
+				return nil, errors.Wrap(err, "resolving provider")
+			}
+			provider = ref
+		}
+	}
+
 	// Serialize all properties, first by awaiting them, and then marshaling them to the requisite gRPC values.
 	keys, rpcProps, rpcDeps, err := marshalInputs(props)
 	if err != nil {
@@ -352,15 +404,18 @@ func (ctx *Context) newResourceOperation(custom bool, props map[string]interface
 	}
 
 	return &resourceOperation{
-		ctx:      ctx,
-		parent:   string(parent),
-		deps:     deps,
-		protect:  protect,
-		props:    props,
-		rpcProps: rpcProps,
-		outURN:   urn,
-		outID:    id,
-		outState: state,
+		ctx:       ctx,
+		parent:    string(parent),
+		deps:      deps,
+		protect:   protect,
+		provider:  provider,
+		providers: providers,
+		version:   ctx.getOptsVersion(opts...),
+		props:     props,
+		rpcProps:  rpcProps,
+		outURN:    urn,
+		outID:     id,
+		outState:  state,
 	}, nil
 }
 
@@ -460,6 +515,160 @@ func (ctx *Context) getOptsProtect(opts ...ResourceOpt) bool {
 	return false
 }
 
+// getOptsImport returns the ID of the existing resource to import, if any, from a resource's options.
+func (ctx *Context) getOptsImport(opts ...ResourceOpt) ID {
+	for _, opt := range opts {
+		if opt.Import != "" {
+			return opt.Import
+		}
+	}
+	return ""
+}
+
+// getOptsIgnoreChanges returns the list of property paths whose changes are to be ignored from a resource's options.
+func (ctx *Context) getOptsIgnoreChanges(opts ...ResourceOpt) []string {
+	var paths []string
+	for _, opt := range opts {
+		paths = append(paths, opt.IgnoreChanges...)
+	}
+	return paths
+}
+
+// getOptsAliases returns the list of URNs by which a resource may previously have been known from its options.
+func (ctx *Context) getOptsAliases(opts ...ResourceOpt) []string {
+	var aliases []string
+	for _, opt := range opts {
+		for _, alias := range opt.Aliases {
+			aliases = append(aliases, string(alias))
+		}
+	}
+	return aliases
+}
+
+// getOptsCustomTimeouts returns the custom timeouts, if any, from a resource's options.
+func (ctx *Context) getOptsCustomTimeouts(opts ...ResourceOpt) *pulumirpc.RegisterResourceRequest_CustomTimeouts {
+	for _, opt := range opts {
+		if opt.CustomTimeouts != nil {
+			return &pulumirpc.RegisterResourceRequest_CustomTimeouts{
+				Create: opt.CustomTimeouts.Create,
+				Update: opt.CustomTimeouts.Update,
+				Delete: opt.CustomTimeouts.Delete,
+			}
+		}
+	}
+	return nil
+}
+
+// getOptsDeleteBeforeReplace returns true if a resource's options indicate that it must be deleted before it is
+// replaced.
+func (ctx *Context) getOptsDeleteBeforeReplace(opts ...ResourceOpt) bool {
+	for _, opt := range opts {
+		if opt.DeleteBeforeReplace {
+			return true
+		}
+	}
+	return false
+}
+
+// getOptsRetainOnDelete returns true if a resource's options indicate that it is to be retained when deleted.
+func (ctx *Context) getOptsRetainOnDelete(opts ...ResourceOpt) bool {
+	for _, opt := range opts {
+		if opt.RetainOnDelete {
+			return true
+		}
+	}
+	return false
+}
+
+// getOptsProvider returns the explicit provider, if any, from a resource's options.
+func (ctx *Context) getOptsProvider(opts ...ResourceOpt) *ProviderResource {
+	for _, opt := range opts {
+		if opt.Provider != nil {
+			return opt.Provider
+		}
+	}
+	return nil
+}
+
+// getOptsProviders returns the providers, keyed by package, that a resource with the given parent and options makes
+// available to its children.  These are the parent's providers, overridden by those in the resource's options.  The
+// explicit provider of a component resource is also made available to its children.
+func (ctx *Context) getOptsProviders(parent URN, custom bool, opts ...ResourceOpt) map[string]*ProviderResource {
+	providers := make(map[string]*ProviderResource)
+	for pkg, p := range ctx.getProviders(parent) {
+		providers[pkg] = p
+	}
+	for _, opt := range opts {
+		for pkg, p := range opt.Providers {
+			providers[pkg] = p
+		}
+		if !custom && opt.Provider != nil {
+			providers[opt.Provider.Package()] = opt.Provider
+		}
+	}
+	return providers
+}
+
+// getOptsVersion returns the package version, if any, from a resource's options.
+func (ctx *Context) getOptsVersion(opts ...ResourceOpt) string {
+	for _, opt := range opts {
+		if opt.Version != "" {
+			return opt.Version
+		}
+	}
+	return ""
+}
+
+// getInvokeOptsProvider returns the provider reference and package version, if any, to use for invoking the function
+// with the given token.  The provider is the explicit provider in the invoke's options or, failing that, the provider
+// for the function's package among those inherited from the invoke's parent.
+func (ctx *Context) getInvokeOptsProvider(tok string, opts ...InvokeOpt) (string, string, error) {
+	var provider *ProviderResource
+	var parent Resource
+	var version string
+	for _, opt := range opts {
+		if provider == nil && opt.Provider != nil {
+			provider = opt.Provider
+		}
+		if parent == nil && opt.Parent != nil {
+			parent = opt.Parent
+		}
+		if version == "" && opt.Version != "" {
+			version = opt.Version
+		}
+	}
+	if provider == nil && parent != nil {
+		provider = ctx.getProviders(parent.URN())[getPackage(tok)]
+	}
+	if provider == nil {
+		return "", version, nil
+	}
+
+	ref, err := provider.reference()
+	if err != nil {
+		return "", "", errors.Wrap(err, "resolving provider")
+	}
+	return ref, version, nil
+}
+
+// getProviders returns the providers that the resource with the given URN makes available to its children.
+func (ctx *Context) getProviders(urn URN) map[string]*ProviderResource {
+	ctx.providersLock.Lock()
+	defer ctx.providersLock.Unlock()
+	return ctx.providers[urn]
+}
+
+// setProviders records the providers that the resource with the given URN makes available to its children.
+func (ctx *Context) setProviders(urn URN, providers map[string]*ProviderResource) {
+	if len(providers) == 0 {
+		return
+	}
+
+	ctx.providersLock.Lock()
+	defer ctx.providersLock.Unlock()
+	ctx.providers[urn] = providers
+}
+
 // noMoreRPCs is a sentinel value used to stop subsequent RPCs from occurring.
 const noMoreRPCs = -1
 
@@ -0,0 +1,271 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// Mocks allows a Pulumi program to be run without an engine, e.g. from a unit test.  Rather than provisioning
+// resources and calling provider functions, the runtime asks the mocks for the results of each operation.
+type Mocks interface {
+	// NewResource returns the ID and output properties of a custom resource with the given type, name and inputs.
+	// This is called for both registered and read resources.
+	NewResource(typeToken, name string, inputs resource.PropertyMap) (string, resource.PropertyMap, error)
+	// Call returns the results of invoking the provider function identified by the given token with the given
+	// arguments.
+	Call(token string, args resource.PropertyMap) (resource.PropertyMap, error)
+}
+
+// MockResource records a resource that was registered by a program run with mocks.
+type MockResource struct {
+	URN      URN                  // the resource's URN.
+	Type     string               // the resource's type token.
+	Name     string               // the resource's name.
+	Custom   bool                 // true if this is a custom resource, false for a component.
+	Parent   URN                  // the URN of the resource's parent, if any.
+	ID       ID                   // the resource's ID, if this is a custom resource.
+	Provider string               // the reference to the resource's provider, if one was chosen explicitly.
+	Inputs   resource.PropertyMap // the resource's input properties.
+	Outputs  resource.PropertyMap // the resource's output properties.
+}
+
+// MockRun records the results of running a program with mocks.
+type MockRun struct {
+	Resources []*MockResource      // the resources registered by the program, sorted by URN.
+	Exports   resource.PropertyMap // the values exported by the program's stack.
+}
+
+// Resource returns the registered resource with the given type and name, or nil if there is no such resource.
+func (run *MockRun) Resource(typeToken, name string) *MockResource {
+	for _, res := range run.Resources {
+		if res.Type == typeToken && res.Name == name {
+			return res
+		}
+	}
+	return nil
+}
+
+// RunWithMocks executes the body of a Pulumi program against the given mocks rather than a live engine.  The
+// project, stack, configuration and dry-run setting are read from the given info; its RPC addresses are ignored.
+// The returned run records the resources the program registered along with its exports, even if the program failed.
+func RunWithMocks(info RunInfo, mocks Mocks, body RunFunc) (*MockRun, error) {
+	if info.Project == "" {
+		return nil, errors.New("missing project name")
+	} else if info.Stack == "" {
+		return nil, errors.New("missing stack name")
+	}
+
+	monitor := &mockMonitor{
+		project:   tokens.PackageName(info.Project),
+		stack:     tokens.QName(info.Stack),
+		mocks:     mocks,
+		resources: make(map[URN]*MockResource),
+	}
+
+	mutex := &sync.Mutex{}
+	ctx := &Context{
+		ctx:       context.TODO(),
+		info:      info,
+		exports:   make(map[string]interface{}),
+		monitor:   monitor,
+		engine:    &mockEngine{},
+		rpcsLock:  mutex,
+		rpcsDone:  sync.NewCond(mutex),
+		providers: make(map[URN]map[string]*ProviderResource),
+	}
+
+	err := runWithContext(ctx, body)
+	return monitor.run(ctx.stackR), err
+}
+
+// mockMonitor is an in-process resource monitor that answers requests using a set of mocks.
+type mockMonitor struct {
+	project tokens.PackageName
+	stack   tokens.QName
+	mocks   Mocks
+
+	lock      sync.Mutex
+	resources map[URN]*MockResource
+}
+
+var _ pulumirpc.ResourceMonitorClient = (*mockMonitor)(nil)
+
+// run returns the record of the resources registered with this monitor.
+func (m *mockMonitor) run(stackURN URN) *MockRun {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	run := &MockRun{}
+	for _, res := range m.resources {
+		run.Resources = append(run.Resources, res)
+	}
+	sort.Slice(run.Resources, func(i, j int) bool {
+		return run.Resources[i].URN < run.Resources[j].URN
+	})
+	if stack, has := m.resources[stackURN]; has {
+		run.Exports = stack.Outputs
+	}
+	return run
+}
+
+// newURN creates the URN of a resource with the given parent, type and name in the same way as the engine.
+func (m *mockMonitor) newURN(parent, t, name string) URN {
+	var parentType tokens.Type
+	if p := resource.URN(parent); p != "" && p.Type() != resource.RootStackType {
+		parentType = p.QualifiedType()
+	}
+	return URN(resource.NewURN(m.stack, m.project, parentType, tokens.Type(t), tokens.QName(name)))
+}
+
+func (m *mockMonitor) Invoke(ctx context.Context, req *pulumirpc.InvokeRequest,
+	opts ...grpc.CallOption) (*pulumirpc.InvokeResponse, error) {
+
+	args, err := plugin.UnmarshalProperties(req.GetArgs(), plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := m.mocks.Call(req.GetTok(), args)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := plugin.MarshalProperties(result, plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.InvokeResponse{Return: ret}, nil
+}
+
+func (m *mockMonitor) ReadResource(ctx context.Context, req *pulumirpc.ReadResourceRequest,
+	opts ...grpc.CallOption) (*pulumirpc.ReadResourceResponse, error) {
+
+	inputs, err := plugin.UnmarshalProperties(req.GetProperties(), plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	_, outputs, err := m.mocks.NewResource(req.GetType(), req.GetName(), inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := plugin.MarshalProperties(outputs, plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.ReadResourceResponse{
+		Urn:        string(m.newURN(req.GetParent(), req.GetType(), req.GetName())),
+		Properties: props,
+	}, nil
+}
+
+func (m *mockMonitor) RegisterResource(ctx context.Context, req *pulumirpc.RegisterResourceRequest,
+	opts ...grpc.CallOption) (*pulumirpc.RegisterResourceResponse, error) {
+
+	inputs, err := plugin.UnmarshalProperties(req.GetObject(), plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// Only custom resources are passed to the mocks; components simply echo their inputs.
+	var id string
+	outputs := inputs
+	if req.GetCustom() {
+		if id, outputs, err = m.mocks.NewResource(req.GetType(), req.GetName(), inputs); err != nil {
+			return nil, err
+		}
+	}
+
+	urn := m.newURN(req.GetParent(), req.GetType(), req.GetName())
+
+	m.lock.Lock()
+	m.resources[urn] = &MockResource{
+		URN:      urn,
+		Type:     req.GetType(),
+		Name:     req.GetName(),
+		Custom:   req.GetCustom(),
+		Parent:   URN(req.GetParent()),
+		ID:       ID(id),
+		Provider: req.GetProvider(),
+		Inputs:   inputs,
+		Outputs:  outputs,
+	}
+	m.lock.Unlock()
+
+	obj, err := plugin.MarshalProperties(outputs, plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.RegisterResourceResponse{
+		Urn:    string(urn),
+		Id:     id,
+		Object: obj,
+	}, nil
+}
+
+func (m *mockMonitor) RegisterResourceOutputs(ctx context.Context, req *pulumirpc.RegisterResourceOutputsRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+
+	outputs, err := plugin.UnmarshalProperties(req.GetOutputs(), plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	res, has := m.resources[URN(req.GetUrn())]
+	if !has {
+		return nil, errors.Errorf("unknown resource %s", req.GetUrn())
+	}
+	res.Outputs = outputs
+	return &empty.Empty{}, nil
+}
+
+// mockEngine is an in-process engine that discards log messages and tracks the root resource.
+type mockEngine struct {
+	rootResource string
+}
+
+var _ pulumirpc.EngineClient = (*mockEngine)(nil)
+
+func (e *mockEngine) Log(ctx context.Context, req *pulumirpc.LogRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (e *mockEngine) GetRootResource(ctx context.Context, req *pulumirpc.GetRootResourceRequest,
+	opts ...grpc.CallOption) (*pulumirpc.GetRootResourceResponse, error) {
+	return &pulumirpc.GetRootResourceResponse{Urn: e.rootResource}, nil
+}
+
+func (e *mockEngine) SetRootResource(ctx context.Context, req *pulumirpc.SetRootResourceRequest,
+	opts ...grpc.CallOption) (*pulumirpc.SetRootResourceResponse, error) {
+	e.rootResource = req.GetUrn()
+	return &pulumirpc.SetRootResourceResponse{}, nil
+}
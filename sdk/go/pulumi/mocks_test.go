@@ -0,0 +1,187 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+type testMocks struct {
+	newResourceF func(typeToken, name string, inputs resource.PropertyMap) (string, resource.PropertyMap, error)
+	callF        func(token string, args resource.PropertyMap) (resource.PropertyMap, error)
+}
+
+func (m *testMocks) NewResource(typeToken, name string,
+	inputs resource.PropertyMap) (string, resource.PropertyMap, error) {
+	return m.newResourceF(typeToken, name, inputs)
+}
+
+func (m *testMocks) Call(token string, args resource.PropertyMap) (resource.PropertyMap, error) {
+	return m.callF(token, args)
+}
+
+// TestRunWithMocks ensures that a program run with mocks registers its resources and exports with the mocks.
+func TestRunWithMocks(t *testing.T) {
+	mocks := &testMocks{
+		newResourceF: func(typeToken, name string, inputs resource.PropertyMap) (string, resource.PropertyMap, error) {
+			assert.Equal(t, "test:index:Bucket", typeToken)
+			outputs := inputs.Copy()
+			outputs["arn"] = resource.NewStringProperty("arn:" + name)
+			return name + "-id", outputs, nil
+		},
+		callF: func(token string, args resource.PropertyMap) (resource.PropertyMap, error) {
+			assert.Equal(t, "test:index:getRegion", token)
+			return resource.PropertyMap{"region": resource.NewStringProperty("us-west-2")}, nil
+		},
+	}
+
+	run, err := RunWithMocks(RunInfo{Project: "proj", Stack: "stack"}, mocks, func(ctx *Context) error {
+		region, err := ctx.Invoke("test:index:getRegion", nil)
+		if err != nil {
+			return err
+		}
+
+		comp, err := ctx.RegisterResource("test:index:Component", "comp", false, nil)
+		if err != nil {
+			return err
+		}
+		compURN, err := comp.URN.Value()
+		if err != nil {
+			return err
+		}
+
+		bucket, err := ctx.RegisterResource("test:index:Bucket", "bucket", true, map[string]interface{}{
+			"region": region["region"],
+			"arn":    nil,
+		}, ResourceOpt{Parent: testResource(compURN)})
+		if err != nil {
+			return err
+		}
+
+		arn, known, err := bucket.State["arn"].String()
+		assert.NoError(t, err)
+		assert.True(t, known)
+		assert.Equal(t, "arn:bucket", arn)
+
+		ctx.Export("arn", bucket.State["arn"])
+		ctx.Export("id", (*Output)(bucket.ID))
+		return nil
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, run.Resources, 3)
+
+	comp := run.Resource("test:index:Component", "comp")
+	if assert.NotNil(t, comp) {
+		assert.False(t, comp.Custom)
+		assert.Equal(t, ID(""), comp.ID)
+	}
+
+	bucket := run.Resource("test:index:Bucket", "bucket")
+	if assert.NotNil(t, bucket) {
+		assert.True(t, bucket.Custom)
+		assert.Equal(t, ID("bucket-id"), bucket.ID)
+		assert.Equal(t, comp.URN, bucket.Parent)
+		assert.Equal(t, URN("urn:pulumi:stack::proj::test:index:Component$test:index:Bucket::bucket"), bucket.URN)
+		assert.Equal(t, resource.NewStringProperty("us-west-2"), bucket.Inputs["region"])
+		assert.Equal(t, resource.NewStringProperty("arn:bucket"), bucket.Outputs["arn"])
+	}
+
+	assert.Equal(t, resource.PropertyMap{
+		"arn": resource.NewStringProperty("arn:bucket"),
+		"id":  resource.NewStringProperty("bucket-id"),
+	}, run.Exports)
+}
+
+// TestRunWithMocksURNs ensures that the mock monitor assigns resources the same URNs as the engine.
+func TestRunWithMocksURNs(t *testing.T) {
+	mocks := &testMocks{
+		newResourceF: func(typeToken, name string, inputs resource.PropertyMap) (string, resource.PropertyMap, error) {
+			return name + "-id", inputs, nil
+		},
+	}
+
+	run, err := RunWithMocks(RunInfo{Project: "proj", Stack: "stack"}, mocks, func(ctx *Context) error {
+		comp, err := ctx.RegisterResource("test:index:Component", "comp", false, nil)
+		if err != nil {
+			return err
+		}
+		compURN, err := comp.URN.Value()
+		if err != nil {
+			return err
+		}
+
+		child, err := ctx.RegisterResource("test:index:Child", "child", false, nil,
+			ResourceOpt{Parent: testResource(compURN)})
+		if err != nil {
+			return err
+		}
+		childURN, err := child.URN.Value()
+		if err != nil {
+			return err
+		}
+
+		_, err = ctx.RegisterResource("test:index:Bucket", "bucket", true, nil,
+			ResourceOpt{Parent: testResource(childURN)})
+		return err
+	})
+	assert.NoError(t, err)
+
+	// Top-level resources are not qualified by the type of the root stack.
+	comp := run.Resource("test:index:Component", "comp")
+	if assert.NotNil(t, comp) {
+		assert.Equal(t, URN("urn:pulumi:stack::proj::test:index:Component::comp"), comp.URN)
+	}
+
+	// Other resources are qualified by the types of all of their ancestors.
+	bucket := run.Resource("test:index:Bucket", "bucket")
+	if assert.NotNil(t, bucket) {
+		assert.Equal(t,
+			URN("urn:pulumi:stack::proj::test:index:Component$test:index:Child$test:index:Bucket::bucket"),
+			bucket.URN)
+	}
+}
+
+// TestRunWithMocksFailure ensures that errors returned by the mocks are surfaced to the program.
+func TestRunWithMocksFailure(t *testing.T) {
+	mocks := &testMocks{
+		newResourceF: func(typeToken, name string, inputs resource.PropertyMap) (string, resource.PropertyMap, error) {
+			return "", nil, errors.New("out of buckets")
+		},
+	}
+
+	run, err := RunWithMocks(RunInfo{Project: "proj", Stack: "stack"}, mocks, func(ctx *Context) error {
+		bucket, err := ctx.RegisterResource("test:index:Bucket", "bucket", true, nil)
+		if err != nil {
+			return err
+		}
+		_, _, err = bucket.ID.Value()
+		return err
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "out of buckets")
+	}
+	assert.Nil(t, run.Resource("test:index:Bucket", "bucket"))
+}
+
+// testResource is a resource that is identified only by its URN.
+type testResource URN
+
+func (r testResource) URN() URN { return URN(r) }
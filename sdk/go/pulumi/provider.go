@@ -0,0 +1,91 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+)
+
+// providerTypePrefix is the prefix of the type tokens of provider resources.
+const providerTypePrefix = "pulumi:providers:"
+
+// ProviderResource is a resource that represents a configured instance of a package's provider plugin.  A provider
+// resource may be passed to other resources using the Provider or Providers resource options in order to manage
+// them with that instance rather than the package's default provider, e.g. to target several regions or accounts.
+type ProviderResource struct {
+	s   *ResourceState
+	pkg string
+}
+
+// NewProviderResource creates and registers a provider resource for the given package.  props contains the
+// provider's configuration, e.g. its region or credentials.
+func NewProviderResource(ctx *Context, pkg, name string, props map[string]interface{},
+	opts ...ResourceOpt) (*ProviderResource, error) {
+	s, err := ctx.RegisterResource(providerTypePrefix+pkg, name, true, props, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ProviderResource{s: s, pkg: pkg}, nil
+}
+
+var _ Resource = (*ProviderResource)(nil) // ensure that providers may be used as parents and dependencies.
+
+// URN is this resource's stable logical URN used to distinctly address it before, during, and after deployments.  It
+// blocks until the provider has been registered, and is empty if the registration failed.
+func (p *ProviderResource) URN() URN {
+	urn, err := p.s.URN.Value()
+	if err != nil {
+		return ""
+	}
+	return urn
+}
+
+// ID is this resource's unique identifier assigned by the engine.
+func (p *ProviderResource) ID() *IDOutput { return p.s.ID }
+
+// Package is the name of the package whose resources this provider manages.
+func (p *ProviderResource) Package() string { return p.pkg }
+
+// State contains the provider's output properties.
+func (p *ProviderResource) State() Outputs { return p.s.State }
+
+// reference awaits the provider's registration and returns the reference by which the engine identifies it, which
+// is the provider's URN and ID separated by "::".  If the ID is not yet known, the unknown sentinel is used instead.
+func (p *ProviderResource) reference() (string, error) {
+	urn, err := p.s.URN.Value()
+	if err != nil {
+		return "", err
+	}
+	id, known, err := p.s.ID.Value()
+	if err != nil {
+		return "", err
+	}
+	if !known {
+		id = ID(plugin.UnknownStringValue)
+	}
+	return string(urn) + "::" + string(id), nil
+}
+
+// isProviderType returns true if the given type token is that of a provider resource.
+func isProviderType(t string) bool {
+	return strings.HasPrefix(t, providerTypePrefix)
+}
+
+// getPackage returns the package name of the given resource or function token.
+func getPackage(tok string) string {
+	return strings.SplitN(tok, ":", 2)[0]
+}
@@ -0,0 +1,156 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+// TestExplicitProviders ensures that explicit providers are passed to the engine for custom resources, either
+// directly or by inheritance from a resource's parents.
+func TestExplicitProviders(t *testing.T) {
+	mocks := &testMocks{
+		newResourceF: func(typeToken, name string, inputs resource.PropertyMap) (string, resource.PropertyMap, error) {
+			return name + "-id", inputs, nil
+		},
+	}
+
+	run, err := RunWithMocks(RunInfo{Project: "proj", Stack: "stack"}, mocks, func(ctx *Context) error {
+		west, err := NewProviderResource(ctx, "test", "west", map[string]interface{}{"region": "us-west-2"})
+		if err != nil {
+			return err
+		}
+		east, err := NewProviderResource(ctx, "test", "east", map[string]interface{}{"region": "us-east-1"})
+		if err != nil {
+			return err
+		}
+
+		// A resource without an explicit provider uses the default provider.
+		if _, err = ctx.RegisterResource("test:index:Bucket", "default", true, nil); err != nil {
+			return err
+		}
+
+		// A resource may name its provider directly.
+		if _, err = ctx.RegisterResource("test:index:Bucket", "direct", true, nil,
+			ResourceOpt{Provider: east}); err != nil {
+			return err
+		}
+
+		// Children inherit their parent's providers.
+		comp, err := ctx.RegisterResource("test:index:Component", "comp", false, nil,
+			ResourceOpt{Providers: map[string]*ProviderResource{"test": west}})
+		if err != nil {
+			return err
+		}
+		compURN, err := comp.URN.Value()
+		if err != nil {
+			return err
+		}
+		if _, err = ctx.RegisterResource("test:index:Bucket", "inherited", true, nil,
+			ResourceOpt{Parent: testResource(compURN)}); err != nil {
+			return err
+		}
+
+		// Providers for other packages are not used.
+		_, err = ctx.RegisterResource("other:index:Bucket", "other", true, nil,
+			ResourceOpt{Parent: testResource(compURN), Version: "1.2.3"})
+		return err
+	})
+	assert.NoError(t, err)
+
+	providerRef := func(name string) string {
+		res := run.Resource("pulumi:providers:test", name)
+		if !assert.NotNil(t, res) {
+			return ""
+		}
+		assert.Equal(t, "", res.Provider)
+		return string(res.URN) + "::" + string(res.ID)
+	}
+
+	expected := map[string]string{
+		"default":   "",
+		"direct":    providerRef("east"),
+		"inherited": providerRef("west"),
+	}
+	for name, provider := range expected {
+		res := run.Resource("test:index:Bucket", name)
+		if assert.NotNil(t, res) {
+			assert.Equal(t, provider, res.Provider, name)
+		}
+	}
+
+	other := run.Resource("other:index:Bucket", "other")
+	if assert.NotNil(t, other) {
+		assert.Equal(t, "", other.Provider)
+	}
+}
+
+// TestProviderParent ensures that a provider resource may be used as the parent of another resource.
+func TestProviderParent(t *testing.T) {
+	mocks := &testMocks{
+		newResourceF: func(typeToken, name string, inputs resource.PropertyMap) (string, resource.PropertyMap, error) {
+			return name + "-id", inputs, nil
+		},
+	}
+
+	run, err := RunWithMocks(RunInfo{Project: "proj", Stack: "stack"}, mocks, func(ctx *Context) error {
+		west, err := NewProviderResource(ctx, "test", "west", map[string]interface{}{"region": "us-west-2"})
+		if err != nil {
+			return err
+		}
+		_, err = ctx.RegisterResource("test:index:Bucket", "child", true, nil,
+			ResourceOpt{Parent: west})
+		return err
+	})
+	assert.NoError(t, err)
+
+	provider := run.Resource("pulumi:providers:test", "west")
+	child := run.Resource("test:index:Bucket", "child")
+	if assert.NotNil(t, provider) && assert.NotNil(t, child) {
+		assert.Equal(t, provider.URN, child.Parent)
+		assert.Equal(t,
+			URN("urn:pulumi:stack::proj::pulumi:providers:test$test:index:Bucket::child"),
+			child.URN)
+	}
+}
+
+// TestInvokeOptsProvider ensures that the provider for an invoke is chosen from its options or its parent.
+func TestInvokeOptsProvider(t *testing.T) {
+	ctx := &Context{providers: make(map[URN]map[string]*ProviderResource)}
+
+	urn, resolveURN, _ := NewOutput(nil)
+	resolveURN(URN("urn:pulumi:stack::proj::pulumi:providers:test::p"), true)
+	id, resolveID, _ := NewOutput(nil)
+	resolveID(ID("p-id"), true)
+	p := &ProviderResource{s: &ResourceState{URN: (*URNOutput)(urn), ID: (*IDOutput)(id)}, pkg: "test"}
+	ctx.setProviders("parent", map[string]*ProviderResource{"test": p})
+
+	provider, version, err := ctx.getInvokeOptsProvider("test:index:getThing", InvokeOpt{Provider: p, Version: "1.0.0"})
+	assert.NoError(t, err)
+	assert.Equal(t, "urn:pulumi:stack::proj::pulumi:providers:test::p::p-id", provider)
+	assert.Equal(t, "1.0.0", version)
+
+	provider, _, err = ctx.getInvokeOptsProvider("test:index:getThing", InvokeOpt{Parent: testResource("parent")})
+	assert.NoError(t, err)
+	assert.Equal(t, "urn:pulumi:stack::proj::pulumi:providers:test::p::p-id", provider)
+
+	provider, _, err = ctx.getInvokeOptsProvider("other:index:getThing", InvokeOpt{Parent: testResource("parent")})
+	assert.NoError(t, err)
+	assert.Equal(t, "", provider)
+}
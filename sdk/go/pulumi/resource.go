@@ -51,4 +51,53 @@ type ResourceOpt struct {
 	DependsOn []Resource
 	// Protect, when set to true, ensures that this resource cannot be deleted (without first setting it to false).
 	Protect bool
+	// Import, when set to the ID of an existing custom resource, adopts that resource into the stack rather than
+	// creating a new one.  The resource's inputs must match the existing resource's state for the import to succeed.
+	Import ID
+	// IgnoreChanges is an optional list of property paths whose changes should be ignored when diffing this resource
+	// against its current state, e.g. `tags.Name` or `rules[0].port`.  Ignored properties never trigger an update or a
+	// replacement.
+	IgnoreChanges []string
+	// Aliases is an optional list of URNs by which this resource may previously have been known.  If an existing
+	// resource matches one of these URNs, it is treated as this resource rather than being deleted and re-created,
+	// which allows resources to be renamed or re-parented without being replaced.
+	Aliases []URN
+	// CustomTimeouts optionally overrides the amount of time the provider may spend creating, updating, or deleting
+	// this resource.
+	CustomTimeouts *CustomTimeouts
+	// DeleteBeforeReplace, when set to true, ensures that this resource is deleted before its replacement is created
+	// whenever it must be replaced.  This is useful for resources whose names must be globally unique.
+	DeleteBeforeReplace bool
+	// RetainOnDelete, when set to true, ensures that this resource is never deleted by its provider.  If the resource
+	// is removed from the program or replaced, it is simply dropped from the stack's state.
+	RetainOnDelete bool
+	// Provider is an optional provider resource to use for this resource's CRUD operations.  If no provider is
+	// supplied, the provider for the resource's package is taken from its parent's providers, falling back to the
+	// package's default provider.  For component resources, the provider is made available to the resource's children.
+	Provider *ProviderResource
+	// Providers is an optional map from package name to the provider resource to use for that package.  These
+	// providers are used by this resource and inherited by all of its children.
+	Providers map[string]*ProviderResource
+	// Version is an optional version of the resource's package, used to select the plugin for its default provider.
+	Version string
+}
+
+// InvokeOpt contains optional settings that control an invoke's behavior.
+type InvokeOpt struct {
+	// Parent is an optional resource from whose providers the invoke's provider is chosen.
+	Parent Resource
+	// Provider is an optional provider resource to use for the invoke.  If no provider is supplied, the provider for
+	// the function's package is taken from the parent's providers, falling back to the package's default provider.
+	Provider *ProviderResource
+	// Version is an optional version of the function's package, used to select the plugin for its default provider.
+	Version string
+}
+
+// CustomTimeouts overrides the maximum amount of time that a provider may spend creating, updating, or deleting a
+// resource.  Each timeout is a duration string such as "5m" or "1h30m"; an empty string leaves the provider's
+// default in place.
+type CustomTimeouts struct {
+	Create string
+	Update string
+	Delete string
 }
@@ -63,9 +63,15 @@ func RunErr(body RunFunc) error {
 	}
 	defer contract.IgnoreClose(ctx)
 
+	return runWithContext(ctx, body)
+}
+
+// runWithContext executes the body of a Pulumi program using the given deployment context.  It registers the root
+// stack resource, runs the body, registers the stack's outputs and then awaits all outstanding RPCs.
+func runWithContext(ctx *Context, body RunFunc) error {
 	// Create a root stack resource that we'll parent everything to.
 	reg, err := ctx.RegisterResource(
-		"pulumi:pulumi:Stack", fmt.Sprintf("%s-%s", info.Project, info.Stack), false, nil)
+		"pulumi:pulumi:Stack", fmt.Sprintf("%s-%s", ctx.info.Project, ctx.info.Stack), false, nil)
 	if err != nil {
 		return err
 	}
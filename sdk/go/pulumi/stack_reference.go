@@ -0,0 +1,57 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+// stackReferenceType is the type token of the builtin stack reference resource.
+const stackReferenceType = "pulumi:pulumi:StackReference"
+
+// StackReference is a resource that exposes the outputs of another stack.  The outputs are read when the reference
+// is created, and re-read by each subsequent deployment or refresh of the stack that contains the reference.
+type StackReference struct {
+	s *ResourceState
+}
+
+// NewStackReference creates a reference to the stack with the given fully qualified name, e.g. "org/project/stack".
+// The name of the referenced stack is also used as the name of the resource.
+func NewStackReference(ctx *Context, name string, opts ...ResourceOpt) (*StackReference, error) {
+	s, err := ctx.RegisterResource(stackReferenceType, name, true, map[string]interface{}{
+		"name":    name,
+		"outputs": nil,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &StackReference{s: s}, nil
+}
+
+// URN is this resource's stable logical URN used to distinctly address it before, during, and after deployments.
+func (r *StackReference) URN() *URNOutput { return r.s.URN }
+
+// ID is this resource's unique identifier assigned by the engine.
+func (r *StackReference) ID() *IDOutput { return r.s.ID }
+
+// Name is the fully qualified name of the referenced stack.
+func (r *StackReference) Name() *StringOutput { return (*StringOutput)(r.s.State["name"]) }
+
+// Outputs resolves to the outputs of the referenced stack.
+func (r *StackReference) Outputs() *MapOutput { return (*MapOutput)(r.s.State["outputs"]) }
+
+// GetOutput returns an output that resolves to the named output of the referenced stack, or to nil if the referenced
+// stack has no such output.
+func (r *StackReference) GetOutput(name string) *Output {
+	return r.Outputs().Apply(func(outputs map[string]interface{}) (interface{}, error) {
+		return outputs[name], nil
+	})
+}
@@ -55,6 +55,17 @@ function deserialize_pulumirpc_AnalyzeResponse(buffer_arg) {
   return analyzer_pb.AnalyzeResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_AnalyzeStackRequest(arg) {
+  if (!(arg instanceof analyzer_pb.AnalyzeStackRequest)) {
+    throw new Error('Expected argument of type pulumirpc.AnalyzeStackRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_AnalyzeStackRequest(buffer_arg) {
+  return analyzer_pb.AnalyzeStackRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_pulumirpc_PluginInfo(arg) {
   if (!(arg instanceof plugin_pb.PluginInfo)) {
     throw new Error('Expected argument of type pulumirpc.PluginInfo');
@@ -82,6 +93,19 @@ var AnalyzerService = exports.AnalyzerService = {
     responseSerialize: serialize_pulumirpc_AnalyzeResponse,
     responseDeserialize: deserialize_pulumirpc_AnalyzeResponse,
   },
+  // AnalyzeStack analyzes all of the resources in a stack once the stack's plan has been generated, and returns any
+  // errors that it finds.  Unlike Analyze, it may express rules that span multiple resources.
+  analyzeStack: {
+    path: '/pulumirpc.Analyzer/AnalyzeStack',
+    requestStream: false,
+    responseStream: false,
+    requestType: analyzer_pb.AnalyzeStackRequest,
+    responseType: analyzer_pb.AnalyzeResponse,
+    requestSerialize: serialize_pulumirpc_AnalyzeStackRequest,
+    requestDeserialize: deserialize_pulumirpc_AnalyzeStackRequest,
+    responseSerialize: serialize_pulumirpc_AnalyzeResponse,
+    responseDeserialize: deserialize_pulumirpc_AnalyzeResponse,
+  },
   // GetPluginInfo returns generic information about this plugin, like its version.
   getPluginInfo: {
     path: '/pulumirpc.Analyzer/GetPluginInfo',
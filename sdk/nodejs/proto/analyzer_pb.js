@@ -17,6 +17,9 @@ var google_protobuf_struct_pb = require('google-protobuf/google/protobuf/struct_
 goog.exportSymbol('proto.pulumirpc.AnalyzeFailure', null, global);
 goog.exportSymbol('proto.pulumirpc.AnalyzeRequest', null, global);
 goog.exportSymbol('proto.pulumirpc.AnalyzeResponse', null, global);
+goog.exportSymbol('proto.pulumirpc.AnalyzeStackRequest', null, global);
+goog.exportSymbol('proto.pulumirpc.AnalyzerResource', null, global);
+goog.exportSymbol('proto.pulumirpc.EnforcementLevel', null, global);
 
 /**
  * Generated by JsPbCodeGenerator.
@@ -419,7 +422,9 @@ proto.pulumirpc.AnalyzeFailure.prototype.toObject = function(opt_includeInstance
 proto.pulumirpc.AnalyzeFailure.toObject = function(includeInstance, msg) {
   var f, obj = {
     property: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    reason: jspb.Message.getFieldWithDefault(msg, 2, "")
+    reason: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    enforcementlevel: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    urn: jspb.Message.getFieldWithDefault(msg, 4, "")
   };
 
   if (includeInstance) {
@@ -464,6 +469,14 @@ proto.pulumirpc.AnalyzeFailure.deserializeBinaryFromReader = function(msg, reade
       var value = /** @type {string} */ (reader.readString());
       msg.setReason(value);
       break;
+    case 3:
+      var value = /** @type {!proto.pulumirpc.EnforcementLevel} */ (reader.readEnum());
+      msg.setEnforcementlevel(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setUrn(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -507,6 +520,20 @@ proto.pulumirpc.AnalyzeFailure.serializeBinaryToWriter = function(message, write
       f
     );
   }
+  f = message.getEnforcementlevel();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      3,
+      f
+    );
+  }
+  f = message.getUrn();
+  if (f.length > 0) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
 };
 
 
@@ -540,4 +567,542 @@ proto.pulumirpc.AnalyzeFailure.prototype.setReason = function(value) {
 };
 
 
+/**
+ * optional EnforcementLevel enforcementLevel = 3;
+ * @return {!proto.pulumirpc.EnforcementLevel}
+ */
+proto.pulumirpc.AnalyzeFailure.prototype.getEnforcementlevel = function() {
+  return /** @type {!proto.pulumirpc.EnforcementLevel} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {!proto.pulumirpc.EnforcementLevel} value */
+proto.pulumirpc.AnalyzeFailure.prototype.setEnforcementlevel = function(value) {
+  jspb.Message.setProto3EnumField(this, 3, value);
+};
+
+
+/**
+ * optional string urn = 4;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzeFailure.prototype.getUrn = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzeFailure.prototype.setUrn = function(value) {
+  jspb.Message.setProto3StringField(this, 4, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.AnalyzeStackRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.AnalyzeStackRequest.repeatedFields_, null);
+};
+goog.inherits(proto.pulumirpc.AnalyzeStackRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.pulumirpc.AnalyzeStackRequest.displayName = 'proto.pulumirpc.AnalyzeStackRequest';
+}
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.AnalyzeStackRequest.repeatedFields_ = [1];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.AnalyzeStackRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.AnalyzeStackRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.AnalyzeStackRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzeStackRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    resourcesList: jspb.Message.toObjectList(msg.getResourcesList(),
+    proto.pulumirpc.AnalyzerResource.toObject, includeInstance)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.AnalyzeStackRequest}
+ */
+proto.pulumirpc.AnalyzeStackRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.AnalyzeStackRequest;
+  return proto.pulumirpc.AnalyzeStackRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.AnalyzeStackRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.AnalyzeStackRequest}
+ */
+proto.pulumirpc.AnalyzeStackRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new proto.pulumirpc.AnalyzerResource;
+      reader.readMessage(value,proto.pulumirpc.AnalyzerResource.deserializeBinaryFromReader);
+      msg.addResources(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.AnalyzeStackRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.AnalyzeStackRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.AnalyzeStackRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzeStackRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getResourcesList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      1,
+      f,
+      proto.pulumirpc.AnalyzerResource.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * repeated AnalyzerResource resources = 1;
+ * @return {!Array.<!proto.pulumirpc.AnalyzerResource>}
+ */
+proto.pulumirpc.AnalyzeStackRequest.prototype.getResourcesList = function() {
+  return /** @type{!Array.<!proto.pulumirpc.AnalyzerResource>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.pulumirpc.AnalyzerResource, 1));
+};
+
+
+/** @param {!Array.<!proto.pulumirpc.AnalyzerResource>} value */
+proto.pulumirpc.AnalyzeStackRequest.prototype.setResourcesList = function(value) {
+  jspb.Message.setRepeatedWrapperField(this, 1, value);
+};
+
+
+/**
+ * @param {!proto.pulumirpc.AnalyzerResource=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.AnalyzerResource}
+ */
+proto.pulumirpc.AnalyzeStackRequest.prototype.addResources = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 1, opt_value, proto.pulumirpc.AnalyzerResource, opt_index);
+};
+
+
+proto.pulumirpc.AnalyzeStackRequest.prototype.clearResourcesList = function() {
+  this.setResourcesList([]);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.AnalyzerResource = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.AnalyzerResource.repeatedFields_, null);
+};
+goog.inherits(proto.pulumirpc.AnalyzerResource, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.pulumirpc.AnalyzerResource.displayName = 'proto.pulumirpc.AnalyzerResource';
+}
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.AnalyzerResource.repeatedFields_ = [5];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.AnalyzerResource.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.AnalyzerResource.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.AnalyzerResource} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzerResource.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    type: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    properties: (f = msg.getProperties()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
+    urn: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    parent: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    dependenciesList: jspb.Message.getRepeatedField(msg, 5),
+    outputs: (f = msg.getOutputs()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.AnalyzerResource}
+ */
+proto.pulumirpc.AnalyzerResource.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.AnalyzerResource;
+  return proto.pulumirpc.AnalyzerResource.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.AnalyzerResource} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.AnalyzerResource}
+ */
+proto.pulumirpc.AnalyzerResource.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setType(value);
+      break;
+    case 2:
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setProperties(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setUrn(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setParent(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addDependencies(value);
+      break;
+    case 6:
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setOutputs(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.AnalyzerResource.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.AnalyzerResource.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.AnalyzerResource} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzerResource.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getType();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getProperties();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
+    );
+  }
+  f = message.getUrn();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getParent();
+  if (f.length > 0) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
+  f = message.getDependenciesList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      5,
+      f
+    );
+  }
+  f = message.getOutputs();
+  if (f != null) {
+    writer.writeMessage(
+      6,
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional string type = 1;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzerResource.prototype.getType = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzerResource.prototype.setType = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional google.protobuf.Struct properties = 2;
+ * @return {?proto.google.protobuf.Struct}
+ */
+proto.pulumirpc.AnalyzerResource.prototype.getProperties = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 2));
+};
+
+
+/** @param {?proto.google.protobuf.Struct|undefined} value */
+proto.pulumirpc.AnalyzerResource.prototype.setProperties = function(value) {
+  jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+proto.pulumirpc.AnalyzerResource.prototype.clearProperties = function() {
+  this.setProperties(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.pulumirpc.AnalyzerResource.prototype.hasProperties = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+/**
+ * optional string urn = 3;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzerResource.prototype.getUrn = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzerResource.prototype.setUrn = function(value) {
+  jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * optional string parent = 4;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzerResource.prototype.getParent = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzerResource.prototype.setParent = function(value) {
+  jspb.Message.setProto3StringField(this, 4, value);
+};
+
+
+/**
+ * repeated string dependencies = 5;
+ * @return {!Array.<string>}
+ */
+proto.pulumirpc.AnalyzerResource.prototype.getDependenciesList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 5));
+};
+
+
+/** @param {!Array.<string>} value */
+proto.pulumirpc.AnalyzerResource.prototype.setDependenciesList = function(value) {
+  jspb.Message.setField(this, 5, value || []);
+};
+
+
+/**
+ * @param {!string} value
+ * @param {number=} opt_index
+ */
+proto.pulumirpc.AnalyzerResource.prototype.addDependencies = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 5, value, opt_index);
+};
+
+
+proto.pulumirpc.AnalyzerResource.prototype.clearDependenciesList = function() {
+  this.setDependenciesList([]);
+};
+
+
+/**
+ * optional google.protobuf.Struct outputs = 6;
+ * @return {?proto.google.protobuf.Struct}
+ */
+proto.pulumirpc.AnalyzerResource.prototype.getOutputs = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 6));
+};
+
+
+/** @param {?proto.google.protobuf.Struct|undefined} value */
+proto.pulumirpc.AnalyzerResource.prototype.setOutputs = function(value) {
+  jspb.Message.setWrapperField(this, 6, value);
+};
+
+
+proto.pulumirpc.AnalyzerResource.prototype.clearOutputs = function() {
+  this.setOutputs(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.pulumirpc.AnalyzerResource.prototype.hasOutputs = function() {
+  return jspb.Message.getField(this, 6) != null;
+};
+
+
+/**
+ * @enum {number}
+ */
+proto.pulumirpc.EnforcementLevel = {
+  MANDATORY: 0,
+  ADVISORY: 1
+};
+
 goog.object.extend(exports, proto.pulumirpc);
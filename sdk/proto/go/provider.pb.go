@@ -25,6 +25,41 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type PropertyDiff_Kind int32
+
+const (
+	PropertyDiff_ADD            PropertyDiff_Kind = 0
+	PropertyDiff_ADD_REPLACE    PropertyDiff_Kind = 1
+	PropertyDiff_DELETE         PropertyDiff_Kind = 2
+	PropertyDiff_DELETE_REPLACE PropertyDiff_Kind = 3
+	PropertyDiff_UPDATE         PropertyDiff_Kind = 4
+	PropertyDiff_UPDATE_REPLACE PropertyDiff_Kind = 5
+)
+
+var PropertyDiff_Kind_name = map[int32]string{
+	0: "ADD",
+	1: "ADD_REPLACE",
+	2: "DELETE",
+	3: "DELETE_REPLACE",
+	4: "UPDATE",
+	5: "UPDATE_REPLACE",
+}
+var PropertyDiff_Kind_value = map[string]int32{
+	"ADD":            0,
+	"ADD_REPLACE":    1,
+	"DELETE":         2,
+	"DELETE_REPLACE": 3,
+	"UPDATE":         4,
+	"UPDATE_REPLACE": 5,
+}
+
+func (x PropertyDiff_Kind) String() string {
+	return proto.EnumName(PropertyDiff_Kind_name, int32(x))
+}
+func (PropertyDiff_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{8, 0}
+}
+
 type DiffResponse_DiffChanges int32
 
 const (
@@ -48,7 +83,7 @@ func (x DiffResponse_DiffChanges) String() string {
 	return proto.EnumName(DiffResponse_DiffChanges_name, int32(x))
 }
 func (DiffResponse_DiffChanges) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{8, 0}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{9, 0}
 }
 
 type ConfigureRequest struct {
@@ -62,7 +97,7 @@ func (m *ConfigureRequest) Reset()         { *m = ConfigureRequest{} }
 func (m *ConfigureRequest) String() string { return proto.CompactTextString(m) }
 func (*ConfigureRequest) ProtoMessage()    {}
 func (*ConfigureRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{0}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{0}
 }
 func (m *ConfigureRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigureRequest.Unmarshal(m, b)
@@ -101,7 +136,7 @@ func (m *ConfigureErrorMissingKeys) Reset()         { *m = ConfigureErrorMissing
 func (m *ConfigureErrorMissingKeys) String() string { return proto.CompactTextString(m) }
 func (*ConfigureErrorMissingKeys) ProtoMessage()    {}
 func (*ConfigureErrorMissingKeys) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{1}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{1}
 }
 func (m *ConfigureErrorMissingKeys) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigureErrorMissingKeys.Unmarshal(m, b)
@@ -140,7 +175,7 @@ func (m *ConfigureErrorMissingKeys_MissingKey) Reset()         { *m = ConfigureE
 func (m *ConfigureErrorMissingKeys_MissingKey) String() string { return proto.CompactTextString(m) }
 func (*ConfigureErrorMissingKeys_MissingKey) ProtoMessage()    {}
 func (*ConfigureErrorMissingKeys_MissingKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{1, 0}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{1, 0}
 }
 func (m *ConfigureErrorMissingKeys_MissingKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigureErrorMissingKeys_MissingKey.Unmarshal(m, b)
@@ -178,6 +213,7 @@ type InvokeRequest struct {
 	Tok                  string          `protobuf:"bytes,1,opt,name=tok" json:"tok,omitempty"`
 	Args                 *_struct.Struct `protobuf:"bytes,2,opt,name=args" json:"args,omitempty"`
 	Provider             string          `protobuf:"bytes,3,opt,name=provider" json:"provider,omitempty"`
+	Version              string          `protobuf:"bytes,4,opt,name=version" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -187,7 +223,7 @@ func (m *InvokeRequest) Reset()         { *m = InvokeRequest{} }
 func (m *InvokeRequest) String() string { return proto.CompactTextString(m) }
 func (*InvokeRequest) ProtoMessage()    {}
 func (*InvokeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{2}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{2}
 }
 func (m *InvokeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InvokeRequest.Unmarshal(m, b)
@@ -228,6 +264,13 @@ func (m *InvokeRequest) GetProvider() string {
 	return ""
 }
 
+func (m *InvokeRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
 type InvokeResponse struct {
 	Return               *_struct.Struct `protobuf:"bytes,1,opt,name=return" json:"return,omitempty"`
 	Failures             []*CheckFailure `protobuf:"bytes,2,rep,name=failures" json:"failures,omitempty"`
@@ -240,7 +283,7 @@ func (m *InvokeResponse) Reset()         { *m = InvokeResponse{} }
 func (m *InvokeResponse) String() string { return proto.CompactTextString(m) }
 func (*InvokeResponse) ProtoMessage()    {}
 func (*InvokeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{3}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{3}
 }
 func (m *InvokeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InvokeResponse.Unmarshal(m, b)
@@ -287,7 +330,7 @@ func (m *CheckRequest) Reset()         { *m = CheckRequest{} }
 func (m *CheckRequest) String() string { return proto.CompactTextString(m) }
 func (*CheckRequest) ProtoMessage()    {}
 func (*CheckRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{4}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{4}
 }
 func (m *CheckRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckRequest.Unmarshal(m, b)
@@ -340,7 +383,7 @@ func (m *CheckResponse) Reset()         { *m = CheckResponse{} }
 func (m *CheckResponse) String() string { return proto.CompactTextString(m) }
 func (*CheckResponse) ProtoMessage()    {}
 func (*CheckResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{5}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{5}
 }
 func (m *CheckResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckResponse.Unmarshal(m, b)
@@ -386,7 +429,7 @@ func (m *CheckFailure) Reset()         { *m = CheckFailure{} }
 func (m *CheckFailure) String() string { return proto.CompactTextString(m) }
 func (*CheckFailure) ProtoMessage()    {}
 func (*CheckFailure) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{6}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{6}
 }
 func (m *CheckFailure) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckFailure.Unmarshal(m, b)
@@ -434,7 +477,7 @@ func (m *DiffRequest) Reset()         { *m = DiffRequest{} }
 func (m *DiffRequest) String() string { return proto.CompactTextString(m) }
 func (*DiffRequest) ProtoMessage()    {}
 func (*DiffRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{7}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{7}
 }
 func (m *DiffRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DiffRequest.Unmarshal(m, b)
@@ -482,11 +525,51 @@ func (m *DiffRequest) GetNews() *_struct.Struct {
 	return nil
 }
 
+// PropertyDiff describes the kind of change made to a single property by an update.
+type PropertyDiff struct {
+	Kind                 PropertyDiff_Kind `protobuf:"varint,1,opt,name=kind,enum=pulumirpc.PropertyDiff_Kind" json:"kind,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PropertyDiff) Reset()         { *m = PropertyDiff{} }
+func (m *PropertyDiff) String() string { return proto.CompactTextString(m) }
+func (*PropertyDiff) ProtoMessage()    {}
+func (*PropertyDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{8}
+}
+func (m *PropertyDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PropertyDiff.Unmarshal(m, b)
+}
+func (m *PropertyDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PropertyDiff.Marshal(b, m, deterministic)
+}
+func (dst *PropertyDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PropertyDiff.Merge(dst, src)
+}
+func (m *PropertyDiff) XXX_Size() int {
+	return xxx_messageInfo_PropertyDiff.Size(m)
+}
+func (m *PropertyDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_PropertyDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PropertyDiff proto.InternalMessageInfo
+
+func (m *PropertyDiff) GetKind() PropertyDiff_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return PropertyDiff_ADD
+}
+
 type DiffResponse struct {
 	Replaces             []string                 `protobuf:"bytes,1,rep,name=replaces" json:"replaces,omitempty"`
 	Stables              []string                 `protobuf:"bytes,2,rep,name=stables" json:"stables,omitempty"`
 	DeleteBeforeReplace  bool                     `protobuf:"varint,3,opt,name=deleteBeforeReplace" json:"deleteBeforeReplace,omitempty"`
 	Changes              DiffResponse_DiffChanges `protobuf:"varint,4,opt,name=changes,enum=pulumirpc.DiffResponse_DiffChanges" json:"changes,omitempty"`
+	DetailedDiff         map[string]*PropertyDiff `protobuf:"bytes,5,rep,name=detailedDiff" json:"detailedDiff,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
 	XXX_unrecognized     []byte                   `json:"-"`
 	XXX_sizecache        int32                    `json:"-"`
@@ -496,7 +579,7 @@ func (m *DiffResponse) Reset()         { *m = DiffResponse{} }
 func (m *DiffResponse) String() string { return proto.CompactTextString(m) }
 func (*DiffResponse) ProtoMessage()    {}
 func (*DiffResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{8}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{9}
 }
 func (m *DiffResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DiffResponse.Unmarshal(m, b)
@@ -544,9 +627,17 @@ func (m *DiffResponse) GetChanges() DiffResponse_DiffChanges {
 	return DiffResponse_DIFF_UNKNOWN
 }
 
+func (m *DiffResponse) GetDetailedDiff() map[string]*PropertyDiff {
+	if m != nil {
+		return m.DetailedDiff
+	}
+	return nil
+}
+
 type CreateRequest struct {
 	Urn                  string          `protobuf:"bytes,1,opt,name=urn" json:"urn,omitempty"`
 	Properties           *_struct.Struct `protobuf:"bytes,2,opt,name=properties" json:"properties,omitempty"`
+	Timeout              float64         `protobuf:"fixed64,3,opt,name=timeout" json:"timeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -556,7 +647,7 @@ func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
 func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRequest) ProtoMessage()    {}
 func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{9}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{10}
 }
 func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
@@ -590,6 +681,13 @@ func (m *CreateRequest) GetProperties() *_struct.Struct {
 	return nil
 }
 
+func (m *CreateRequest) GetTimeout() float64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
 type CreateResponse struct {
 	Id                   string          `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	Properties           *_struct.Struct `protobuf:"bytes,2,opt,name=properties" json:"properties,omitempty"`
@@ -602,7 +700,7 @@ func (m *CreateResponse) Reset()         { *m = CreateResponse{} }
 func (m *CreateResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateResponse) ProtoMessage()    {}
 func (*CreateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{10}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{11}
 }
 func (m *CreateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateResponse.Unmarshal(m, b)
@@ -649,7 +747,7 @@ func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
 func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadRequest) ProtoMessage()    {}
 func (*ReadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{11}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{12}
 }
 func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadRequest.Unmarshal(m, b)
@@ -702,7 +800,7 @@ func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
 func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse) ProtoMessage()    {}
 func (*ReadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{12}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{13}
 }
 func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadResponse.Unmarshal(m, b)
@@ -741,6 +839,7 @@ type UpdateRequest struct {
 	Urn                  string          `protobuf:"bytes,2,opt,name=urn" json:"urn,omitempty"`
 	Olds                 *_struct.Struct `protobuf:"bytes,3,opt,name=olds" json:"olds,omitempty"`
 	News                 *_struct.Struct `protobuf:"bytes,4,opt,name=news" json:"news,omitempty"`
+	Timeout              float64         `protobuf:"fixed64,5,opt,name=timeout" json:"timeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -750,7 +849,7 @@ func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
 func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateRequest) ProtoMessage()    {}
 func (*UpdateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{13}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{14}
 }
 func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
@@ -798,6 +897,13 @@ func (m *UpdateRequest) GetNews() *_struct.Struct {
 	return nil
 }
 
+func (m *UpdateRequest) GetTimeout() float64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
 type UpdateResponse struct {
 	Properties           *_struct.Struct `protobuf:"bytes,1,opt,name=properties" json:"properties,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
@@ -809,7 +915,7 @@ func (m *UpdateResponse) Reset()         { *m = UpdateResponse{} }
 func (m *UpdateResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateResponse) ProtoMessage()    {}
 func (*UpdateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{14}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{15}
 }
 func (m *UpdateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateResponse.Unmarshal(m, b)
@@ -840,6 +946,7 @@ type DeleteRequest struct {
 	Id                   string          `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	Urn                  string          `protobuf:"bytes,2,opt,name=urn" json:"urn,omitempty"`
 	Properties           *_struct.Struct `protobuf:"bytes,3,opt,name=properties" json:"properties,omitempty"`
+	Timeout              float64         `protobuf:"fixed64,4,opt,name=timeout" json:"timeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -849,7 +956,7 @@ func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
 func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRequest) ProtoMessage()    {}
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{15}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{16}
 }
 func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
@@ -890,6 +997,13 @@ func (m *DeleteRequest) GetProperties() *_struct.Struct {
 	return nil
 }
 
+func (m *DeleteRequest) GetTimeout() float64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
 // ErrorResourceInitFailed is sent as a Detail `ResourceProvider.{Create, Update}` fail because a
 // resource was created successfully, but failed to initialize.
 type ErrorResourceInitFailed struct {
@@ -905,7 +1019,7 @@ func (m *ErrorResourceInitFailed) Reset()         { *m = ErrorResourceInitFailed
 func (m *ErrorResourceInitFailed) String() string { return proto.CompactTextString(m) }
 func (*ErrorResourceInitFailed) ProtoMessage()    {}
 func (*ErrorResourceInitFailed) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{16}
+	return fileDescriptor_provider_3bc18efc24f7b381, []int{17}
 }
 func (m *ErrorResourceInitFailed) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ErrorResourceInitFailed.Unmarshal(m, b)
@@ -957,7 +1071,9 @@ func init() {
 	proto.RegisterType((*CheckResponse)(nil), "pulumirpc.CheckResponse")
 	proto.RegisterType((*CheckFailure)(nil), "pulumirpc.CheckFailure")
 	proto.RegisterType((*DiffRequest)(nil), "pulumirpc.DiffRequest")
+	proto.RegisterType((*PropertyDiff)(nil), "pulumirpc.PropertyDiff")
 	proto.RegisterType((*DiffResponse)(nil), "pulumirpc.DiffResponse")
+	proto.RegisterMapType((map[string]*PropertyDiff)(nil), "pulumirpc.DiffResponse.DetailedDiffEntry")
 	proto.RegisterType((*CreateRequest)(nil), "pulumirpc.CreateRequest")
 	proto.RegisterType((*CreateResponse)(nil), "pulumirpc.CreateResponse")
 	proto.RegisterType((*ReadRequest)(nil), "pulumirpc.ReadRequest")
@@ -966,6 +1082,7 @@ func init() {
 	proto.RegisterType((*UpdateResponse)(nil), "pulumirpc.UpdateResponse")
 	proto.RegisterType((*DeleteRequest)(nil), "pulumirpc.DeleteRequest")
 	proto.RegisterType((*ErrorResourceInitFailed)(nil), "pulumirpc.ErrorResourceInitFailed")
+	proto.RegisterEnum("pulumirpc.PropertyDiff_Kind", PropertyDiff_Kind_name, PropertyDiff_Kind_value)
 	proto.RegisterEnum("pulumirpc.DiffResponse_DiffChanges", DiffResponse_DiffChanges_name, DiffResponse_DiffChanges_value)
 }
 
@@ -1370,64 +1487,74 @@ var _ResourceProvider_serviceDesc = grpc.ServiceDesc{
 	Metadata: "provider.proto",
 }
 
-func init() { proto.RegisterFile("provider.proto", fileDescriptor_provider_5951afc12b1894bc) }
-
-var fileDescriptor_provider_5951afc12b1894bc = []byte{
-	// 889 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xc4, 0x56, 0xdd, 0x8e, 0xdb, 0x44,
-	0x14, 0x5e, 0x27, 0xd9, 0x74, 0x73, 0xf2, 0xa3, 0x68, 0x80, 0xdd, 0x34, 0xe5, 0xa2, 0x32, 0x37,
-	0x15, 0x48, 0x0e, 0x4a, 0x2f, 0x80, 0xaa, 0x15, 0x28, 0xdd, 0x2c, 0x44, 0x55, 0xb3, 0xc5, 0x55,
-	0x59, 0xc1, 0x0d, 0xf2, 0xda, 0x93, 0xac, 0x89, 0x63, 0x9b, 0xf1, 0x38, 0x28, 0x88, 0x17, 0x58,
-	0xf1, 0x06, 0x3c, 0x06, 0xcf, 0xc6, 0x03, 0x30, 0x9e, 0x1f, 0xc7, 0x93, 0x6c, 0xb2, 0x61, 0xb5,
-	0xa2, 0x77, 0x73, 0x7c, 0x7e, 0xbe, 0xf3, 0x9d, 0x73, 0xe6, 0x8c, 0xa1, 0x15, 0x93, 0x68, 0xe1,
-	0x7b, 0x98, 0x58, 0xec, 0x40, 0x23, 0x54, 0x8b, 0xd3, 0x20, 0x9d, 0xfb, 0x24, 0x76, 0xbb, 0x8d,
-	0x38, 0x48, 0xa7, 0x7e, 0x28, 0x14, 0xdd, 0x47, 0xd3, 0x28, 0x9a, 0x06, 0xb8, 0xc7, 0xa5, 0xcb,
-	0x74, 0xd2, 0xc3, 0xf3, 0x98, 0x2e, 0xa5, 0xf2, 0xe3, 0x75, 0x65, 0x42, 0x49, 0xea, 0x52, 0xa1,
-	0x35, 0xff, 0x32, 0xa0, 0xfd, 0x32, 0x0a, 0x27, 0xfe, 0x34, 0x25, 0xd8, 0xc6, 0xbf, 0xa6, 0x38,
-	0xa1, 0xe8, 0x3b, 0xa8, 0x2d, 0x1c, 0xe2, 0x3b, 0x97, 0x01, 0x4e, 0x3a, 0xc6, 0xe3, 0xf2, 0x93,
-	0x7a, 0xff, 0x53, 0x2b, 0x07, 0xb7, 0xd6, 0xed, 0xad, 0x1f, 0x94, 0xf1, 0x30, 0xa4, 0x64, 0x69,
-	0xaf, 0x9c, 0xbb, 0xcf, 0xa1, 0xa5, 0x2b, 0x51, 0x1b, 0xca, 0x33, 0xbc, 0x64, 0x51, 0x8d, 0x27,
-	0x35, 0x3b, 0x3b, 0xa2, 0x0f, 0xe1, 0x70, 0xe1, 0x04, 0x29, 0xee, 0x94, 0xf8, 0x37, 0x21, 0x3c,
-	0x2b, 0x7d, 0x69, 0x98, 0x7f, 0x1b, 0xf0, 0x30, 0x07, 0x1b, 0x12, 0x12, 0x91, 0xd7, 0x7e, 0x92,
-	0xf8, 0xe1, 0xf4, 0x15, 0x5e, 0x26, 0xe8, 0x7b, 0xa8, 0xcf, 0x57, 0xa2, 0xcc, 0xb3, 0x77, 0x53,
-	0x9e, 0xeb, 0xae, 0xd6, 0xea, 0x6c, 0x17, 0x63, 0x74, 0x07, 0x00, 0x2b, 0x15, 0x42, 0x50, 0x09,
-	0x9d, 0x39, 0x96, 0xb9, 0xf2, 0x33, 0x7a, 0x0c, 0x75, 0x0f, 0x27, 0x2e, 0xf1, 0x63, 0xea, 0x47,
-	0xa1, 0x4c, 0xb9, 0xf8, 0xc9, 0xfc, 0x05, 0x9a, 0xa3, 0x70, 0x11, 0xcd, 0xf2, 0x6a, 0x32, 0xc6,
-	0x34, 0x9a, 0x29, 0xc6, 0xec, 0x88, 0x3e, 0x83, 0x8a, 0x43, 0xa6, 0x09, 0xf7, 0xae, 0xf7, 0x4f,
-	0x2c, 0xd1, 0x21, 0x4b, 0x75, 0xc8, 0x7a, 0xcb, 0x3b, 0x64, 0x73, 0x23, 0xd4, 0x85, 0x23, 0x35,
-	0x07, 0x9d, 0x32, 0x8f, 0x91, 0xcb, 0xe6, 0x02, 0x5a, 0x0a, 0x2b, 0x89, 0xa3, 0x30, 0xc1, 0xa8,
-	0x07, 0x55, 0x82, 0x69, 0x4a, 0x42, 0x8e, 0xb7, 0x23, 0xb8, 0x34, 0x43, 0x4f, 0xe1, 0x68, 0xe2,
-	0xf8, 0x01, 0xab, 0x52, 0x96, 0x4f, 0x99, 0xbb, 0x14, 0x4a, 0x78, 0x85, 0xdd, 0xd9, 0x99, 0xd0,
-	0xdb, 0xb9, 0xa1, 0xf9, 0x3b, 0x34, 0xb8, 0xa6, 0x40, 0x51, 0x41, 0x32, 0x8a, 0x59, 0x58, 0x46,
-	0x31, 0x0a, 0xbc, 0xdb, 0x29, 0x66, 0x46, 0x99, 0x71, 0x88, 0x7f, 0x4b, 0x38, 0xbd, 0x5d, 0xc6,
-	0x99, 0x91, 0x99, 0x42, 0x53, 0x62, 0xaf, 0x28, 0xfb, 0x61, 0x9c, 0xd2, 0xe4, 0x56, 0xca, 0xc2,
-	0xec, 0x6e, 0x94, 0x07, 0x92, 0xb2, 0xd4, 0xc8, 0xb6, 0xc4, 0x98, 0x50, 0x35, 0xcc, 0xb9, 0x8c,
-	0x8e, 0xb3, 0x26, 0x38, 0x49, 0x3e, 0x1f, 0x52, 0x32, 0xaf, 0x0d, 0xa8, 0x9f, 0xfa, 0x93, 0x89,
-	0x2a, 0x5b, 0x0b, 0x4a, 0xbe, 0x27, 0xbd, 0xd9, 0x49, 0x95, 0xb1, 0xb4, 0x59, 0xc6, 0xf2, 0x7f,
-	0x29, 0x63, 0x65, 0x9f, 0x32, 0xfe, 0x63, 0x40, 0x43, 0xe4, 0x22, 0xcb, 0xc8, 0x08, 0x11, 0x1c,
-	0x07, 0x8e, 0x2b, 0xef, 0x3c, 0x23, 0xa4, 0x64, 0xd4, 0x81, 0x07, 0x09, 0x15, 0xeb, 0xa0, 0xc4,
-	0x55, 0x4a, 0x44, 0x9f, 0xc3, 0x07, 0x1e, 0x0e, 0x30, 0xc5, 0x03, 0x3c, 0x89, 0xb2, 0x8d, 0xc0,
-	0x3d, 0x78, 0xbe, 0x47, 0xf6, 0x4d, 0x2a, 0xf4, 0x02, 0x1e, 0xb8, 0x57, 0x4e, 0x38, 0xc5, 0x22,
-	0xd1, 0x56, 0xff, 0x93, 0x42, 0xf1, 0x8b, 0x19, 0x71, 0xe1, 0xa5, 0x30, 0xb5, 0x95, 0x8f, 0xf9,
-	0x42, 0x94, 0x50, 0x7e, 0x67, 0x25, 0x6b, 0x9c, 0x8e, 0xce, 0xce, 0x7e, 0x7e, 0x37, 0x7e, 0x35,
-	0x3e, 0xbf, 0x18, 0xb7, 0x0f, 0x50, 0x13, 0x6a, 0xfc, 0xcb, 0xf8, 0x7c, 0x3c, 0x6c, 0x1b, 0xb9,
-	0xf8, 0xf6, 0xfc, 0xf5, 0xb0, 0x5d, 0x32, 0x7f, 0x62, 0xd3, 0xc3, 0xba, 0x41, 0xf1, 0xf6, 0xd1,
-	0xfd, 0x02, 0x40, 0x76, 0xd2, 0xc7, 0xb7, 0x0e, 0x70, 0xc1, 0xd4, 0xfc, 0x11, 0x5a, 0x2a, 0xb6,
-	0xac, 0xe9, 0x7a, 0x83, 0xef, 0x1c, 0xfa, 0x0a, 0xea, 0x36, 0x76, 0xbc, 0xfd, 0x07, 0x47, 0x47,
-	0x2a, 0xef, 0x8f, 0x74, 0x01, 0x0d, 0x81, 0x74, 0xdf, 0x14, 0xfe, 0x34, 0xa0, 0xf9, 0x2e, 0xf6,
-	0x0a, 0xa5, 0x7f, 0x9f, 0xe3, 0x3f, 0x82, 0x96, 0x4a, 0x46, 0x12, 0xd5, 0x89, 0x19, 0xfb, 0x13,
-	0x63, 0x0b, 0xff, 0x94, 0xcf, 0xf9, 0xff, 0xd0, 0x9d, 0x3f, 0xe0, 0x84, 0x3f, 0x66, 0x2c, 0xeb,
-	0x28, 0x25, 0x2e, 0x1e, 0x85, 0x3e, 0xcd, 0x36, 0x12, 0xf6, 0xee, 0xad, 0x51, 0xd9, 0x65, 0x17,
-	0xfb, 0x2a, 0xcb, 0x8c, 0x5f, 0x76, 0x29, 0xf6, 0xaf, 0x0f, 0xa1, 0xad, 0x90, 0xdf, 0xc8, 0x37,
-	0x08, 0x0d, 0xa0, 0x96, 0x3f, 0xb4, 0xe8, 0xd1, 0x8e, 0xdf, 0x84, 0xee, 0xf1, 0x06, 0xfa, 0x30,
-	0xfb, 0x4f, 0x31, 0x0f, 0xd0, 0xd7, 0x50, 0x15, 0xef, 0x18, 0xea, 0x14, 0x02, 0x68, 0xcf, 0x68,
-	0xf7, 0xe1, 0x0d, 0x1a, 0xd1, 0x3a, 0x16, 0xe0, 0x39, 0x1c, 0xf2, 0xed, 0x8c, 0x36, 0x36, 0xb9,
-	0x72, 0xef, 0x6c, 0x2a, 0x72, 0xef, 0xaf, 0xa0, 0x92, 0xed, 0x14, 0x74, 0xbc, 0xb1, 0x89, 0x84,
-	0xef, 0xc9, 0x96, 0x0d, 0x25, 0x32, 0x17, 0x77, 0x5e, 0xcb, 0x5c, 0x5b, 0x31, 0x5a, 0xe6, 0xfa,
-	0x82, 0x10, 0xd8, 0xd9, 0x7d, 0xd3, 0xb0, 0x0b, 0x57, 0x5d, 0xc3, 0x2e, 0x5e, 0x4c, 0x81, 0x2d,
-	0x66, 0x58, 0xc3, 0xd6, 0xee, 0x98, 0x86, 0xad, 0x0f, 0x3c, 0xaf, 0x5a, 0x55, 0x4c, 0xae, 0x16,
-	0x40, 0x1b, 0xe6, 0x1d, 0x4d, 0x7b, 0xc6, 0xa8, 0x3b, 0xa1, 0x8b, 0x03, 0xb4, 0xc5, 0x66, 0x87,
-	0xef, 0x37, 0xd0, 0xfc, 0x16, 0xd3, 0x37, 0xfc, 0x27, 0x76, 0x14, 0x4e, 0xa2, 0xad, 0x21, 0x3e,
-	0x2a, 0x24, 0xb6, 0x32, 0x37, 0x0f, 0x2e, 0xab, 0xdc, 0xf0, 0xe9, 0xbf, 0x01, 0x00, 0x00, 0xff,
-	0xff, 0x2f, 0x82, 0x27, 0xcd, 0x25, 0x0b, 0x00, 0x00,
+func init() { proto.RegisterFile("provider.proto", fileDescriptor_provider_3bc18efc24f7b381) }
+
+var fileDescriptor_provider_3bc18efc24f7b381 = []byte{
+	// 1051 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xc4, 0x57, 0x4f, 0x73, 0xdb, 0x44,
+	0x14, 0x8f, 0x2c, 0xdb, 0x89, 0x9f, 0xff, 0x20, 0x16, 0x48, 0x14, 0xb5, 0x87, 0x8c, 0xb8, 0x14,
+	0x18, 0x9c, 0x4e, 0x7a, 0x00, 0x3a, 0xed, 0x40, 0x12, 0x2b, 0x90, 0x49, 0xe3, 0x18, 0xb5, 0xa1,
+	0x70, 0x2a, 0x8a, 0xb5, 0x76, 0x76, 0x2c, 0x4b, 0x62, 0xb5, 0x32, 0x13, 0x86, 0x23, 0x97, 0x7e,
+	0x05, 0x66, 0xb8, 0x30, 0xc3, 0x17, 0xe0, 0x13, 0x32, 0xbb, 0x2b, 0xc9, 0xab, 0xd8, 0x4e, 0x42,
+	0xa7, 0x0c, 0xb7, 0x7d, 0xfa, 0xfd, 0xde, 0xbe, 0xbf, 0xfb, 0x76, 0x05, 0x9d, 0x98, 0x46, 0x33,
+	0xe2, 0x63, 0xda, 0x8d, 0x69, 0xc4, 0x22, 0xd4, 0x88, 0xd3, 0x20, 0x9d, 0x12, 0x1a, 0x0f, 0xad,
+	0x56, 0x1c, 0xa4, 0x63, 0x12, 0x4a, 0xc0, 0xba, 0x37, 0x8e, 0xa2, 0x71, 0x80, 0x77, 0x85, 0x74,
+	0x91, 0x8e, 0x76, 0xf1, 0x34, 0x66, 0x57, 0x19, 0x78, 0xff, 0x3a, 0x98, 0x30, 0x9a, 0x0e, 0x99,
+	0x44, 0xed, 0xdf, 0x35, 0x30, 0x0e, 0xa3, 0x70, 0x44, 0xc6, 0x29, 0xc5, 0x2e, 0xfe, 0x29, 0xc5,
+	0x09, 0x43, 0xdf, 0x40, 0x63, 0xe6, 0x51, 0xe2, 0x5d, 0x04, 0x38, 0x31, 0xb5, 0x1d, 0xfd, 0x41,
+	0x73, 0xef, 0xe3, 0x6e, 0x61, 0xbc, 0x7b, 0x9d, 0xdf, 0xfd, 0x2e, 0x27, 0x3b, 0x21, 0xa3, 0x57,
+	0xee, 0x5c, 0xd9, 0x7a, 0x02, 0x9d, 0x32, 0x88, 0x0c, 0xd0, 0x27, 0xf8, 0xca, 0xd4, 0x76, 0xb4,
+	0x07, 0x0d, 0x97, 0x2f, 0xd1, 0xfb, 0x50, 0x9b, 0x79, 0x41, 0x8a, 0xcd, 0x8a, 0xf8, 0x26, 0x85,
+	0xc7, 0x95, 0xcf, 0x35, 0xfb, 0x6f, 0x0d, 0xb6, 0x0b, 0x63, 0x0e, 0xa5, 0x11, 0x3d, 0x25, 0x49,
+	0x42, 0xc2, 0xf1, 0x09, 0xbe, 0x4a, 0xd0, 0xb7, 0xd0, 0x9c, 0xce, 0xc5, 0xcc, 0xcf, 0xdd, 0x65,
+	0x7e, 0x5e, 0x57, 0xed, 0xce, 0xd7, 0xae, 0xba, 0x87, 0x75, 0x00, 0x30, 0x87, 0x10, 0x82, 0x6a,
+	0xe8, 0x4d, 0x71, 0xe6, 0xab, 0x58, 0xa3, 0x1d, 0x68, 0xfa, 0x38, 0x19, 0x52, 0x12, 0x33, 0x12,
+	0x85, 0x99, 0xcb, 0xea, 0x27, 0xfb, 0x37, 0x0d, 0xda, 0xc7, 0xe1, 0x2c, 0x9a, 0x14, 0xe9, 0x34,
+	0x40, 0x67, 0xd1, 0x24, 0x0f, 0x99, 0x45, 0x13, 0xf4, 0x09, 0x54, 0x3d, 0x3a, 0x4e, 0x84, 0x7a,
+	0x73, 0x6f, 0xab, 0x2b, 0x4b, 0xd4, 0xcd, 0x4b, 0xd4, 0x7d, 0x2e, 0x4a, 0xe4, 0x0a, 0x12, 0xb2,
+	0x60, 0x23, 0x6f, 0x04, 0x53, 0x17, 0x7b, 0x14, 0x32, 0x32, 0x61, 0x7d, 0x86, 0x69, 0xc2, 0x5d,
+	0xa9, 0x0a, 0x28, 0x17, 0xed, 0x19, 0x74, 0x72, 0x2f, 0x92, 0x38, 0x0a, 0x13, 0x8c, 0x76, 0xa1,
+	0x4e, 0x31, 0x4b, 0x69, 0x68, 0x6a, 0x37, 0x9b, 0xcd, 0x68, 0xe8, 0x11, 0x6c, 0x8c, 0x3c, 0x12,
+	0xa4, 0x14, 0x73, 0x4f, 0x75, 0xa1, 0xa2, 0x64, 0xf7, 0x12, 0x0f, 0x27, 0x47, 0x12, 0x77, 0x0b,
+	0xa2, 0xfd, 0x0b, 0xb4, 0x04, 0xa2, 0x04, 0x9f, 0x9b, 0x6c, 0xb8, 0x7c, 0xc9, 0x83, 0x8f, 0x02,
+	0xff, 0xf6, 0xe0, 0x39, 0x89, 0x93, 0x43, 0xfc, 0x73, 0x62, 0xea, 0xb7, 0x90, 0x39, 0xc9, 0x4e,
+	0xa1, 0x9d, 0xd9, 0x9e, 0x87, 0x4c, 0xc2, 0x38, 0x65, 0xc9, 0xad, 0x21, 0x4b, 0xda, 0x9b, 0x85,
+	0x7c, 0x00, 0x2d, 0x15, 0xc9, 0x0a, 0x16, 0x63, 0xca, 0xf2, 0x3e, 0x2f, 0x64, 0xb4, 0xc9, 0x8b,
+	0xe0, 0x25, 0x45, 0xeb, 0x64, 0x92, 0xfd, 0x5a, 0x83, 0x66, 0x8f, 0x8c, 0x46, 0x79, 0xda, 0x3a,
+	0x50, 0x21, 0x7e, 0xa6, 0x5d, 0x21, 0x7e, 0x9e, 0xc6, 0xca, 0x62, 0x1a, 0xf5, 0x7f, 0x93, 0xc6,
+	0xea, 0x5d, 0xd2, 0xf8, 0xa7, 0x06, 0xad, 0x41, 0xe6, 0x30, 0xf7, 0x09, 0x3d, 0x84, 0xea, 0x84,
+	0x84, 0xd2, 0x9d, 0xce, 0xde, 0x7d, 0x25, 0x23, 0x2a, 0xad, 0x7b, 0x42, 0x42, 0xdf, 0x15, 0x4c,
+	0xfb, 0x47, 0xa8, 0x72, 0x09, 0xad, 0x83, 0xbe, 0xdf, 0xeb, 0x19, 0x6b, 0xe8, 0x1d, 0x68, 0xee,
+	0xf7, 0x7a, 0xaf, 0x5c, 0x67, 0xf0, 0x6c, 0xff, 0xd0, 0x31, 0x34, 0x04, 0x50, 0xef, 0x39, 0xcf,
+	0x9c, 0x17, 0x8e, 0x51, 0x41, 0x08, 0x3a, 0x72, 0x5d, 0xe0, 0x3a, 0xc7, 0xcf, 0x07, 0xbd, 0xfd,
+	0x17, 0x8e, 0x51, 0xe5, 0xb8, 0x5c, 0x17, 0x78, 0xcd, 0xfe, 0x43, 0x87, 0x96, 0x4c, 0x58, 0x56,
+	0x6b, 0x0b, 0x36, 0x28, 0x8e, 0x03, 0x6f, 0x98, 0xcd, 0xac, 0x86, 0x5b, 0xc8, 0xfc, 0x98, 0x24,
+	0x4c, 0x8e, 0xb3, 0x8a, 0x80, 0x72, 0x11, 0x3d, 0x84, 0xf7, 0x7c, 0x1c, 0x60, 0x86, 0x0f, 0xf0,
+	0x28, 0xe2, 0x13, 0x4d, 0x68, 0x88, 0xa4, 0x6e, 0xb8, 0xcb, 0x20, 0xf4, 0x14, 0xd6, 0x87, 0x97,
+	0x5e, 0x38, 0xc6, 0x32, 0x9b, 0x9d, 0xbd, 0x0f, 0x95, 0x7c, 0xa8, 0x1e, 0x09, 0xe1, 0x50, 0x52,
+	0xdd, 0x5c, 0x07, 0x9d, 0x42, 0xcb, 0xc7, 0xcc, 0x23, 0x01, 0xf6, 0x39, 0x6e, 0xd6, 0x44, 0x97,
+	0x7d, 0xb4, 0x72, 0x0f, 0x85, 0x2b, 0xa7, 0x6b, 0x49, 0xdd, 0xfa, 0x1e, 0xde, 0x5d, 0xa0, 0x2c,
+	0x99, 0xb1, 0x9f, 0xaa, 0x33, 0xb6, 0xdc, 0xd4, 0x6a, 0x09, 0xd5, 0xe1, 0xfb, 0x14, 0x9a, 0x4a,
+	0x00, 0xc8, 0x80, 0x56, 0xef, 0xf8, 0xe8, 0xe8, 0xd5, 0x79, 0xff, 0xa4, 0x7f, 0xf6, 0xb2, 0x6f,
+	0xac, 0xa1, 0x36, 0x34, 0xc4, 0x97, 0xfe, 0x59, 0x9f, 0x17, 0x34, 0x17, 0x9f, 0x9f, 0x9d, 0x3a,
+	0x46, 0xc5, 0x66, 0xd0, 0x3e, 0xa4, 0xd8, 0x63, 0x78, 0xf5, 0x20, 0xf8, 0x0c, 0x20, 0x3b, 0x17,
+	0x04, 0xdf, 0x3a, 0x0e, 0x14, 0x2a, 0x2f, 0x27, 0x23, 0x53, 0x1c, 0xa5, 0x4c, 0x14, 0x4a, 0x73,
+	0x73, 0xd1, 0xfe, 0x01, 0x3a, 0xb9, 0xd5, 0xac, 0x2d, 0xae, 0x1f, 0xa4, 0x37, 0x35, 0x6a, 0x5f,
+	0x42, 0xd3, 0xc5, 0x9e, 0x7f, 0xf7, 0x03, 0x5a, 0xb6, 0xa4, 0xdf, 0xdd, 0xd2, 0x4b, 0x68, 0x49,
+	0x4b, 0x6f, 0x3b, 0x84, 0xbf, 0x34, 0x68, 0x9f, 0xc7, 0xbe, 0x52, 0x94, 0xff, 0x71, 0xcc, 0xa8,
+	0x55, 0xac, 0x95, 0xab, 0x78, 0x0c, 0x9d, 0xdc, 0xcd, 0x2c, 0x05, 0xe5, 0x90, 0xb5, 0xbb, 0x87,
+	0xcc, 0x6f, 0xe3, 0x9e, 0x38, 0xc5, 0xff, 0x7d, 0xe1, 0xd4, 0x88, 0xaa, 0xe5, 0x88, 0x7e, 0x85,
+	0x2d, 0xf1, 0x08, 0x71, 0x71, 0x12, 0xa5, 0x74, 0x88, 0x8f, 0x43, 0xc2, 0x8e, 0xc4, 0xa9, 0x7d,
+	0x6b, 0xd5, 0xe5, 0xd6, 0xe5, 0x65, 0xc2, 0x7d, 0x16, 0x43, 0x2e, 0x13, 0xf7, 0x5e, 0xd7, 0xc0,
+	0xc8, 0x2d, 0x0f, 0xf2, 0xa7, 0xc3, 0x01, 0x34, 0x8a, 0x07, 0x12, 0xba, 0x77, 0xc3, 0xf3, 0xce,
+	0xda, 0x5c, 0xb0, 0xee, 0xf0, 0xf7, 0xa5, 0xbd, 0x86, 0xbe, 0x84, 0xba, 0x7c, 0x64, 0x20, 0x53,
+	0xd9, 0xa0, 0xf4, 0xfa, 0xb1, 0xb6, 0x97, 0x20, 0xb2, 0xaa, 0xf6, 0x1a, 0x7a, 0x02, 0x35, 0x71,
+	0x75, 0xa2, 0x85, 0x6b, 0x36, 0x57, 0x37, 0x17, 0x81, 0x42, 0xfb, 0x0b, 0xa8, 0x8a, 0xfb, 0x69,
+	0x73, 0x61, 0x7a, 0x4a, 0xdd, 0xad, 0x15, 0x53, 0x55, 0x7a, 0x2e, 0x07, 0x45, 0xc9, 0xf3, 0xd2,
+	0xc4, 0xb2, 0xb6, 0x97, 0x20, 0xaa, 0x6d, 0x7e, 0x48, 0x4b, 0xb6, 0x95, 0xf9, 0x60, 0x6d, 0x2d,
+	0x7c, 0x57, 0x6d, 0xcb, 0xf6, 0x2e, 0xd9, 0x2e, 0x1d, 0x4c, 0x6b, 0x7b, 0x09, 0xa2, 0x64, 0xad,
+	0x2e, 0x7b, 0xba, 0xb4, 0x41, 0xa9, 0xcd, 0x6f, 0x28, 0xda, 0x63, 0xa8, 0x1f, 0x7a, 0xe1, 0x10,
+	0x07, 0x68, 0x05, 0xe7, 0x06, 0xdd, 0xaf, 0xa0, 0xfd, 0x35, 0x66, 0x03, 0xf1, 0xf3, 0x71, 0x1c,
+	0x8e, 0xa2, 0x95, 0x5b, 0x7c, 0xa0, 0xde, 0x30, 0x05, 0xdd, 0x5e, 0xbb, 0xa8, 0x0b, 0xe2, 0xa3,
+	0x7f, 0x06, 0x00, 0x3d, 0x91, 0x98, 0xfb, 0xdd, 0x0c, 0x00, 0x00,
 }
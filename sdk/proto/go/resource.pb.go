@@ -34,6 +34,7 @@ type ReadResourceRequest struct {
 	Properties           *_struct.Struct `protobuf:"bytes,5,opt,name=properties" json:"properties,omitempty"`
 	Dependencies         []string        `protobuf:"bytes,6,rep,name=dependencies" json:"dependencies,omitempty"`
 	Provider             string          `protobuf:"bytes,7,opt,name=provider" json:"provider,omitempty"`
+	Version              string          `protobuf:"bytes,8,opt,name=version" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -43,7 +44,7 @@ func (m *ReadResourceRequest) Reset()         { *m = ReadResourceRequest{} }
 func (m *ReadResourceRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadResourceRequest) ProtoMessage()    {}
 func (*ReadResourceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_5aa1dff965971124, []int{0}
+	return fileDescriptor_resource_520fd247aa4e715b, []int{0}
 }
 func (m *ReadResourceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadResourceRequest.Unmarshal(m, b)
@@ -112,6 +113,13 @@ func (m *ReadResourceRequest) GetProvider() string {
 	return ""
 }
 
+func (m *ReadResourceRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
 // ReadResourceResponse contains the result of reading a resource's state.
 type ReadResourceResponse struct {
 	Urn                  string          `protobuf:"bytes,1,opt,name=urn" json:"urn,omitempty"`
@@ -125,7 +133,7 @@ func (m *ReadResourceResponse) Reset()         { *m = ReadResourceResponse{} }
 func (m *ReadResourceResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResourceResponse) ProtoMessage()    {}
 func (*ReadResourceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_5aa1dff965971124, []int{1}
+	return fileDescriptor_resource_520fd247aa4e715b, []int{1}
 }
 func (m *ReadResourceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadResourceResponse.Unmarshal(m, b)
@@ -161,24 +169,31 @@ func (m *ReadResourceResponse) GetProperties() *_struct.Struct {
 
 // RegisterResourceRequest contains information about a resource object that was newly allocated.
 type RegisterResourceRequest struct {
-	Type                 string          `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
-	Name                 string          `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	Parent               string          `protobuf:"bytes,3,opt,name=parent" json:"parent,omitempty"`
-	Custom               bool            `protobuf:"varint,4,opt,name=custom" json:"custom,omitempty"`
-	Object               *_struct.Struct `protobuf:"bytes,5,opt,name=object" json:"object,omitempty"`
-	Protect              bool            `protobuf:"varint,6,opt,name=protect" json:"protect,omitempty"`
-	Dependencies         []string        `protobuf:"bytes,7,rep,name=dependencies" json:"dependencies,omitempty"`
-	Provider             string          `protobuf:"bytes,8,opt,name=provider" json:"provider,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Type                 string                                  `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Name                 string                                  `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Parent               string                                  `protobuf:"bytes,3,opt,name=parent" json:"parent,omitempty"`
+	Custom               bool                                    `protobuf:"varint,4,opt,name=custom" json:"custom,omitempty"`
+	Object               *_struct.Struct                         `protobuf:"bytes,5,opt,name=object" json:"object,omitempty"`
+	Protect              bool                                    `protobuf:"varint,6,opt,name=protect" json:"protect,omitempty"`
+	Dependencies         []string                                `protobuf:"bytes,7,rep,name=dependencies" json:"dependencies,omitempty"`
+	Provider             string                                  `protobuf:"bytes,8,opt,name=provider" json:"provider,omitempty"`
+	ImportId             string                                  `protobuf:"bytes,9,opt,name=importId" json:"importId,omitempty"`
+	IgnoreChanges        []string                                `protobuf:"bytes,10,rep,name=ignoreChanges" json:"ignoreChanges,omitempty"`
+	Aliases              []string                                `protobuf:"bytes,11,rep,name=aliases" json:"aliases,omitempty"`
+	CustomTimeouts       *RegisterResourceRequest_CustomTimeouts `protobuf:"bytes,12,opt,name=customTimeouts" json:"customTimeouts,omitempty"`
+	DeleteBeforeReplace  bool                                    `protobuf:"varint,13,opt,name=deleteBeforeReplace" json:"deleteBeforeReplace,omitempty"`
+	RetainOnDelete       bool                                    `protobuf:"varint,14,opt,name=retainOnDelete" json:"retainOnDelete,omitempty"`
+	Version              string                                  `protobuf:"bytes,15,opt,name=version" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                `json:"-"`
+	XXX_unrecognized     []byte                                  `json:"-"`
+	XXX_sizecache        int32                                   `json:"-"`
 }
 
 func (m *RegisterResourceRequest) Reset()         { *m = RegisterResourceRequest{} }
 func (m *RegisterResourceRequest) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceRequest) ProtoMessage()    {}
 func (*RegisterResourceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_5aa1dff965971124, []int{2}
+	return fileDescriptor_resource_520fd247aa4e715b, []int{2}
 }
 func (m *RegisterResourceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceRequest.Unmarshal(m, b)
@@ -254,6 +269,114 @@ func (m *RegisterResourceRequest) GetProvider() string {
 	return ""
 }
 
+func (m *RegisterResourceRequest) GetImportId() string {
+	if m != nil {
+		return m.ImportId
+	}
+	return ""
+}
+
+func (m *RegisterResourceRequest) GetIgnoreChanges() []string {
+	if m != nil {
+		return m.IgnoreChanges
+	}
+	return nil
+}
+
+func (m *RegisterResourceRequest) GetAliases() []string {
+	if m != nil {
+		return m.Aliases
+	}
+	return nil
+}
+
+func (m *RegisterResourceRequest) GetCustomTimeouts() *RegisterResourceRequest_CustomTimeouts {
+	if m != nil {
+		return m.CustomTimeouts
+	}
+	return nil
+}
+
+func (m *RegisterResourceRequest) GetDeleteBeforeReplace() bool {
+	if m != nil {
+		return m.DeleteBeforeReplace
+	}
+	return false
+}
+
+func (m *RegisterResourceRequest) GetRetainOnDelete() bool {
+	if m != nil {
+		return m.RetainOnDelete
+	}
+	return false
+}
+
+func (m *RegisterResourceRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+// CustomTimeouts allows a user to be able to create a set of custom timeout parameters.  Each timeout is a
+// duration string as accepted by Go's time.ParseDuration (e.g. "5m" or "1h30m"); an empty string uses the
+// provider's default.
+type RegisterResourceRequest_CustomTimeouts struct {
+	Create               string   `protobuf:"bytes,1,opt,name=create" json:"create,omitempty"`
+	Update               string   `protobuf:"bytes,2,opt,name=update" json:"update,omitempty"`
+	Delete               string   `protobuf:"bytes,3,opt,name=delete" json:"delete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterResourceRequest_CustomTimeouts) Reset() {
+	*m = RegisterResourceRequest_CustomTimeouts{}
+}
+func (m *RegisterResourceRequest_CustomTimeouts) String() string { return proto.CompactTextString(m) }
+func (*RegisterResourceRequest_CustomTimeouts) ProtoMessage()    {}
+func (*RegisterResourceRequest_CustomTimeouts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_resource_520fd247aa4e715b, []int{2, 0}
+}
+func (m *RegisterResourceRequest_CustomTimeouts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterResourceRequest_CustomTimeouts.Unmarshal(m, b)
+}
+func (m *RegisterResourceRequest_CustomTimeouts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterResourceRequest_CustomTimeouts.Marshal(b, m, deterministic)
+}
+func (dst *RegisterResourceRequest_CustomTimeouts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterResourceRequest_CustomTimeouts.Merge(dst, src)
+}
+func (m *RegisterResourceRequest_CustomTimeouts) XXX_Size() int {
+	return xxx_messageInfo_RegisterResourceRequest_CustomTimeouts.Size(m)
+}
+func (m *RegisterResourceRequest_CustomTimeouts) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterResourceRequest_CustomTimeouts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterResourceRequest_CustomTimeouts proto.InternalMessageInfo
+
+func (m *RegisterResourceRequest_CustomTimeouts) GetCreate() string {
+	if m != nil {
+		return m.Create
+	}
+	return ""
+}
+
+func (m *RegisterResourceRequest_CustomTimeouts) GetUpdate() string {
+	if m != nil {
+		return m.Update
+	}
+	return ""
+}
+
+func (m *RegisterResourceRequest_CustomTimeouts) GetDelete() string {
+	if m != nil {
+		return m.Delete
+	}
+	return ""
+}
+
 // RegisterResourceResponse is returned by the engine after a resource has finished being initialized.  It includes the
 // auto-assigned URN, the provider-assigned ID, and any other properties initialized by the engine.
 type RegisterResourceResponse struct {
@@ -271,7 +394,7 @@ func (m *RegisterResourceResponse) Reset()         { *m = RegisterResourceRespon
 func (m *RegisterResourceResponse) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceResponse) ProtoMessage()    {}
 func (*RegisterResourceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_5aa1dff965971124, []int{3}
+	return fileDescriptor_resource_520fd247aa4e715b, []int{3}
 }
 func (m *RegisterResourceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceResponse.Unmarshal(m, b)
@@ -339,7 +462,7 @@ func (m *RegisterResourceOutputsRequest) Reset()         { *m = RegisterResource
 func (m *RegisterResourceOutputsRequest) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceOutputsRequest) ProtoMessage()    {}
 func (*RegisterResourceOutputsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_5aa1dff965971124, []int{4}
+	return fileDescriptor_resource_520fd247aa4e715b, []int{4}
 }
 func (m *RegisterResourceOutputsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceOutputsRequest.Unmarshal(m, b)
@@ -377,6 +500,7 @@ func init() {
 	proto.RegisterType((*ReadResourceRequest)(nil), "pulumirpc.ReadResourceRequest")
 	proto.RegisterType((*ReadResourceResponse)(nil), "pulumirpc.ReadResourceResponse")
 	proto.RegisterType((*RegisterResourceRequest)(nil), "pulumirpc.RegisterResourceRequest")
+	proto.RegisterType((*RegisterResourceRequest_CustomTimeouts)(nil), "pulumirpc.RegisterResourceRequest.CustomTimeouts")
 	proto.RegisterType((*RegisterResourceResponse)(nil), "pulumirpc.RegisterResourceResponse")
 	proto.RegisterType((*RegisterResourceOutputsRequest)(nil), "pulumirpc.RegisterResourceOutputsRequest")
 }
@@ -552,39 +676,49 @@ var _ResourceMonitor_serviceDesc = grpc.ServiceDesc{
 	Metadata: "resource.proto",
 }
 
-func init() { proto.RegisterFile("resource.proto", fileDescriptor_resource_5aa1dff965971124) }
-
-var fileDescriptor_resource_5aa1dff965971124 = []byte{
-	// 491 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x8c, 0x94, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xc7, 0x6b, 0xbb, 0x38, 0xe9, 0x50, 0x85, 0x6a, 0x41, 0xad, 0x31, 0xa8, 0x54, 0xe6, 0x02,
-	0x17, 0x47, 0x94, 0x03, 0x47, 0x4e, 0x1c, 0x38, 0x20, 0x84, 0x39, 0x83, 0xe4, 0xd8, 0x43, 0x64,
-	0x48, 0xbc, 0xcb, 0x7e, 0x54, 0xea, 0xd3, 0xf0, 0x66, 0x9c, 0x78, 0x0c, 0x0e, 0x78, 0x77, 0xbd,
-	0x26, 0xfe, 0x68, 0xd3, 0x53, 0xe6, 0x6b, 0x67, 0xe7, 0xff, 0xf3, 0x6c, 0x60, 0xc1, 0x51, 0x50,
-	0xc5, 0x0b, 0x4c, 0x19, 0xa7, 0x92, 0x92, 0x23, 0xa6, 0x36, 0x6a, 0x5b, 0x71, 0x56, 0xc4, 0x4f,
-	0xd6, 0x94, 0xae, 0x37, 0xb8, 0x34, 0x89, 0x95, 0xfa, 0xb6, 0xc4, 0x2d, 0x93, 0xd7, 0xb6, 0x2e,
-	0x7e, 0x3a, 0x4c, 0x0a, 0xc9, 0x55, 0x21, 0xdb, 0xec, 0xa2, 0xf9, 0xb9, 0xaa, 0x4a, 0xe4, 0xd6,
-	0x4f, 0x7e, 0x7b, 0xf0, 0x30, 0xc3, 0xbc, 0xcc, 0xda, 0xcb, 0x32, 0xfc, 0xa9, 0x50, 0x48, 0xb2,
-	0x00, 0xbf, 0x2a, 0x23, 0xef, 0xc2, 0x7b, 0x71, 0x94, 0x35, 0x16, 0x21, 0x70, 0x28, 0xaf, 0x19,
-	0x46, 0xbe, 0x89, 0x18, 0x5b, 0xc7, 0xea, 0x7c, 0x8b, 0x51, 0x60, 0x63, 0xda, 0x26, 0xa7, 0x10,
-	0xb2, 0x9c, 0x63, 0x2d, 0xa3, 0x43, 0x13, 0x6d, 0x3d, 0xf2, 0x06, 0xa0, 0xb9, 0x90, 0x21, 0x97,
-	0x15, 0x8a, 0xe8, 0x5e, 0x93, 0xbb, 0x7f, 0x79, 0x96, 0xda, 0x51, 0x53, 0x37, 0x6a, 0xfa, 0xd9,
-	0x8c, 0x9a, 0xed, 0x94, 0x92, 0x04, 0x8e, 0x4b, 0x64, 0x58, 0x97, 0x58, 0x17, 0xfa, 0x68, 0x78,
-	0x11, 0x34, 0x6d, 0x7b, 0x31, 0x12, 0xc3, 0xdc, 0xc9, 0x8a, 0x66, 0xe6, 0xda, 0xce, 0x4f, 0x72,
-	0x78, 0xd4, 0xd7, 0x27, 0x18, 0xad, 0x05, 0x92, 0x13, 0x08, 0x14, 0xaf, 0x5b, 0x85, 0xda, 0x1c,
-	0x8c, 0xe8, 0xdf, 0x79, 0xc4, 0xe4, 0xaf, 0x07, 0x67, 0x19, 0xae, 0x2b, 0x21, 0x91, 0x0f, 0x39,
-	0x3a, 0x6e, 0xde, 0x04, 0x37, 0x7f, 0x92, 0x5b, 0xd0, 0xe3, 0xd6, 0xc4, 0x0b, 0x25, 0x24, 0xdd,
-	0x1a, 0x9e, 0xf3, 0xac, 0xf5, 0xc8, 0x12, 0x42, 0xba, 0xfa, 0x8e, 0x85, 0xdc, 0xc7, 0xb2, 0x2d,
-	0x23, 0x11, 0xcc, 0x74, 0x4a, 0x9f, 0x08, 0x4d, 0x27, 0xe7, 0x8e, 0x08, 0xcf, 0xf6, 0x10, 0x9e,
-	0x0f, 0x08, 0xff, 0xf2, 0x20, 0x1a, 0xcb, 0xbf, 0x11, 0xb3, 0xdd, 0x2c, 0xbf, 0xdb, 0xac, 0xff,
-	0x4a, 0x82, 0xbb, 0x29, 0x69, 0x90, 0x08, 0x99, 0xaf, 0x36, 0xe8, 0x90, 0x58, 0x4f, 0x2b, 0xb4,
-	0x96, 0xde, 0x2f, 0x2d, 0xc1, 0xb9, 0x09, 0xc2, 0xf9, 0x70, 0xc0, 0x8f, 0x4a, 0x32, 0x25, 0x85,
-	0xfb, 0x4c, 0xe3, 0x31, 0x5f, 0xc1, 0x8c, 0xda, 0x9a, 0x7d, 0xab, 0xe0, 0xea, 0x2e, 0xff, 0xf8,
-	0xf0, 0xc0, 0xf5, 0xff, 0x40, 0xeb, 0x4a, 0x52, 0x4e, 0xde, 0x42, 0xf8, 0xbe, 0xbe, 0xa2, 0x3f,
-	0x9a, 0xf1, 0xd2, 0xee, 0x01, 0xa7, 0x36, 0xd4, 0x5e, 0x1e, 0x3f, 0x9e, 0xc8, 0x58, 0x7c, 0xc9,
-	0x01, 0xf9, 0x04, 0xc7, 0xbb, 0xfb, 0x4b, 0xce, 0x77, 0x8a, 0x27, 0x1e, 0x6e, 0xfc, 0xec, 0xc6,
-	0x7c, 0xd7, 0xf2, 0x0b, 0x9c, 0x0c, 0x71, 0x90, 0xa4, 0x77, 0x6c, 0x72, 0x97, 0xe3, 0xe7, 0xb7,
-	0xd6, 0x74, 0xed, 0xbf, 0x8e, 0x5f, 0x43, 0x4b, 0x9b, 0xbc, 0xbc, 0xa5, 0x43, 0xff, 0x8b, 0xc4,
-	0xa7, 0x23, 0xdc, 0xef, 0xf4, 0x9f, 0x5c, 0x72, 0xb0, 0x0a, 0x4d, 0xe4, 0xf5, 0xbf, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0xa3, 0x97, 0x3d, 0x93, 0x21, 0x05, 0x00, 0x00,
+func init() { proto.RegisterFile("resource.proto", fileDescriptor_resource_520fd247aa4e715b) }
+
+var fileDescriptor_resource_520fd247aa4e715b = []byte{
+	// 648 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x8c, 0x55, 0xc1, 0x6e, 0xd3, 0x40,
+	0x10, 0x6d, 0x9c, 0xd6, 0x49, 0xa6, 0x6d, 0x5a, 0x6d, 0x51, 0xba, 0x18, 0x54, 0x2a, 0x83, 0x50,
+	0xb9, 0xb8, 0xb4, 0x1c, 0x38, 0x22, 0x51, 0x38, 0xf4, 0x80, 0x2a, 0x0c, 0x07, 0x38, 0x80, 0xe4,
+	0xd8, 0xd3, 0x60, 0x48, 0xbc, 0xcb, 0xee, 0x3a, 0x52, 0x8f, 0x7c, 0x09, 0x3f, 0xc7, 0x47, 0x70,
+	0x44, 0xbb, 0xeb, 0x0d, 0xb1, 0x93, 0x34, 0xbd, 0xed, 0x9b, 0x37, 0x9e, 0x9d, 0x79, 0xfb, 0x26,
+	0x81, 0xbe, 0x40, 0xc9, 0x4a, 0x91, 0x62, 0xc4, 0x05, 0x53, 0x8c, 0xf4, 0x78, 0x39, 0x2e, 0x27,
+	0xb9, 0xe0, 0x69, 0xf0, 0x60, 0xc4, 0xd8, 0x68, 0x8c, 0xa7, 0x86, 0x18, 0x96, 0xd7, 0xa7, 0x38,
+	0xe1, 0xea, 0xc6, 0xe6, 0x05, 0x0f, 0x9b, 0xa4, 0x54, 0xa2, 0x4c, 0x55, 0xc5, 0xf6, 0xb9, 0x60,
+	0xd3, 0x3c, 0x43, 0x61, 0x71, 0xf8, 0xb7, 0x05, 0x07, 0x31, 0x26, 0x59, 0x5c, 0x5d, 0x16, 0xe3,
+	0xcf, 0x12, 0xa5, 0x22, 0x7d, 0xf0, 0xf2, 0x8c, 0xb6, 0x8e, 0x5b, 0x27, 0xbd, 0xd8, 0xcb, 0x33,
+	0x42, 0x60, 0x53, 0xdd, 0x70, 0xa4, 0x9e, 0x89, 0x98, 0xb3, 0x8e, 0x15, 0xc9, 0x04, 0x69, 0xdb,
+	0xc6, 0xf4, 0x99, 0x0c, 0xc0, 0xe7, 0x89, 0xc0, 0x42, 0xd1, 0x4d, 0x13, 0xad, 0x10, 0x79, 0x09,
+	0xc0, 0x05, 0xe3, 0x28, 0x54, 0x8e, 0x92, 0x6e, 0x1d, 0xb7, 0x4e, 0xb6, 0xcf, 0x0f, 0x23, 0xdb,
+	0x6a, 0xe4, 0x5a, 0x8d, 0x3e, 0x98, 0x56, 0xe3, 0xb9, 0x54, 0x12, 0xc2, 0x4e, 0x86, 0x1c, 0x8b,
+	0x0c, 0x8b, 0x54, 0x7f, 0xea, 0x1f, 0xb7, 0x4f, 0x7a, 0x71, 0x2d, 0x46, 0x02, 0xe8, 0xba, 0xb1,
+	0x68, 0xc7, 0x5c, 0x3b, 0xc3, 0x84, 0x42, 0x67, 0x8a, 0x42, 0xe6, 0xac, 0xa0, 0x5d, 0x43, 0x39,
+	0x18, 0x26, 0x70, 0xaf, 0x3e, 0xb9, 0xe4, 0xac, 0x90, 0x48, 0xf6, 0xa1, 0x5d, 0x8a, 0xa2, 0x9a,
+	0x5d, 0x1f, 0x1b, 0xcd, 0x7b, 0x77, 0x6e, 0x3e, 0xfc, 0xb5, 0x05, 0x87, 0x31, 0x8e, 0x72, 0xa9,
+	0x50, 0x34, 0x15, 0x76, 0x8a, 0xb6, 0x96, 0x28, 0xea, 0x2d, 0x55, 0xb4, 0x5d, 0x53, 0x74, 0x00,
+	0x7e, 0x5a, 0x4a, 0xc5, 0x26, 0x46, 0xe9, 0x6e, 0x5c, 0x21, 0x72, 0x0a, 0x3e, 0x1b, 0x7e, 0xc7,
+	0x54, 0xad, 0x53, 0xb9, 0x4a, 0xd3, 0x0a, 0x69, 0x4a, 0x7f, 0xe1, 0x9b, 0x4a, 0x0e, 0x2e, 0x68,
+	0xdf, 0x59, 0xa3, 0x7d, 0xb7, 0xa1, 0x7d, 0x00, 0xdd, 0x7c, 0xc2, 0x99, 0x50, 0x97, 0x19, 0xed,
+	0x59, 0xce, 0x61, 0xf2, 0x04, 0x76, 0xf3, 0x51, 0xc1, 0x04, 0x5e, 0x7c, 0x4b, 0x8a, 0x11, 0x4a,
+	0x0a, 0xa6, 0x78, 0x3d, 0xa8, 0x7b, 0x4b, 0xc6, 0x79, 0x22, 0x51, 0xd2, 0x6d, 0xc3, 0x3b, 0x48,
+	0x3e, 0x43, 0xdf, 0x0e, 0xfc, 0x31, 0x9f, 0x20, 0x2b, 0x95, 0xa4, 0x3b, 0x66, 0xdc, 0xb3, 0x68,
+	0xb6, 0x27, 0xd1, 0x0a, 0xe9, 0xa3, 0x8b, 0xda, 0x87, 0x71, 0xa3, 0x10, 0x79, 0x0e, 0x07, 0x19,
+	0x8e, 0x51, 0xe1, 0x6b, 0xbc, 0x66, 0x02, 0x63, 0xe4, 0xe3, 0x24, 0x45, 0xba, 0x6b, 0xc4, 0x59,
+	0x46, 0x91, 0xa7, 0x7a, 0x5b, 0x55, 0x92, 0x17, 0x57, 0xc5, 0x1b, 0x43, 0xd3, 0xbe, 0x49, 0x6e,
+	0x44, 0xe7, 0xcd, 0xb8, 0x57, 0x33, 0x63, 0xf0, 0x09, 0xfa, 0xf5, 0xae, 0xcc, 0xfb, 0x0a, 0x4c,
+	0x94, 0x73, 0x48, 0x85, 0x74, 0xbc, 0xe4, 0x59, 0xa2, 0x9c, 0x4b, 0x2a, 0xa4, 0xe3, 0xb6, 0x35,
+	0xe7, 0x13, 0x8b, 0xc2, 0xdf, 0x2d, 0xa0, 0x8b, 0x42, 0xac, 0xf4, 0xba, 0x5d, 0x7c, 0x6f, 0xb6,
+	0xf8, 0xff, 0xed, 0xd4, 0xbe, 0x9b, 0x9d, 0x06, 0xe0, 0x4b, 0x95, 0x0c, 0xc7, 0xe8, 0x7c, 0x69,
+	0x91, 0x9e, 0xdd, 0x9e, 0xf4, 0xfa, 0x9b, 0xa7, 0xac, 0x60, 0x88, 0x70, 0xd4, 0x6c, 0xf0, 0xaa,
+	0x54, 0x5c, 0x3f, 0x4d, 0xb5, 0x2b, 0x8b, 0x6d, 0x9e, 0x41, 0x87, 0xd9, 0x9c, 0x75, 0xfb, 0xe8,
+	0xf2, 0xce, 0xff, 0x78, 0xb0, 0xe7, 0xea, 0xbf, 0x63, 0x45, 0xae, 0x98, 0x20, 0xaf, 0xc0, 0xbf,
+	0x2c, 0xa6, 0xec, 0x07, 0x12, 0x3a, 0xe7, 0x1b, 0x1b, 0xaa, 0x2e, 0x0f, 0xee, 0x2f, 0x61, 0xac,
+	0x7c, 0xe1, 0x06, 0x79, 0x0f, 0x3b, 0xf3, 0x3f, 0x22, 0xe4, 0xa8, 0x66, 0xbf, 0x85, 0xdf, 0xd5,
+	0xe0, 0xd1, 0x4a, 0x7e, 0x56, 0xf2, 0x0b, 0xec, 0x37, 0xe5, 0x20, 0xe1, 0x7a, 0x57, 0x07, 0x8f,
+	0x6f, 0xcd, 0x99, 0x95, 0xff, 0x0a, 0x87, 0x2b, 0xd4, 0x26, 0xcf, 0x6e, 0xa9, 0x50, 0x7f, 0x91,
+	0x60, 0xb0, 0x20, 0xf7, 0x5b, 0xfd, 0x1f, 0x14, 0x6e, 0x0c, 0x7d, 0x13, 0x79, 0xf1, 0x6f, 0x00,
+	0x41, 0x73, 0x7a, 0xbd, 0xc0, 0x06, 0x00, 0x00,
 }